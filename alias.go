@@ -0,0 +1,44 @@
+package envload
+
+import (
+	"reflect"
+	"strings"
+)
+
+// aliasKeys returns the alternative variable names listed in the field's `alias` tag,
+// in lookup order: `alias:"DB_URL|POSTGRES_URL"` -> [DB_URL POSTGRES_URL].
+func aliasKeys(field reflect.StructField) []string {
+	aliases := field.Tag.Get("alias")
+	if aliases == "" {
+		return nil
+	}
+
+	return strings.Split(aliases, "|")
+}
+
+// lookupAlias returns the value of the first alias of the field that is set in envMap,
+// recording that alias as the source key.
+// Resolution order is the `env` name, then each alias in turn, then the `default` tag;
+// like the `env` name, an alias set to an empty value ends the lookup unless
+// empty values are treated as unset (see [fieldResolver.lookupKey]).
+func (resolver *fieldResolver) lookupAlias(envMap map[string]string) (string, bool) {
+	for _, alias := range resolver.aliasKeys() {
+		if rawValue, ok := resolver.lookupKey(envMap, alias); ok {
+			resolver.sourceKey = alias
+			return rawValue, true
+		}
+	}
+
+	return "", false
+}
+
+// aliasKeys returns the aliases of the current field behind the `envPrefix` of the
+// structs enclosing it, like its `env` name.
+func (resolver *fieldResolver) aliasKeys() []string {
+	aliases := aliasKeys(resolver.field)
+	for i := range aliases {
+		aliases[i] = resolver.prefix + aliases[i]
+	}
+
+	return aliases
+}
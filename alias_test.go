@@ -0,0 +1,76 @@
+package envload
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_AliasResolutionOrder(t *testing.T) {
+	type Config struct {
+		DatabaseURL string `env:"DATABASE_URL" alias:"DB_URL|POSTGRES_URL" default:"postgres://localhost"`
+	}
+
+	tests := []struct {
+		name     string
+		envMap   map[string]string
+		expected string
+	}{
+		{"env name wins", map[string]string{"DATABASE_URL": "a", "DB_URL": "b", "POSTGRES_URL": "c"}, "a"},
+		{"first alias", map[string]string{"DB_URL": "b", "POSTGRES_URL": "c"}, "b"},
+		{"second alias", map[string]string{"POSTGRES_URL": "c"}, "c"},
+		{"default", map[string]string{}, "postgres://localhost"},
+		{"empty alias ends lookup", map[string]string{"DB_URL": "", "POSTGRES_URL": "c"}, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cfg Config
+			if err := Parse(test.envMap, &cfg); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if cfg.DatabaseURL != test.expected {
+				t.Errorf("Expected %q, got %q", test.expected, cfg.DatabaseURL)
+			}
+		})
+	}
+}
+
+func Test_AliasRequired(t *testing.T) {
+	type Config struct {
+		Port int `env:"PORT" alias:"HTTP_PORT" required:"true"`
+	}
+
+	var cfg Config
+	if err := Parse(map[string]string{"HTTP_PORT": "9090"}, &cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.Port != 9090 {
+		t.Errorf("Expected 9090, got %d", cfg.Port)
+	}
+
+	err := Parse(map[string]string{}, &cfg)
+	if !errors.Is(err, errMissingRequiredField) || !strings.Contains(err.Error(), "env=PORT|HTTP_PORT") {
+		t.Errorf("Expected missing field error naming every variable, got %v", err)
+	}
+}
+
+func Test_AliasStrictTags(t *testing.T) {
+	var withoutEnv struct {
+		Port int `alias:"HTTP_PORT"`
+	}
+
+	if err := Parse(nil, &withoutEnv, WithStrictTags()); !errors.Is(err, errInvalidTagDefinition) {
+		t.Errorf("Expected errInvalidTagDefinition, got %v", err)
+	}
+
+	var emptyAlias struct {
+		Port int `env:"PORT" alias:"HTTP_PORT|"`
+	}
+
+	if err := Parse(nil, &emptyAlias, WithStrictTags()); !errors.Is(err, errInvalidTagDefinition) {
+		t.Errorf("Expected errInvalidTagDefinition, got %v", err)
+	}
+}
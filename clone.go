@@ -0,0 +1,84 @@
+package envload
+
+import (
+	"reflect"
+)
+
+// Clone returns a deep copy of cfg: slices, maps, arrays, pointers and nested structs
+// reachable through exported fields are copied, so the clone can be modified without
+// aliasing the original. Interface values, funcs and channels are shared, and so is
+// state kept in unexported fields (such as the cached value of a [Lazy] field).
+// cfg must not contain pointer cycles.
+//
+// Example:
+//
+//	local := envload.Clone(cfg)
+//	local.Features["beta"] = true // cfg.Features is untouched
+func Clone[T any](cfg T) T {
+	deepCopy(reflect.ValueOf(&cfg).Elem())
+	return cfg
+}
+
+// deepCopy replaces every reference reachable from value with a copy, in place.
+// Values that cannot be set (unexported fields and their contents) are left shared.
+func deepCopy(value reflect.Value) {
+	if !value.CanSet() {
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.Slice:
+		if value.IsNil() {
+			return
+		}
+
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		reflect.Copy(copied, value)
+
+		for i := range copied.Len() {
+			deepCopy(copied.Index(i))
+		}
+
+		value.Set(copied)
+
+	case reflect.Array:
+		for i := range value.Len() {
+			deepCopy(value.Index(i))
+		}
+
+	case reflect.Map:
+		if value.IsNil() {
+			return
+		}
+
+		copied := reflect.MakeMapWithSize(value.Type(), value.Len())
+		entry := reflect.New(value.Type().Elem()).Elem()
+
+		iter := value.MapRange()
+		for iter.Next() {
+			entry.Set(iter.Value())
+			deepCopy(entry)
+			copied.SetMapIndex(iter.Key(), entry)
+		}
+
+		value.Set(copied)
+
+	case reflect.Pointer:
+		if value.IsNil() {
+			return
+		}
+
+		copied := reflect.New(value.Type().Elem())
+		copied.Elem().Set(value.Elem())
+		deepCopy(copied.Elem())
+
+		value.Set(copied)
+
+	case reflect.Struct:
+		for i := range value.NumField() {
+			deepCopy(value.Field(i))
+		}
+
+	default:
+	}
+}
@@ -0,0 +1,75 @@
+package envload
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Clone(t *testing.T) {
+	type Limits struct {
+		Burst []int
+	}
+
+	type Config struct {
+		Name     string
+		Hosts    []string
+		Features Features
+		Groups   map[string][]string
+		Limits   *Limits
+		Pairs    OrderedMap
+		Ports    [2][]int
+		Token    Lazy[string] `env:"TOKEN"`
+	}
+
+	var original Config
+	if err := Parse(map[string]string{"TOKEN": "secret"}, &original); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	original.Name = "service"
+	original.Hosts = []string{"a", "b"}
+	original.Features = Features{"beta": true}
+	original.Groups = map[string][]string{"admins": {"alice"}}
+	original.Limits = &Limits{Burst: []int{10}}
+	original.Pairs = OrderedMap{{Key: "a", Value: "1"}}
+	original.Ports = [2][]int{{80}, {443}}
+
+	clone := Clone(original)
+	if !reflect.DeepEqual(clone, original) {
+		t.Fatalf("Expected clone to equal original, got %+v", clone)
+	}
+
+	clone.Hosts[0] = "c"
+	clone.Features["beta"] = false
+	clone.Groups["admins"][0] = "mallory"
+	clone.Limits.Burst[0] = 99
+	clone.Pairs[0].Value = "2"
+	clone.Ports[1][0] = 8443
+
+	switch {
+	case original.Hosts[0] != "a":
+		t.Errorf("Expected original Hosts to be untouched, got %v", original.Hosts)
+	case !original.Features["beta"]:
+		t.Errorf("Expected original Features to be untouched, got %v", original.Features)
+	case original.Groups["admins"][0] != "alice":
+		t.Errorf("Expected original Groups to be untouched, got %v", original.Groups)
+	case original.Limits.Burst[0] != 10:
+		t.Errorf("Expected original Limits to be untouched, got %v", original.Limits)
+	case original.Pairs[0].Value != "1":
+		t.Errorf("Expected original Pairs to be untouched, got %v", original.Pairs)
+	case original.Ports[1][0] != 443:
+		t.Errorf("Expected original Ports to be untouched, got %v", original.Ports)
+	}
+
+	if token, err := clone.Token.Get(); err != nil || token != "secret" {
+		t.Errorf("Expected cloned lazy field to resolve to secret, got %q, %v", token, err)
+	}
+
+	t.Run("nil references stay nil", func(t *testing.T) {
+		clone := Clone(Config{})
+
+		if clone.Hosts != nil || clone.Features != nil || clone.Limits != nil {
+			t.Errorf("Expected nil references to stay nil, got %+v", clone)
+		}
+	})
+}
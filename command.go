@@ -0,0 +1,195 @@
+package envload
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+const (
+	// [defaultCommandTimeout] bounds [CommandMap] when it gets no timeout.
+	defaultCommandTimeout = 30 * time.Second
+
+	// [commandWaitDelay] bounds the wait for output pipes after the command is killed.
+	commandWaitDelay = time.Second
+
+	// [minCommandRead] is the smallest free space [secretBuffer.ReadFrom] reads into.
+	minCommandRead = 4096
+)
+
+type (
+	// secretBuffer collects the output of a command. Unlike bytes.Buffer, it wipes every
+	// backing array it outgrows, and reads straight into its own array, so no copy of
+	// the output is left behind for the garbage collector.
+	secretBuffer struct {
+		data []byte
+	}
+)
+
+var (
+	errCommandFailed = errors.New("config command failed")
+
+	// [baseCommandEnv] lists the variables every command receives, enough for tools to
+	// find their binaries, home directory and locale.
+	baseCommandEnv = []string{"PATH", "HOME", "USER", "LOGNAME", "LANG", "LC_*", "TZ", "TMPDIR", "SYSTEMROOT"}
+)
+
+// CommandMap runs the command name with args and parses its standard output as a .env
+// file, so secret tooling such as `pass show app/env` or `op inject -i app.env.tpl` can
+// feed [Parse]. The command is killed after timeout (30 seconds when zero or negative).
+// It runs with a sanitized environment: only PATH, HOME, USER, LOGNAME, LANG, LC_*, TZ,
+// TMPDIR and the variables matching passEnv (exact names or globs such as "OP_*") are
+// passed on, so unrelated secrets of the caller never reach the tool.
+// A failing command is reported with its exit status and the start of its standard
+// error; malformed output as a [ParseError] naming the command.
+//
+// Example:
+//
+//	envMap, err := envload.CommandMap(10*time.Second, []string{"GNUPGHOME", "PASSWORD_STORE_*"},
+//		"pass", "show", "app/env")
+//	if err != nil {
+//		return err
+//	}
+//	err = envload.Parse(envMap, &cfg)
+func CommandMap(timeout time.Duration, passEnv []string, name string, args ...string) (map[string]string, error) {
+	if timeout <= 0 {
+		timeout = defaultCommandTimeout
+	}
+
+	env, err := commandEnv(passEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var (
+		stdout secretBuffer
+		stderr bytes.Buffer
+	)
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = env
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = commandWaitDelay
+
+	// The output holds secrets, so wipe it once parsed.
+	defer stdout.wipe()
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: %s timed out after %s: %w", errCommandFailed, name, timeout, ctx.Err())
+		}
+
+		if detail := strings.TrimSpace(stderr.String()); detail != "" {
+			return nil, fmt.Errorf("%w: %s: %w: %s", errCommandFailed, name, err, truncateExcerpt(detail))
+		}
+
+		return nil, fmt.Errorf("%w: %s: %w", errCommandFailed, name, err)
+	}
+
+	return parseCommandOutput(name, stdout.data)
+}
+
+// Write appends p, growing the buffer as needed.
+func (buffer *secretBuffer) Write(p []byte) (int, error) {
+	buffer.grow(len(p))
+	buffer.data = append(buffer.data, p...)
+
+	return len(p), nil
+}
+
+// ReadFrom reads r until EOF directly into the buffer, so [io.Copy] needs no
+// intermediate buffer of its own.
+func (buffer *secretBuffer) ReadFrom(r io.Reader) (int64, error) {
+	var total int64
+
+	for {
+		buffer.grow(minCommandRead)
+
+		n, err := r.Read(buffer.data[len(buffer.data):cap(buffer.data)])
+		buffer.data = buffer.data[:len(buffer.data)+n]
+		total += int64(n)
+
+		if errors.Is(err, io.EOF) {
+			return total, nil
+		}
+
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+// grow makes room for n more bytes, wiping the array it replaces.
+func (buffer *secretBuffer) grow(n int) {
+	if len(buffer.data)+n <= cap(buffer.data) {
+		return
+	}
+
+	grown := make([]byte, len(buffer.data), max(2*cap(buffer.data), len(buffer.data)+n))
+	copy(grown, buffer.data)
+	clear(buffer.data)
+	buffer.data = grown
+}
+
+// wipe overwrites the buffer's array with zeros.
+func (buffer *secretBuffer) wipe() {
+	clear(buffer.data[:cap(buffer.data)])
+}
+
+// parseCommandOutput parses the .env formatted output of the command name.
+func parseCommandOutput(name string, output []byte) (map[string]string, error) {
+	var (
+		lines  []envLine
+		number int
+	)
+
+	for text := range bytes.Lines(output) {
+		number++
+		lines = append(lines, envLine{file: name, number: number, text: normalizeLineEnding(text)})
+	}
+
+	content := joinLines(lines)
+	defer clear(content)
+
+	envMap, err := godotenv.UnmarshalBytes(content)
+	if err != nil {
+		return nil, locateParseError(lines, content)
+	}
+
+	return envMap, nil
+}
+
+// commandEnv returns the variables of the process environment passed to commands:
+// the base set and those matching passEnv.
+func commandEnv(passEnv []string) ([]string, error) {
+	patterns := append(baseCommandEnv[:len(baseCommandEnv):len(baseCommandEnv)], passEnv...)
+
+	var env []string
+
+	for _, entry := range os.Environ() {
+		key, _, _ := strings.Cut(entry, "=")
+
+		matched, err := matchesAnyKey(patterns, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if matched {
+			env = append(env, entry)
+		}
+	}
+
+	return env, nil
+}
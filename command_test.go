@@ -0,0 +1,110 @@
+package envload
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommandMap(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+
+	t.Setenv("APP_SECRET", "leaked")
+	t.Setenv("OP_SESSION", "session")
+
+	script := `echo "DB_URL=postgres://db/app"; echo "SECRET=${APP_SECRET}"; echo "SESSION=${OP_SESSION}"`
+
+	got, err := CommandMap(0, []string{"OP_*"}, "sh", "-c", script)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]string{"DB_URL": "postgres://db/app", "SECRET": "", "SESSION": "session"}
+	if !maps.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestCommandMap_errors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+
+	t.Run("exit status", func(t *testing.T) {
+		_, err := CommandMap(0, nil, "sh", "-c", "echo 'vault is locked' >&2; exit 3")
+		if !errors.Is(err, errCommandFailed) {
+			t.Fatalf("Expected errCommandFailed, got %v", err)
+		}
+
+		if !strings.Contains(err.Error(), "exit status 3") || !strings.Contains(err.Error(), "vault is locked") {
+			t.Errorf("Expected exit status and stderr in %q", err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		start := time.Now()
+
+		_, err := CommandMap(50*time.Millisecond, nil, "sh", "-c", "sleep 5")
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+		}
+
+		if elapsed := time.Since(start); elapsed > 3*time.Second {
+			t.Errorf("Expected command to be killed, took %s", elapsed)
+		}
+	})
+
+	t.Run("malformed output", func(t *testing.T) {
+		_, err := CommandMap(0, nil, "sh", "-c", `echo "OK=1"; echo "BROKEN='unterminated"`)
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("Expected *ParseError, got %v", err)
+		}
+
+		if parseErr.File != "sh" || parseErr.Line != 2 {
+			t.Errorf("Expected sh:2, got %s:%d", parseErr.File, parseErr.Line)
+		}
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		if _, err := CommandMap(0, []string{"["}, "sh", "-c", "true"); !errors.Is(err, errInvalidKeyPattern) {
+			t.Errorf("Expected errInvalidKeyPattern, got %v", err)
+		}
+	})
+}
+
+func Test_secretBuffer(t *testing.T) {
+	var buffer secretBuffer
+
+	if _, err := buffer.Write([]byte("TOKEN=")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	outgrown := buffer.data[:cap(buffer.data)]
+	secret := strings.Repeat("s", 2*minCommandRead)
+
+	if _, err := buffer.ReadFrom(strings.NewReader(secret)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if string(buffer.data) != "TOKEN="+secret {
+		t.Errorf("Expected the written and read content, got %d bytes", len(buffer.data))
+	}
+
+	if strings.Trim(string(outgrown), "\x00") != "" {
+		t.Errorf("Expected the outgrown array to be wiped, got %q", outgrown)
+	}
+
+	buffer.wipe()
+
+	if strings.Trim(string(buffer.data), "\x00") != "" {
+		t.Error("Expected wipe to clear the buffer")
+	}
+}
@@ -0,0 +1,195 @@
+package envload
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+type (
+	// conditionalBlock tracks one open #if block while applying conditionals.
+	conditionalBlock struct {
+		parentActive bool
+		matched      bool
+		inElse       bool
+		line         envLine
+	}
+
+	// conditionalState applies #if/#else/#endif directives to lines fed one at a time,
+	// so includes can be expanded only where their branch is kept. Conditions are
+	// evaluated against the keys defined by the kept lines above them, falling back
+	// to the OS environment:
+	//
+	//	#if KEY=value   true when KEY equals value
+	//	#if KEY!=value  true when KEY differs from value
+	//	#if KEY         true when KEY is set and not empty
+	//
+	// Blocks may be nested.
+	conditionalState struct {
+		output  []envLine
+		pending []envLine         // Kept lines not yet parsed into known.
+		known   map[string]string // Keys defined by the kept lines, for conditions.
+		blocks  []conditionalBlock
+		active  bool
+	}
+)
+
+const (
+	// [ifDirective] opens a conditional block: #if APP_ENV=production.
+	ifDirective = "#if"
+	// [elseDirective] switches to the alternative branch of the innermost block.
+	elseDirective = "#else"
+	// [endifDirective] closes the innermost block.
+	endifDirective = "#endif"
+)
+
+var (
+	errInvalidConditional = errors.New("invalid conditional")
+)
+
+// newConditionalState returns the state at the top of a file, outside any block.
+func newConditionalState() *conditionalState {
+	return &conditionalState{known: map[string]string{}, active: true}
+}
+
+// apply processes the next line: directives open, switch or close blocks, and other
+// lines are kept when every enclosing branch holds.
+func (state *conditionalState) apply(line envLine) error {
+	directive, argument := parseDirectiveLine(string(line.text))
+
+	switch directive {
+	case ifDirective:
+		matched := false
+		if state.active {
+			if err := state.updateKnown(line); err != nil {
+				return err
+			}
+
+			var err error
+			matched, err = evaluateCondition(argument, state.known)
+			if err != nil {
+				return conditionalError(line, err.Error())
+			}
+		}
+
+		state.blocks = append(state.blocks, conditionalBlock{parentActive: state.active, matched: matched, line: line})
+		state.active = state.active && matched
+
+	case elseDirective:
+		if len(state.blocks) == 0 || state.blocks[len(state.blocks)-1].inElse {
+			return conditionalError(line, "unexpected "+elseDirective)
+		}
+
+		block := &state.blocks[len(state.blocks)-1]
+		block.inElse = true
+		state.active = block.parentActive && !block.matched
+
+	case endifDirective:
+		if len(state.blocks) == 0 {
+			return conditionalError(line, "unexpected "+endifDirective)
+		}
+
+		state.active = state.blocks[len(state.blocks)-1].parentActive
+		state.blocks = state.blocks[:len(state.blocks)-1]
+
+	default:
+		if state.active {
+			state.output = append(state.output, line)
+			state.pending = append(state.pending, line)
+		}
+	}
+
+	return nil
+}
+
+// updateKnown parses the lines kept since the previous condition into known, so each
+// line is parsed once however many conditions follow. Lines referencing variables are
+// parsed along with everything kept before them, since ${VAR} expands against earlier keys.
+func (state *conditionalState) updateKnown(directive envLine) error {
+	if len(state.pending) == 0 {
+		return nil
+	}
+
+	lines := state.pending
+	if slices.ContainsFunc(lines, func(line envLine) bool { return bytes.IndexByte(line.text, '$') >= 0 }) {
+		lines = state.output
+	}
+
+	content := joinLines(lines)
+	defer clear(content)
+
+	envMap, err := godotenv.UnmarshalBytes(content)
+	if err != nil {
+		return fmt.Errorf("%w at %s:%d: %w", errInvalidConditional, directive.file, directive.number, locateParseError(lines, content))
+	}
+
+	maps.Copy(state.known, envMap)
+	clear(envMap)
+	state.pending = nil
+
+	return nil
+}
+
+// wipe forgets the keys and values collected to evaluate conditions.
+func (state *conditionalState) wipe() {
+	clear(state.known)
+}
+
+// finish returns the kept lines once every line has been applied.
+func (state *conditionalState) finish() ([]envLine, error) {
+	if len(state.blocks) > 0 {
+		return nil, conditionalError(state.blocks[len(state.blocks)-1].line, ifDirective+" is never closed")
+	}
+
+	return state.output, nil
+}
+
+// conditionalError reports a conditional problem at line.
+func conditionalError(line envLine, reason string) error {
+	return fmt.Errorf("%w at %s:%d: %s", errInvalidConditional, line.file, line.number, reason)
+}
+
+// parseDirectiveLine returns the conditional directive on line and its argument, if any.
+func parseDirectiveLine(line string) (string, string) {
+	trimmed := strings.TrimSpace(line)
+
+	for _, directive := range []string{ifDirective, elseDirective, endifDirective} {
+		rest, ok := strings.CutPrefix(trimmed, directive)
+		if ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t') {
+			return directive, strings.TrimSpace(rest)
+		}
+	}
+
+	return "", ""
+}
+
+// evaluateCondition evaluates KEY, KEY=value or KEY!=value against known keys and the OS environment.
+func evaluateCondition(condition string, known map[string]string) (bool, error) {
+	lookup := func(key string) string {
+		if value, ok := known[key]; ok {
+			return value
+		}
+
+		return os.Getenv(key)
+	}
+
+	if key, value, ok := strings.Cut(condition, "!="); ok {
+		return lookup(strings.TrimSpace(key)) != strings.TrimSpace(value), nil
+	}
+
+	if key, value, ok := strings.Cut(condition, "="); ok {
+		return lookup(strings.TrimSpace(key)) == strings.TrimSpace(value), nil
+	}
+
+	if condition == "" {
+		return false, fmt.Errorf("%s needs a condition", ifDirective)
+	}
+
+	return lookup(condition) != "", nil
+}
@@ -0,0 +1,151 @@
+package envload
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/joho/godotenv"
+)
+
+// applyConditionLines feeds lines through a fresh conditional state and returns the kept lines.
+func applyConditionLines(lines []envLine) ([]envLine, error) {
+	state := newConditionalState()
+	defer state.wipe()
+
+	for _, line := range lines {
+		if err := state.apply(line); err != nil {
+			return nil, err
+		}
+	}
+
+	return state.finish()
+}
+
+func Test_conditionalState(t *testing.T) {
+	content := `APP_ENV=production
+LOG_LEVEL=debug
+#if APP_ENV=production
+LOG_LEVEL=warn
+#if REGION
+REPLICAS=3
+#else
+REPLICAS=1
+#endif
+#else
+DEBUG=true
+#endif
+#if APP_ENV!=production
+LOG_LEVEL=info
+#endif
+#if ENVLOAD_TEST_OS_FLAG=on
+FROM_OS=yes
+#endif
+`
+
+	t.Setenv("ENVLOAD_TEST_OS_FLAG", "on")
+
+	output, err := applyConditionLines(envLinesOf("test.env", content))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	envMap, err := godotenv.UnmarshalBytes(joinLines(output))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := Tests[string]{
+		{"matching block overrides", envMap["LOG_LEVEL"], "warn"},
+		{"nested else branch", envMap["REPLICAS"], "1"},
+		{"skipped else branch", envMap["DEBUG"], ""},
+		{"condition on os env", envMap["FROM_OS"], "yes"},
+	}
+
+	tests.runTests(t)
+}
+
+func Test_conditionalState_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"unclosed if":       "#if A=1\nB=2\n",
+		"stray endif":       "A=1\n#endif\n",
+		"stray else":        "#else\n",
+		"double else":       "#if A\n#else\n#else\n#endif\n",
+		"empty if":          "#if\n#endif\n",
+		"unparsable so far": "A=\"unterminated\n#if A\n#endif\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := applyConditionLines(envLinesOf("test.env", content)); !errors.Is(err, errInvalidConditional) {
+				t.Errorf("Expected errInvalidConditional, got %v", err)
+			}
+		})
+	}
+}
+
+func Test_readEnvFile_Conditionals(t *testing.T) {
+	dir := writeEnvFiles(t, map[string]string{
+		"base.env": "APP_ENV=staging\n",
+		"app.env":  "#include base.env\n#if APP_ENV=staging\nDB_HOST=staging-db\n#endif\n",
+	})
+
+	envMap, err := readEnvFile(filepath.Join(dir, "app.env"), options{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if envMap["DB_HOST"] != "staging-db" {
+		t.Errorf("Expected 'staging-db', got '%s'", envMap["DB_HOST"])
+	}
+}
+
+func Test_readEnvFile_IncludeInSkippedBranch(t *testing.T) {
+	dir := writeEnvFiles(t, map[string]string{
+		"staging.env": "DB_HOST=staging-db\n",
+		"app.env":     "APP_ENV=staging\nPORT=9000\n#if APP_ENV=production\n#include prod.env\n#else\n#include staging.env\n#endif\n",
+	})
+
+	envMap, err := readEnvFile(filepath.Join(dir, "app.env"), options{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if envMap["PORT"] != "9000" || envMap["DB_HOST"] != "staging-db" {
+		t.Errorf("Expected the missing prod.env to be skipped, got %v", envMap)
+	}
+}
+
+func Test_conditionalState_ErrorPosition(t *testing.T) {
+	_, err := applyConditionLines(envLinesOf("app.env", "A=1\n#if A=1\nB=2\n"))
+	if err == nil || !strings.Contains(err.Error(), "at app.env:2: #if is never closed") {
+		t.Errorf("Expected position of the unclosed block, got %v", err)
+	}
+}
+
+func Test_readEnvFile_ConditionsAfterConditions(t *testing.T) {
+	dir := writeEnvFiles(t, map[string]string{
+		"app.env": "HOST=db\n#if HOST=db\nPORT=5432\n#endif\nURL=${HOST}:${PORT}\n#if URL=db:5432\nMATCHED=yes\n#endif\n",
+	})
+
+	envMap, err := readEnvFile(filepath.Join(dir, "app.env"), options{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if envMap["MATCHED"] != "yes" {
+		t.Errorf("Expected the condition to see expanded earlier keys, got %v", envMap)
+	}
+}
+
+func Test_readEnvFile_ConditionAfterMalformedLine(t *testing.T) {
+	dir := writeEnvFiles(t, map[string]string{
+		"app.env": "A=1\nB=\"unterminated\n#if A=1\n#endif\n",
+	})
+
+	_, err := readEnvFile(filepath.Join(dir, "app.env"), options{})
+
+	var parseErr *ParseError
+	if !errors.Is(err, errInvalidConditional) || !errors.As(err, &parseErr) || parseErr.Line != 2 {
+		t.Errorf("Expected the malformed line 2 to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package envload
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type (
+	// crossFieldCheck is a field whose `validate` tag compares it with another field,
+	// checked once every field of the struct has been populated.
+	crossFieldCheck struct {
+		field     structField
+		populated bool
+	}
+
+	// crossFieldRelation is the comparison a cross-field rule requires.
+	crossFieldRelation struct {
+		accept func(comparison int) bool
+		text   string
+	}
+)
+
+var (
+	// [crossFieldRelations] maps cross-field rule names to the comparison they require
+	// between the field and the field named by the rule parameter.
+	crossFieldRelations = map[string]crossFieldRelation{
+		"gtfield":  {func(comparison int) bool { return comparison > 0 }, "greater than"},
+		"gtefield": {func(comparison int) bool { return comparison >= 0 }, "at least"},
+		"ltfield":  {func(comparison int) bool { return comparison < 0 }, "less than"},
+		"ltefield": {func(comparison int) bool { return comparison <= 0 }, "at most"},
+	}
+)
+
+// hasCrossFieldRules reports whether the field's `validate` tag may contain a cross-field rule.
+func hasCrossFieldRules(field reflect.StructField) bool {
+	return strings.Contains(field.Tag.Get("validate"), "field=")
+}
+
+// isCrossFieldRule reports whether name is a cross-field rule such as gtfield.
+func isCrossFieldRule(name string) bool {
+	_, ok := crossFieldRelations[name]
+	return ok
+}
+
+// validateCrossFields applies the cross-field rules of checks once the struct is populated.
+// Rules reference fields of the same (nested) struct. References are checked for every
+// field, comparisons only for populated fields.
+// Example: `validate:"gtfield=MinConns"` on MaxConns=5 with MinConns=10 ->
+// "must be greater than MinConns (10), got 5".
+func validateCrossFields(checks []crossFieldCheck) error {
+	for _, check := range checks {
+		resolver := fieldResolver{field: check.field.StructField, value: check.field.value, envKey: check.field.envKey()}
+
+		afterDive := false
+
+		for _, rule := range parseValidateTag(resolver.field.Tag.Get("validate")) {
+			afterDive = afterDive || rule.name == diveRule
+
+			relation, ok := crossFieldRelations[rule.name]
+			if !ok {
+				continue
+			}
+
+			if afterDive {
+				return resolver.tagError("validate", rule.name+" cannot be applied to elements after dive")
+			}
+
+			if err := resolver.compareField(check.field.parent, rule, relation, check.populated); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// compareField checks the field against the field of structValue named by rule.param,
+// which may be promoted from an embedded struct.
+func (resolver *fieldResolver) compareField(structValue reflect.Value, rule validationRule, relation crossFieldRelation, populated bool) error {
+	other, ok := structValue.Type().FieldByName(rule.param)
+	if !ok {
+		return resolver.tagError("validate", fmt.Sprintf("%s references unknown field %q", rule.name, rule.param))
+	}
+
+	otherField, err := structValue.FieldByIndexErr(other.Index)
+	if err != nil {
+		return nil // Promoted through a nil embedded pointer, so unset.
+	}
+
+	value, valueSet := indirectValue(resolver.value)
+	otherValue, otherSet := indirectValue(otherField)
+
+	if !valueSet || !otherSet {
+		return nil // Unset pointer fields have nothing to compare.
+	}
+
+	comparison, ok := compareNumbers(value, otherValue)
+	if !ok {
+		return resolver.tagError("validate", fmt.Sprintf("%s needs numbers or durations on both sides, got %s and %s",
+			rule.name, resolver.field.Type, other.Type))
+	}
+
+	if populated && !relation.accept(comparison) {
+		return resolver.fieldError(codeValidate, &ruleError{rule: rule, err: fmt.Errorf("%w for field '%s': must be %s %s (%v), got %v",
+			errValidationFailed, resolver.field.Name, relation.text, other.Name, otherValue, value)})
+	}
+
+	return nil
+}
+
+// compareNumbers compares two numeric values, returning -1, 0 or +1. It reports false
+// when either is not a number or only one of them is a time.Duration.
+func compareNumbers(value, other reflect.Value) (int, bool) {
+	if isDurationType(value.Type()) != isDurationType(other.Type()) {
+		return 0, false
+	}
+
+	left, leftOK := numberValue(value)
+	right, rightOK := numberValue(other)
+
+	if !leftOK || !rightOK {
+		return 0, false
+	}
+
+	switch {
+	case left < right:
+		return -1, true
+	case left > right:
+		return 1, true
+	default:
+		return 0, true
+	}
+}
+
+// numberValue returns value as a float64 when it is an integer, unsigned or float kind.
+//
+//nolint:exhaustive // note: Other kinds are not numbers.
+func numberValue(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,76 @@
+package envload
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_ValidateCrossFields(t *testing.T) {
+	type Config struct {
+		MinConns    int           `env:"MIN_CONNS" default:"2"`
+		MaxConns    int           `env:"MAX_CONNS" validate:"gtfield=MinConns"`
+		IdleConns   int           `env:"IDLE_CONNS" validate:"gtefield=MinConns,ltefield=MaxConns"`
+		ReadTimeout time.Duration `env:"READ_TIMEOUT" default:"5s" validate:"ltfield=Deadline"`
+		Deadline    time.Duration `env:"DEADLINE" default:"30s"`
+	}
+
+	tests := []struct {
+		name    string
+		envMap  map[string]string
+		message string
+	}{
+		{"valid", map[string]string{"MAX_CONNS": "10", "IDLE_CONNS": "5"}, ""},
+		{"unset fields are not compared", map[string]string{}, ""},
+		{"gtfield", map[string]string{"MIN_CONNS": "10", "MAX_CONNS": "10"}, "field 'MaxConns': must be greater than MinConns (10), got 10"},
+		{"gtefield", map[string]string{"MAX_CONNS": "10", "IDLE_CONNS": "1"}, "field 'IdleConns': must be at least MinConns (2), got 1"},
+		{"ltefield", map[string]string{"MAX_CONNS": "10", "IDLE_CONNS": "11"}, "field 'IdleConns': must be at most MaxConns (10), got 11"},
+		{"later field", map[string]string{"DEADLINE": "1s"}, "field 'ReadTimeout': must be less than Deadline (1s), got 5s"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cfg Config
+
+			err := Parse(test.envMap, &cfg)
+			if test.message == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, errValidationFailed) || !strings.Contains(err.Error(), test.message) {
+				t.Errorf("Expected validation error containing %q, got %v", test.message, err)
+			}
+		})
+	}
+}
+
+func Test_ValidateCrossFields_InvalidRules(t *testing.T) {
+	targets := []any{
+		&struct {
+			Max int `env:"MAX" validate:"gtfield=Missing"`
+		}{},
+		&struct {
+			Min  int    `env:"MIN"`
+			Name string `env:"NAME" validate:"gtfield=Min"`
+		}{},
+		&struct {
+			Min     int           `env:"MIN"`
+			Timeout time.Duration `env:"TIMEOUT" validate:"gtfield=Min"`
+		}{},
+		&struct {
+			Min   int   `env:"MIN"`
+			Ports []int `env:"PORTS" validate:"dive,gtfield=Min"`
+		}{},
+	}
+
+	for _, target := range targets {
+		if err := Parse(nil, target); !errors.Is(err, errInvalidTagDefinition) {
+			t.Errorf("Expected errInvalidTagDefinition for %T, got %v", target, err)
+		}
+	}
+}
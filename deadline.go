@@ -0,0 +1,92 @@
+package envload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+type (
+	// DeadlineError reports that loading exceeded the budget set with [WithDeadline] and
+	// names the phase that was running. It matches [context.DeadlineExceeded] with errors.Is.
+	DeadlineError struct {
+		Phase  string        // Phase that exceeded the budget: "read", "populate", "validate" or "preflight".
+		Budget time.Duration // Budget given to WithDeadline.
+		// Unresolved lists the env-tagged fields not populated when the budget ran out, in
+		// declaration order. Under [WithPartialResults] the fields before them keep their values.
+		Unresolved []string
+	}
+)
+
+const (
+	phaseRead      = "read"
+	phasePopulate  = "populate"
+	phaseValidate  = "validate"
+	phasePreflight = "preflight"
+)
+
+// Error names the phase and the budget it exceeded.
+func (deadlineErr *DeadlineError) Error() string {
+	return fmt.Sprintf("load exceeded its %s budget during the %s phase", deadlineErr.Budget, deadlineErr.Phase)
+}
+
+// Unwrap returns [context.DeadlineExceeded].
+func (deadlineErr *DeadlineError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// checkDeadline returns a [DeadlineError] for phase once the [WithDeadline] budget is spent.
+func (config options) checkDeadline(phase string) *DeadlineError {
+	if config.deadlineAt.IsZero() || time.Now().Before(config.deadlineAt) {
+		return nil
+	}
+
+	return &DeadlineError{Phase: phase, Budget: config.deadline}
+}
+
+// preflightBudget returns the preflight timeout, shortened to what is left of the
+// [WithDeadline] budget.
+func (config options) preflightBudget() time.Duration {
+	timeout := config.preflightTimeout
+	if timeout <= 0 {
+		timeout = defaultPreflightTimeout
+	}
+
+	if config.deadlineAt.IsZero() {
+		return timeout
+	}
+
+	return max(min(timeout, time.Until(config.deadlineAt)), time.Nanosecond)
+}
+
+// unresolvedFields returns the names of the env-tagged fields among fields.
+func unresolvedFields(fields []structField) []string {
+	var names []string
+
+	for _, field := range fields {
+		if field.IsExported() && field.Tag.Get("env") != "" {
+			names = append(names, field.Name)
+		}
+	}
+
+	return names
+}
+
+// snapshotValue returns a copy of value, for [restoreOnDeadline].
+func snapshotValue(value reflect.Value) reflect.Value {
+	original := reflect.New(value.Type()).Elem()
+	original.Set(value)
+
+	return original
+}
+
+// restoreOnDeadline resets value to original, its content before the load, when err is
+// a [DeadlineError], so callers never see a half-populated struct unless they opt in
+// with [WithPartialResults].
+func restoreOnDeadline(value, original reflect.Value, err error) {
+	if deadlineErr := (*DeadlineError)(nil); errors.As(err, &deadlineErr) {
+		value.Set(original)
+	}
+}
@@ -0,0 +1,108 @@
+package envload
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+type (
+	deadlineConfig struct {
+		Slow  string `env:"SLOW" validate:"test-sleep"`
+		Other string `env:"OTHER"`
+	}
+
+	deadlinePartialConfig struct {
+		First string `env:"FIRST"`
+		Slow  string `env:"SLOW" validate:"test-sleep"`
+		Third string `env:"THIRD"`
+		Plain string
+		Last  int `env:"LAST"`
+	}
+
+	deadlineValidateConfig struct {
+		Port int `env:"PORT"`
+	}
+)
+
+func Test_WithDeadline(t *testing.T) {
+	RegisterValidator("test-sleep", func(any, string) error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+
+	var cfg deadlineConfig
+
+	if err := Parse(map[string]string{"SLOW": "x"}, &cfg, WithDeadline(time.Second)); err != nil {
+		t.Errorf("Unexpected error within budget: %v", err)
+	}
+
+	err := Parse(map[string]string{"SLOW": "x"}, &cfg, WithDeadline(5*time.Millisecond))
+
+	var deadlineErr *DeadlineError
+	if !errors.As(err, &deadlineErr) || deadlineErr.Phase != phasePopulate || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected DeadlineError in the populate phase, got %v", err)
+	}
+
+	if want := "load exceeded its 5ms budget during the populate phase"; err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+}
+
+func Test_WithPartialResults(t *testing.T) {
+	RegisterValidator("test-sleep", func(any, string) error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+
+	envMap := map[string]string{"FIRST": "1", "SLOW": "2", "THIRD": "3", "LAST": "4"}
+
+	t.Run("keeps resolved fields", func(t *testing.T) {
+		var cfg deadlinePartialConfig
+
+		err := Parse(envMap, &cfg, WithDeadline(5*time.Millisecond), WithPartialResults())
+
+		var deadlineErr *DeadlineError
+		if !errors.As(err, &deadlineErr) {
+			t.Fatalf("Expected DeadlineError, got %v", err)
+		}
+
+		if !slices.Equal(deadlineErr.Unresolved, []string{"Third", "Last"}) {
+			t.Errorf("Expected Third and Last unresolved, got %v", deadlineErr.Unresolved)
+		}
+
+		if cfg.First != "1" || cfg.Slow != "2" || cfg.Third != "" {
+			t.Errorf("Expected fields before the deadline to be kept, got %+v", cfg)
+		}
+	})
+
+	t.Run("restores without option", func(t *testing.T) {
+		cfg := deadlinePartialConfig{First: "old"}
+
+		if err := Parse(envMap, &cfg, WithDeadline(5*time.Millisecond)); err == nil {
+			t.Fatal("Expected DeadlineError")
+		}
+
+		if cfg != (deadlinePartialConfig{First: "old"}) {
+			t.Errorf("Expected struct to be restored, got %+v", cfg)
+		}
+	})
+}
+
+func Test_WithDeadlineValidatePhase(t *testing.T) {
+	RegisterStructValidator(func(*deadlineValidateConfig) error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+
+	var cfg deadlineValidateConfig
+
+	err := Parse(map[string]string{}, &cfg, WithDeadline(5*time.Millisecond))
+
+	var deadlineErr *DeadlineError
+	if !errors.As(err, &deadlineErr) || deadlineErr.Phase != phaseValidate {
+		t.Errorf("Expected DeadlineError in the validate phase, got %v", err)
+	}
+}
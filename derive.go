@@ -0,0 +1,50 @@
+package envload
+
+import (
+	"strings"
+	"text/template"
+)
+
+// deriveFields sets the fields with a `derive` tag from their text/template, executed
+// against the struct the field belongs to once every env value is resolved, so derived
+// values (addresses, DSNs) never come from the environment. Fields are derived in
+// declaration order, so a template may use fields derived before it. The rendered text
+// is converted like an env value, normalized and checked against the field's `validate` rules.
+// Example: `derive:"{{.Host}}:{{.Port}}"` with Host=db and Port=5432 -> "db:5432".
+func deriveFields(fields []structField) error {
+	for _, field := range fields {
+		resolver := fieldResolver{field: field.StructField, value: field.value}
+
+		tmpl, err := template.New(field.Name).Option("missingkey=error").Parse(field.Tag.Get("derive"))
+		if err != nil {
+			return resolver.tagError("derive", err.Error())
+		}
+
+		if !field.parent.CanInterface() {
+			return resolver.tagError("derive", "enclosing struct is unexported")
+		}
+
+		var rendered strings.Builder
+		if err := tmpl.Execute(&rendered, field.parent.Interface()); err != nil {
+			return resolver.tagError("derive", err.Error())
+		}
+
+		resolver.rawValue = rendered.String()
+
+		if resolver.field.Type == stringType {
+			resolver.value.SetString(resolver.rawValue)
+		} else if err := resolver.setValue(); err != nil {
+			return resolver.fieldError(codeInvalid, err)
+		}
+
+		if err := resolver.normalize(); err != nil {
+			return err
+		}
+
+		if err := resolver.validate(); err != nil {
+			return resolver.fieldError(codeValidate, err)
+		}
+	}
+
+	return nil
+}
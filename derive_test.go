@@ -0,0 +1,72 @@
+package envload
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_DeriveFields(t *testing.T) {
+	type Database struct {
+		Host string `env:"HOST" default:"localhost"`
+		Port int    `env:"PORT" default:"5432"`
+		Addr string `derive:"{{.Host}}:{{.Port}}"`
+	}
+
+	type Config struct {
+		Database Database `envPrefix:"DB_"`
+		Name     string   `env:"NAME" default:"orders"`
+		DSN      string   `derive:"postgres://{{.Database.Addr}}/{{.Name}}"`
+		Workers  int      `derive:"{{len .Name}}" validate:"max=10"`
+	}
+
+	var cfg Config
+	if err := Parse(map[string]string{"DB_HOST": "db.internal", "NAME": "app"}, &cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.Database.Addr != "db.internal:5432" || cfg.DSN != "postgres://db.internal:5432/app" || cfg.Workers != 3 {
+		t.Errorf("Unexpected derived values: %+v", cfg)
+	}
+
+	if err := Parse(map[string]string{"DB_ADDR": "ignored:1"}, &cfg); err != nil || cfg.Database.Addr != "localhost:5432" {
+		t.Errorf("Expected derived field not to be read from env, got %q (%v)", cfg.Database.Addr, err)
+	}
+
+	var fieldErr *FieldError
+	if err := Parse(map[string]string{"NAME": "a-very-long-name"}, &cfg); !errors.As(err, &fieldErr) || fieldErr.Field != "Workers" || fieldErr.Code != codeValidate {
+		t.Errorf("Expected validate FieldError for Workers, got %v", err)
+	}
+}
+
+func Test_DeriveFieldsInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  any
+		opts    []Option
+		message string
+	}{
+		{"syntax", &struct {
+			Addr string `derive:"{{.Host"`
+		}{}, nil, "tag=derive"},
+		{"unknown field", &struct {
+			Addr string `derive:"{{.Host}}"`
+		}{}, nil, "can't evaluate field Host"},
+		{"conversion", &struct {
+			Name  string `env:"NAME" default:"x"`
+			Count int    `derive:"{{.Name}}"`
+		}{}, nil, "invalid int for field 'Count'"},
+		{"strict env tag", &struct {
+			Addr string `env:"ADDR" derive:"x"`
+		}{}, []Option{WithStrictTags()}, "derived fields cannot have env"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Parse(map[string]string{}, test.target, test.opts...)
+			if err == nil || !strings.Contains(err.Error(), test.message) {
+				t.Errorf("Expected error containing %q, got %v", test.message, err)
+			}
+		})
+	}
+}
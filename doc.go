@@ -51,6 +51,82 @@ Define your configuration struct and load it:
 		log.Printf("App: %s running on port %d", cfg.AppName, cfg.Port)
 	}
 
+LoadFromStdin parses .env content piped into the process instead of a file
+(render-config | app --config -), returning read errors rather than falling
+back to defaults.
+
+To populate a struct from a map you already have (for example values
+gathered per request), use Parse. It keeps no shared state and is safe to
+call concurrently on different targets:
+
+	var opts Options
+	err := envload.Parse(map[string]string{"LIMIT": "50"}, &opts)
+
+For single values, Get and the typed helpers (GetString, GetInt, GetFloat,
+GetBool, GetDuration) apply the same conversions without a struct:
+
+	port, err := envload.GetInt(envMap, "PORT", 8080)
+
+HeaderMap and ValuesMap convert http.Header and url.Values into that map
+form, canonicalizing names to env style (X-Page-Size -> X_PAGE_SIZE):
+
+	err := envload.Parse(envload.HeaderMap(r.Header), &opts)
+
+ParseRecord maps a tabular row (such as a CSV record) to a struct, matching
+header columns against env tags:
+
+	err := envload.ParseRecord(header, row, &customer)
+
+On Windows, RegistryMap reads the values under a registry key such as
+HKLM\SOFTWARE\Acme\Service into that map form.
+
+CredentialsMap reads the systemd credentials in $CREDENTIALS_DIRECTORY, and
+EnvironmentFileMap parses a file with the quoting, escaping and comment rules
+of systemd's EnvironmentFile= instead of .env syntax.
+
+EnvrcMap reads the key/value subset of a direnv .envrc file (export lines,
+quoting, $VAR references) and reports other shell syntax as a *ParseError.
+
+PropertiesMap reads Java .properties files with the java.util.Properties
+escaping and continuation rules, canonicalizing keys (db.url -> DB_URL).
+
+INIMap reads INI files, prefixing keys with their section and a configurable
+joiner ("url" under "[database]" becomes DATABASE_URL).
+
+On Linux, ProcessEnvMap reads /proc/<pid>/environ to audit the environment a
+running service started with.
+
+LabelMap turns OCI image labels with a given prefix into defaults keyed by
+canonical variable name, for images with baked-in configuration.
+
+CommandMap runs a command such as "pass show app/env" with a timeout and a
+sanitized environment and parses its output as a .env file.
+
+A "#include other.env" line inlines another file at that position, resolved
+relative to the including file; later lines override included keys. Missing
+included files and include cycles are returned as errors rather than falling
+back to defaults.
+
+Lines between "#if KEY=value" (or KEY!=value, or KEY) and "#endif", with an
+optional "#else", are kept only when the condition holds for the keys defined
+above them or the OS environment. Includes in branches that do not hold are
+never opened.
+
+Under WithSections, a "[database]" section header prefixes the keys that
+follow it, so HOST becomes DATABASE_HOST until the next header; "[]" returns
+to unprefixed keys. Without the option a header line is a parse error.
+
+UTF-8 byte order marks are stripped, CRLF line endings are accepted, and
+UTF-16 files with a byte order mark are transcoded to UTF-8.
+
+WithMaxFileSize, WithMaxKeys and WithMaxValueLength cap what LoadAndParse
+accepts when the .env path can be influenced by users; exceeding a limit is
+returned as an error rather than falling back to defaults.
+
+WithFileRetry keeps reading a .env file that does not exist yet for up to the
+given duration, for volumes mounted or written by init containers while the
+application is already starting.
+
 # Struct Tags
 
 The following struct tags are supported:
@@ -61,9 +137,48 @@ The following struct tags are supported:
 	default  - Fallback value when env var is missing
 	         Example: `default:"8080"`
 
+	alias    - Alternative names tried in order, before the default
+	         Example: `alias:"DB_URL|POSTGRES_URL"`
+
 	required - Fails if missing and no default
 	         Example: `required:"true"`
 
+	unique   - Rejects slices with duplicate elements
+	         Example: `unique:"true"`
+
+	impl     - Allowed implementation names for interface fields
+	         Example: `impl:"s3|gcs|file"`
+
+	secret   - Redacts the value in errors and Redacted output
+	         Example: `secret:"true"`
+
+	preflight - Connectivity check run under WithPreflight (tcp or dns)
+	         Example: `preflight:"tcp"`
+
+	emptyunset - Treats an empty value as unset, so the default applies
+	         Example: `emptyunset:"true"`
+
+	validate - Rules checked against the parsed value (len, minlen, maxlen,
+	         min, max, file, dir, readable, writable, freeport, hostname,
+	         fqdn, email, uuid, base64, and gtfield, gtefield, ltfield,
+	         ltefield comparing with another field, or registered with
+	         RegisterValidator); rules after "dive" apply to each element
+	         Example: `validate:"minlen=1,dive,min=1,max=65535"`
+
+	auto     - Assigns a free port to an integer field left at 0
+	         Example: `auto:"port"`
+
+	envPrefix - Loads a nested struct field from the variables behind the prefix
+	         Example: `envPrefix:"DB_"`
+
+	normalize - Rewrites string values after parsing (trim, trimprefix,
+	         trimsuffix, lower, upper, collapse, ensureprefix, ensuresuffix)
+	         Example: `normalize:"trim,trimsuffix=/"`
+
+	derive   - Template computing the field from the resolved fields of its
+	         struct after parsing; never read from env
+	         Example: `derive:"{{.Host}}:{{.Port}}"`
+
 Example usage:
 
 	type Config struct {
@@ -80,6 +195,91 @@ Example usage:
 		AppName string `env:"APP_NAME" required:"true" default:"MyApp"`
 	}
 
+Struct fields tagged envPrefix group related settings. Their fields are loaded
+like those of the target, with the prefix in front of their env and alias
+names, to any depth; errors name them by path (Database.Host):
+
+	type Config struct {
+		Database struct {
+			Host string `env:"HOST" required:"true"` // DB_HOST
+			Pool struct {
+				Max int `env:"MAX" default:"10"` // DB_POOL_MAX
+			} `envPrefix:"POOL_"`
+		} `envPrefix:"DB_"`
+	}
+
+Embedded structs are inlined, keeping the promoted field names; an envPrefix
+tag on them prefixes their variables like a nested struct:
+
+	type Config struct {
+		envload.HTTPServerConfig // HTTP_ADDR, HTTP_READ_TIMEOUT, ...
+		Worker `envPrefix:"WORKER_"`
+	}
+
+# Options
+
+LoadAndParse, Parse and ParseRecord accept options. WithStrictTags reports
+tag definitions that would otherwise be silently ignored (defaults that
+cannot convert, required:"yes", env tags on unexported fields) as errors:
+
+	err := envload.LoadAndParse(".env", &cfg, envload.WithStrictTags())
+
+WithAllowKeys and WithDenyKeys filter keys by exact name or glob before
+fields are resolved; filtered keys are treated as unset and deny patterns
+win over allow patterns:
+
+	err := envload.Parse(environ, &cfg, envload.WithAllowKeys("APP_*"), envload.WithDenyKeys("LD_*"))
+
+WithProfilePrefix selects a profile in a shared file: with "PROD_", the field
+tagged env:"DB_URL" reads PROD_DB_URL and falls back to DB_URL.
+WithKeyTranslation maps the variable names of an existing deployment to the
+names in env tags, used when the tag's own name is unset.
+
+WithEmptyAsUnset treats variables set to an empty value as unset, so
+aliases and defaults apply instead of an empty field.
+Whitespace-only values count as empty for all but string fields, which keep
+them unless WithWhitespaceAsEmpty is passed.
+
+WithPreflight dials or resolves the endpoints of fields tagged preflight:"tcp"
+or preflight:"dns" concurrently after loading, returning every failure.
+
+WithDeadline bounds the whole load, preflight checks included, and returns a
+*DeadlineError naming the phase that overran its budget. WithPartialResults
+keeps the fields populated in time and lists the unresolved ones.
+
+WithFreeze records a fingerprint of the populated struct; AssertUnchanged
+then reports the fields mutated since, which is useful in tests and health
+checks of debug builds.
+
+Clone deep-copies a config struct so local changes never alias the maps and
+slices of the original.
+
+Equal and Hash compare and fingerprint the env-tagged fields of a config,
+ignoring fields tagged env:"-", for change detection across reloads.
+
+Groups iterates over the schema of a config type group by group (the target,
+then each nested struct), yielding titles, prefixes and field descriptions for
+documentation generators.
+
+Redacted returns the resolved env-tagged values keyed by variable name with
+secret:"true" fields masked. The envloadtest package compares that output
+against golden JSON files, refreshed with go test -update, and GenerateEnv
+produces random env maps that parse into a struct for property-based tests,
+with RegisterGenerator supplying values for enums and parser-decoded types.
+NewEnvBuilder formats Go values (slices, maps, durations, net.IP, url.URL) into env maps.
+
+RegisterSecretScanner plugs in secret detection for untagged values, which
+are then masked in errors, parse error excerpts and Redacted output.
+
+ZeroSecrets clears secret:"true" fields once they are no longer needed,
+overwriting byte slices with zeros; LoadAndParse wipes its internal copies
+of the file content and env map after parsing.
+WithUnsetSecrets removes the variables of secret fields from the process
+environment once parsing succeeds.
+
+VerifyExample checks from a project's tests that an example env file defines
+every variable of a struct and that its non-empty values convert.
+
 # Supported Types
 
 Basic Types:
@@ -90,12 +290,32 @@ Basic Types:
   - bool (accepts: true, false, 1, 0)
   - time.Duration (e.g., "5s", "2m", "1h30m")
 
+Defined types built on these kinds (e.g. type Mode string) are supported as
+fields, slice elements, and map keys/values.
+
+Types implementing encoding.TextUnmarshaler (net.IP, netip.Addr, time.Time,
+uuid.UUID, custom enums) are decoded with UnmarshalText, as fields and as
+slice elements.
+
+url.URL fields must hold an absolute URL with a scheme and host, and
+net.IPNet fields a CIDR block (10.0.0.0/8) or a single address, which becomes
+a /32 or /128 network; []net.IPNet holds comma-separated allowlists. Redacted
+masks the password of URLs.
+
+RegisterParser teaches the loader to decode an application type, taking
+precedence over the built-in conversions for fields and slice elements.
+
+Pointers to them (*int, *string, *time.Duration, ...) stay nil unless the
+variable or a default is present, telling "not set" apart from "set to the
+zero value"; *string fields point to "" when the variable is set but empty.
+
 Slices (comma-separated values):
 
 	type Config struct {
-		Tags    []string  `env:"TAGS" default:"web,api"`
-		Ports   []int     `env:"PORTS" default:"8080,9090"`
-		Enabled []bool    `env:"ENABLED"`
+		Tags    []string        `env:"TAGS" default:"web,api"`
+		Ports   []int           `env:"PORTS" default:"8080,9090"`
+		Enabled []bool          `env:"ENABLED"`
+		Backoff []time.Duration `env:"BACKOFF" default:"1s,5s,30s"`
 	}
 
 Empty values in slices are automatically filtered.
@@ -109,6 +329,77 @@ Maps (comma-separated key:value pairs):
 		Limits   map[string]int    `env:"LIMITS"`
 	}
 
+Map values of struct type are decoded from JSON objects, one per key:
+
+	ENDPOINTS=search:{"url":"https://search.local","retries":3},auth:{"url":"https://auth.local"}
+
+	type Config struct {
+		Endpoints map[string]Endpoint `env:"ENDPOINTS"`
+	}
+
+Under WithInferredTypes, map[string]any values are stored as bool, int,
+float64, time.Duration or string, whichever they parse as first.
+
+Lazy fields (converted on first Get instead of at load time):
+
+	type Config struct {
+		Certificates envload.Lazy[[]string] `env:"CERTIFICATES"`
+	}
+
+	certs, err := cfg.Certificates.Get()
+
+Get applies the field's normalize, unique and validate tags to the converted
+value. The optional lazy:"true" tag only documents the field; WithStrictTags
+rejects it on types other than Lazy.
+
+Interface fields are built by a constructor registered with RegisterImpl;
+the field's env value selects which one:
+
+	envload.RegisterImpl("s3", func(envMap map[string]string) (Storage, error) {
+		return NewS3Storage(envMap["S3_BUCKET"]), nil
+	})
+
+	type Config struct {
+		Storage Storage `env:"STORAGE_BACKEND" impl:"s3|gcs|file" default:"file"`
+	}
+
+# Ready-Made Types
+
+TLSConfig reads TLS_* variables (cert/key files or PEM, CA, min version,
+client auth) and Build validates them into a *tls.Config:
+
+	var cfg envload.TLSConfig
+	err := envload.LoadAndParse(".env", &cfg)
+	tlsConfig, err := cfg.Build()
+
+PostgresDSN and MySQLDSN read DB_URL or discrete DB_HOST/DB_PORT/DB_USER/
+DB_PASSWORD/DB_NAME variables and DSN returns a validated, normalized
+connection string that keeps every connection parameter.
+
+HTTPServerConfig and HTTPClientConfig carry HTTP_* timeouts and limits with
+production defaults; Apply copies them onto an *http.Server and Build returns
+an *http.Client.
+
+LogLevel parses "debug|info|warn|error" and converts to slog.Level (Slog)
+or zapcore.Level values (Zap).
+
+Features is a map[string]bool of feature flags (FEATURES=cache:true,debug:false)
+with an Enabled(name, default) accessor.
+
+RateLimit parses "100/s", "5000/m" or "10/500ms" into {Count, Per}.
+
+Quantity parses Kubernetes-style resource quantities ("500m", "1.5", "256Mi").
+
+Money parses "19.99 USD" into integer minor units plus a currency code.
+
+DurationRange parses "1s..5s" into {Min, Max}, validating Min <= Max.
+
+WeightedList parses "a=3,b=1,c=1" into ordered {Name, Weight} entries with
+positive weights.
+
+OrderedMap keeps key:value pairs in the order they were written
+(MIDDLEWARE=auth:on,ratelimit:on,gzip:off).
+
 # Production Pattern
 
 Use the singleton pattern for application-wide configuration:
@@ -162,6 +453,19 @@ Usage:
 		log.Printf("Starting %s on port %d", cfg.AppName, cfg.Port)
 	}
 
+Services that reload their config at runtime can hold it in a Reloader.
+Reload parses and validates into a fresh struct and only replaces the one
+returned by Current on success; failed reloads keep the last good config and
+are reported to the OnReloadError callback and in Stats:
+
+	reloader, err := envload.NewReloader[Config](".env")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	reloader.OnReloadError(func(err error) { log.Printf("keeping previous config: %v", err) })
+	_ = reloader.Reload() // e.g. on SIGHUP
+
 # Error Handling
 
 envload provides descriptive errors for common issues:
@@ -170,16 +474,44 @@ envload provides descriptive errors for common issues:
   - Invalid type conversion: "invalid int for field 'Port': strconv.ParseInt: parsing \"abc\": invalid syntax"
   - Invalid target: "target must be a pointer to struct"
   - Invalid duration: "invalid duration for field 'Timeout': time.ParseDuration: invalid duration \"xyz\""
+  - Malformed .env line: "app.env:3:4: unexpected character \"-\" in variable name: \"BAD-KEY=2\"" (a *ParseError)
+
+Problems with a field's value are returned as a *FieldError carrying the
+field, the variable, a Code ("required", "invalid", "unique", "validate")
+and the failed validation Rule. WithMessages replaces their messages with
+text/template templates keyed by code or "validate.<rule>", for translated
+or branded messages:
+
+	err := envload.Parse(envMap, &cfg, envload.WithMessages(map[string]string{
+		"required": "Bitte {{.Env}} setzen.",
+	}))
+
+WriteErrors prints errors for people at a terminal: joined errors one per
+line, grouped by variable section (DATABASE for DATABASE_HOST) with aligned
+names and colors when the writer is a terminal. FormatErrors returns the
+same report as a string. ErrorsJSON encodes errors as JSON entries with
+their code, field, variable, rule or file position, for web UIs.
 
 If the .env file doesn't exist, envload logs a warning and continues
 with default values only (graceful degradation).
 
+Unexported fields carrying an env tag can never be populated; envload logs
+a warning naming the field once per type, or returns an error under WithStrictTags.
+
+WithWarnings additionally collects the warnings of a load, in order, as
+[]Warning values with a code, the file or field concerned and the message. WithSilent
+stops envload from writing to the standard logger.
+Warnings are highlighted only when the logger writes to a terminal and
+NO_COLOR is unset; WithColor overrides the detection.
+
 # Limitations
 
-  - Nested structs are not supported — use flat structures
-  - Pointer fields are not supported — use value types
+  - Nested structs need an envPrefix tag (which may be empty)
+  - Pointers to nested structs are not supported — nest struct values
   - Map keys must be strings
-  - Slice elements must be basic types (string, int, float, bool)
+  - Slice elements must be basic types (string, int, float, bool), durations
+    or types implementing encoding.TextUnmarshaler, url.URL, net.IPNet and
+    types with a registered parser
 
 For more details and examples, see the README.md file at:
 https://github.com/go-fynx/envload
@@ -0,0 +1,90 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+type (
+	// DurationRange is a duration interval parsed from "1s..5s", for backoff and jitter settings.
+	// A single duration ("3s") yields a range where Min equals Max.
+	//
+	// Example:
+	//
+	//	type Config struct {
+	//		RetryBackoff envload.DurationRange `env:"RETRY_BACKOFF" default:"100ms..2s"`
+	//	}
+	//
+	//	time.Sleep(cfg.RetryBackoff.Random())
+	DurationRange struct {
+		Min time.Duration
+		Max time.Duration
+	}
+)
+
+const (
+	// [durationRangeSeparator] separates the bounds of a DurationRange.
+	durationRangeSeparator = ".."
+)
+
+var (
+	errInvalidDurationRange = errors.New("invalid duration range")
+)
+
+// ParseDurationRange parses "min..max" (or a single duration) and checks that min <= max.
+func ParseDurationRange(rawValue string) (DurationRange, error) {
+	minValue, maxValue, isRange := strings.Cut(strings.TrimSpace(rawValue), durationRangeSeparator)
+	if !isRange {
+		maxValue = minValue
+	}
+
+	minDuration, err := time.ParseDuration(strings.TrimSpace(minValue))
+	if err != nil {
+		return DurationRange{}, fmt.Errorf("%w %q: min: %w", errInvalidDurationRange, rawValue, err)
+	}
+
+	maxDuration, err := time.ParseDuration(strings.TrimSpace(maxValue))
+	if err != nil {
+		return DurationRange{}, fmt.Errorf("%w %q: max: %w", errInvalidDurationRange, rawValue, err)
+	}
+
+	if minDuration > maxDuration {
+		return DurationRange{}, fmt.Errorf("%w %q: min %s exceeds max %s", errInvalidDurationRange, rawValue, minDuration, maxDuration)
+	}
+
+	return DurationRange{Min: minDuration, Max: maxDuration}, nil
+}
+
+// Contains reports whether duration lies within the range, bounds included.
+func (durationRange DurationRange) Contains(duration time.Duration) bool {
+	return duration >= durationRange.Min && duration <= durationRange.Max
+}
+
+// Random returns a uniformly distributed duration within the range, for jitter.
+func (durationRange DurationRange) Random() time.Duration {
+	if durationRange.Max <= durationRange.Min {
+		return durationRange.Min
+	}
+
+	return durationRange.Min + rand.N(durationRange.Max-durationRange.Min+1) //nolint:gosec // note: Jitter does not need a cryptographic source.
+}
+
+// String formats the range as "min..max".
+func (durationRange DurationRange) String() string {
+	return durationRange.Min.String() + durationRangeSeparator + durationRange.Max.String()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (durationRange *DurationRange) UnmarshalText(text []byte) error {
+	parsed, err := ParseDurationRange(string(text))
+	if err != nil {
+		return err
+	}
+
+	*durationRange = parsed
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package envload
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_DurationRange_FieldDecoding(t *testing.T) {
+	envMap := map[string]string{
+		"BACKOFF": "1s..5s",
+		"JITTER":  " 100ms .. 250ms ",
+		"FIXED":   "3s",
+	}
+
+	var config struct {
+		Backoff DurationRange `env:"BACKOFF"`
+		Jitter  DurationRange `env:"JITTER"`
+		Fixed   DurationRange `env:"FIXED"`
+		Default DurationRange `env:"DEFAULT_RANGE" default:"1m..1h"`
+	}
+
+	if err := populateStruct(envMap, &config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := Tests[DurationRange]{
+		{"range", config.Backoff, DurationRange{Min: time.Second, Max: 5 * time.Second}},
+		{"spaces around bounds", config.Jitter, DurationRange{Min: 100 * time.Millisecond, Max: 250 * time.Millisecond}},
+		{"single duration", config.Fixed, DurationRange{Min: 3 * time.Second, Max: 3 * time.Second}},
+		{"default", config.Default, DurationRange{Min: time.Minute, Max: time.Hour}},
+	}
+
+	tests.runTests(t)
+
+	for range 100 {
+		if value := config.Backoff.Random(); !config.Backoff.Contains(value) {
+			t.Fatalf("Random returned %v outside %v", value, config.Backoff)
+		}
+	}
+
+	if config.Fixed.Random() != 3*time.Second {
+		t.Errorf("Expected fixed range to return 3s, got %v", config.Fixed.Random())
+	}
+
+	if config.Backoff.String() != "1s..5s" {
+		t.Errorf("Expected '1s..5s', got '%s'", config.Backoff.String())
+	}
+}
+
+func Test_ParseDurationRange_Invalid(t *testing.T) {
+	for _, rawValue := range []string{"5s..1s", "soon..5s", "1s..later", "", "1s..5s..9s"} {
+		t.Run(rawValue, func(t *testing.T) {
+			if _, err := ParseDurationRange(rawValue); !errors.Is(err, errInvalidDurationRange) {
+				t.Errorf("Expected errInvalidDurationRange for %q, got %v", rawValue, err)
+			}
+		})
+	}
+}
@@ -1,22 +1,32 @@
 package envload
 
 import (
+	"encoding"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
-
-	"github.com/joho/godotenv"
 )
 
 type (
 	fieldResolver struct {
-		field    reflect.StructField
-		value    reflect.Value
-		rawValue string
+		field             reflect.StructField
+		value             reflect.Value
+		envMap            map[string]string
+		envKey            string
+		sourceKey         string   // Key of envMap the value was read from, empty for defaults.
+		normalized        []string // Strings rewritten by the `normalize` tag.
+		prefix            string
+		rawValue          string
+		present           bool
+		inferTypes        bool
+		emptyAsUnset      bool
+		whitespaceAsEmpty bool
 	}
 )
 
@@ -26,26 +36,80 @@ const (
 )
 
 var (
+	// [textUnmarshalerType] is used to detect slice elements implementing encoding.TextUnmarshaler.
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+	// [stringType] is the only type that takes the string fast path; defined string types
+	// may unmarshal themselves.
+	stringType = reflect.TypeFor[string]()
+
 	errTargetMustBePointer         = errors.New("target must be a pointer")
 	errTargetMustBePointerToStruct = errors.New("target must be a pointer to struct")
 	errInvalidMapFormat            = errors.New("invalid map format for field")
 	errUnsupportedMapValueType     = errors.New("unsupported map value type")
 	errMissingRequiredField        = errors.New("missing required field")
+	errDuplicateElement            = errors.New("duplicate element")
 )
 
 // LoadAndParse reads a .env file and maps its values to a struct.
-// It supports env, default, and required struct tags.
-// If the env file cannot be read, it logs a warning and continues with default values only.
-func LoadAndParse(filePath string, target any) error {
-	envMap, err := godotenv.Read(filePath)
+// It supports env, default, and required struct tags; struct fields tagged `envPrefix`
+// are loaded from the variables behind that prefix.
+// Lines of the form "#include other.env" inline another file, resolved relative to the including file.
+// If the env file cannot be read, it logs a warning and continues with default values only,
+// except when an included file cannot be read, an include forms a cycle or the file
+// exceeds a limit set with [WithMaxFileSize], [WithMaxKeys] or [WithMaxValueLength],
+// which is returned as an error.
+func LoadAndParse(filePath string, target any, opts ...Option) error {
+	return load(target, opts, filePath, true, func(config options) (map[string]string, error) {
+		return readEnvFileWithRetry(filePath, config)
+	})
+}
+
+// load maps the env map returned by read to target, counting the [WithDeadline] budget
+// from before the read. With fallback, a missing or unreadable file named name is
+// logged and target gets its defaults only; otherwise read errors are returned.
+func load(target any, opts []Option, name string, fallback bool, read func(config options) (map[string]string, error)) error {
+	config := newOptions(opts)
+
+	envMap, err := read(config)
+	if errors.Is(err, errLimitExceeded) || errors.Is(err, errIncludeFailed) {
+		// Only a missing or unreadable top-level file falls back to defaults; a broken
+		// include would otherwise drop the keys of the including file too.
+		return err
+	}
+
+	if err := config.checkDeadline(phaseRead); err != nil {
+		return err
+	}
+
 	if err != nil {
-		// Log warning and continue with defaults only - allows graceful degradation.
-		log.Printf("\033[33m[Warning]:\033[0m Could not read env file [%s: %v]. Using defaults only.", filePath, err)
+		if !fallback {
+			return err
+		}
+
+		// Warn and continue with defaults only - allows graceful degradation.
+		config.warn(missingFileWarning(name, err))
 
 		envMap = make(map[string]string)
 	}
 
-	return populateStruct(envMap, target)
+	// The map is internal, so drop its copies of the values once the struct is populated.
+	defer clear(envMap)
+
+	// Keep counting the WithDeadline budget from the start of the load.
+	return populateStruct(envMap, target, append(slices.Clip(opts), withDeadlineAt(config.deadlineAt))...)
+}
+
+// logWarning writes a warning to the standard logger, with the prefix highlighted when color is set.
+func logWarning(message string, color bool) {
+	log.Print(colorize("[Warning]:", ansiYellow, color) + " " + message)
+}
+
+// Parse maps the values of an already loaded env map to a struct.
+// It supports the same struct tags as [LoadAndParse].
+// Parse keeps no shared state, so it is safe to call concurrently on different targets.
+func Parse(envMap map[string]string, target any, opts ...Option) error {
+	return populateStruct(envMap, target, opts...)
 }
 
 // validateStruct validates that the target is a pointer to a struct.
@@ -64,39 +128,183 @@ func validateStruct(target any) error {
 
 // populateStruct sets values from envMap into the target struct.
 // Uses struct tags: `env` for key, `default` for fallback value, `required` for validation.
-func populateStruct(envMap map[string]string, target any) error {
+func populateStruct(envMap map[string]string, target any, opts ...Option) (err error) {
 	if err := validateStruct(target); err != nil {
 		return err
 	}
 
+	config := newOptions(opts)
+
+	catalog, err := compileMessages(config.messages)
+	if err != nil {
+		return err
+	}
+
+	defer func() { err = catalog.localize(err) }()
+
+	envMap, sources := config.translateKeys(config.applyProfile(envMap))
+
+	envMap, err = config.filterKeys(envMap)
+	if err != nil {
+		return err
+	}
+
 	value := reflect.ValueOf(target)
 
 	value = value.Elem()
-	typ := value.Type()
 
-	var resolver fieldResolver
-	for i := range value.NumField() {
-		resolver.field = typ.Field(i)
-		resolver.value = value.Field(i)
+	if config.deadline > 0 && !config.partialResults {
+		original := snapshotValue(value)
+		defer func() { restoreOnDeadline(value, original, err) }()
+	}
+
+	var (
+		resolver = fieldResolver{
+			inferTypes:        config.inferTypes,
+			emptyAsUnset:      config.emptyAsUnset,
+			whitespaceAsEmpty: config.whitespaceAsEmpty,
+		}
+		secretKeys  []string
+		crossChecks []crossFieldCheck
+		autoPorts   []structField
+		derived     []structField
+	)
+
+	// Walk the cached layout rather than structFields, so populating does not allocate.
+	layouts := structLayout(value.Type())
+
+	for i := range layouts {
+		field := layouts[i].bind(value)
+
+		if deadlineErr := config.checkDeadline(phasePopulate); deadlineErr != nil {
+			// Slow constructors or validators of the previous field.
+			deadlineErr.Unresolved = unresolvedFields(structFields(value)[i:])
+
+			return deadlineErr
+		}
+
+		resolver.field = field.StructField
+		resolver.value = field.value
+		resolver.prefix = field.prefix
+
+		if config.strictTags {
+			if err := resolver.validateTags(); err != nil {
+				return err
+			}
+		} else if resolver.isUnexportedWithEnv() {
+			config.warnOnce(warningKey{value.Type(), i}, resolver.unexportedFieldWarning())
+		}
+
+		if layouts[i].plain {
+			// Fast path: plain strings need no tag lookups, conversion or checks.
+			resolver.setPlainString(&layouts[i], envMap)
+			continue
+		}
 
 		resolver.resolveValue(envMap)
 
+		if config.unsetSecrets && resolver.envKey != "" && isSecretField(resolver.field) {
+			secretKeys = append(secretKeys, resolver.envKey)
+			secretKeys = append(secretKeys, resolver.aliasKeys()...)
+
+			if resolver.sourceKey != "" {
+				// The variable actually read, e.g. PROD_DB_PASSWORD under a profile
+				// or the external name of a key translation.
+				secretKeys = append(secretKeys, originalKey(sources, resolver.sourceKey))
+			}
+		}
+
 		if resolver.rawValue == "" && resolver.isRequired() {
-			return fmt.Errorf("%w: field=%s env=%s",
+			return resolver.fieldError(codeRequired, fmt.Errorf("%w: field=%s env=%s",
 				errMissingRequiredField,
 				resolver.field.Name,
-				resolver.field.Tag.Get("env"),
-			)
+				strings.Join(append([]string{resolver.envKey}, resolver.aliasKeys()...), "|"),
+			))
+		}
+
+		if hasCrossFieldRules(resolver.field) {
+			crossChecks = append(crossChecks, crossFieldCheck{field: field, populated: resolver.rawValue != ""})
+		}
+
+		if resolver.field.Tag.Get("auto") == autoPort && resolver.value.CanSet() {
+			autoPorts = append(autoPorts, field)
+		}
+
+		if resolver.field.Tag.Get("derive") != "" && resolver.value.CanSet() {
+			derived = append(derived, field)
 		}
 
 		if resolver.rawValue == "" {
 			// Skip fields without env tag or that can't be set.
+			resolver.setEmptyPointer()
 			continue
 		}
 
 		if err := resolver.setValue(); err != nil {
+			return resolver.fieldError(codeInvalid, err)
+		}
+
+		if isLazyField(resolver.field) {
+			continue // Normalized and validated by Get once converted.
+		}
+
+		if err := resolver.normalize(); err != nil {
 			return err
 		}
+
+		if resolver.isUnique() {
+			if err := resolver.checkUnique(); err != nil {
+				return resolver.fieldError(codeUnique, err)
+			}
+		}
+
+		if err := resolver.validate(); err != nil {
+			return resolver.fieldError(codeValidate, err)
+		}
+	}
+
+	if err := config.checkDeadline(phasePopulate); err != nil {
+		return err
+	}
+
+	if err := assignAutoPorts(autoPorts); err != nil {
+		return err
+	}
+
+	if err := deriveFields(derived); err != nil {
+		return err
+	}
+
+	if err := validateCrossFields(crossChecks); err != nil {
+		return err
+	}
+
+	for _, nested := range append(nestedStructs(value), value) {
+		if err := validateStructValue(nested); err != nil {
+			return err
+		}
+	}
+
+	if err := config.checkDeadline(phaseValidate); err != nil {
+		return err
+	}
+
+	if config.preflight {
+		if err := runPreflight(value, config.preflightBudget()); err != nil {
+			if deadlineErr := config.checkDeadline(phasePreflight); deadlineErr != nil {
+				return errors.Join(deadlineErr, err)
+			}
+
+			return err
+		}
+	}
+
+	if err := unsetSecretEnv(secretKeys); err != nil {
+		return err
+	}
+
+	if config.freeze {
+		freezeConfig(target)
 	}
 
 	return nil
@@ -104,18 +312,67 @@ func populateStruct(envMap map[string]string, target any) error {
 
 func (resolver *fieldResolver) resolveValue(envMap map[string]string) {
 	resolver.rawValue = ""
-	envKey := resolver.field.Tag.Get("env")
+	resolver.sourceKey = ""
+	resolver.normalized = nil
+	resolver.present = false
+	resolver.envMap = envMap
+	resolver.envKey = resolver.field.Tag.Get("env")
 
-	if envKey == "" || !resolver.value.CanSet() {
+	if resolver.envKey == "" || !resolver.value.CanSet() {
 		return // Skip fields without env tag or that can't be set.
 	}
 
-	rawValue, ok := envMap[envKey]
+	resolver.envKey = resolver.prefix + resolver.envKey
+
+	rawValue, ok := resolver.lookupKey(envMap, resolver.envKey)
+	if ok {
+		resolver.sourceKey = resolver.envKey
+	} else {
+		rawValue, ok = resolver.lookupAlias(envMap)
+	}
+
 	if !ok {
-		rawValue = resolver.field.Tag.Get("default")
+		rawValue, ok = resolver.field.Tag.Lookup("default")
 	}
 
 	resolver.rawValue = rawValue
+	resolver.present = ok
+}
+
+// setPlainString sets the plain string field of layout (see [fieldLayout]) from envMap,
+// following the rules of resolveValue and lookupKey for a field without aliases.
+func (resolver *fieldResolver) setPlainString(layout *fieldLayout, envMap map[string]string) {
+	rawValue, ok := envMap[layout.envKey]
+
+	if resolver.whitespaceAsEmpty && strings.TrimSpace(rawValue) == "" {
+		rawValue = ""
+	}
+
+	if !ok || (rawValue == "" && resolver.emptyAsUnset) {
+		rawValue = layout.defaultValue
+	}
+
+	if rawValue != "" {
+		resolver.value.SetString(rawValue)
+	}
+}
+
+// lookupKey returns the value of key in envMap. Whitespace-only values become empty
+// for non-string fields, and for every field under [WithWhitespaceAsEmpty]. Under
+// [WithEmptyAsUnset] or the `emptyunset` tag, an empty value is reported as missing
+// so the lookup falls through to aliases and the default.
+func (resolver *fieldResolver) lookupKey(envMap map[string]string, key string) (string, bool) {
+	rawValue, ok := envMap[key]
+
+	if strings.TrimSpace(rawValue) == "" && (resolver.whitespaceAsEmpty || indirectType(resolver.field.Type).Kind() != reflect.String) {
+		rawValue = ""
+	}
+
+	if rawValue == "" && (resolver.emptyAsUnset || resolver.field.Tag.Get("emptyunset") == "true") {
+		return "", false
+	}
+
+	return rawValue, ok
 }
 
 // isRequired checks if a field has the required tag set to true.
@@ -123,12 +380,71 @@ func (resolver *fieldResolver) isRequired() bool {
 	return resolver.field.Tag.Get("required") == "true"
 }
 
+// isUnique checks if a field has the unique tag set to true.
+func (resolver *fieldResolver) isUnique() bool {
+	return resolver.field.Tag.Get("unique") == "true"
+}
+
+// checkUnique rejects slices that contain the same element twice.
+// Example: ALLOWED_HOSTS=a.com,b.com,a.com -> error naming "a.com" at index 2.
+func (resolver *fieldResolver) checkUnique() error {
+	if resolver.value.Kind() != reflect.Slice || !resolver.value.Type().Elem().Comparable() {
+		return nil
+	}
+
+	seen := make(map[any]int, resolver.value.Len())
+
+	for i := range resolver.value.Len() {
+		element := resolver.value.Index(i).Interface()
+		if first, ok := seen[element]; ok {
+			return fmt.Errorf("%w for field '%s': %v at index %d (first at index %d)",
+				errDuplicateElement, resolver.field.Name, element, i, first)
+		}
+
+		seen[element] = i
+	}
+
+	return nil
+}
+
 // setValue sets rawValue into the given fieldVal based on its kind and type.
 // Supported types: string, int, uint, float, bool, time.Duration,
 // slices ([]string, []int, []float64, []bool), maps (map[string]string, map[string]int, etc.).
+// [Lazy] fields only capture rawValue; conversion happens on their first Get call.
+// Types with a parser from [RegisterParser] are converted by it before any other rule.
+// Interface fields are built by the constructor registered for rawValue (see [RegisterImpl]),
+// and pointer fields point to a new value holding the conversion of rawValue.
+// The package's own value types (e.g. [LogLevel]) parse rawValue themselves, and other types
+// implementing encoding.TextUnmarshaler (net.IP, time.Time, uuid.UUID) are given rawValue
+// through UnmarshalText.
 //
 //nolint:exhaustive,revive,cyclop // note: This function is used to set values into the given fieldVal based on its kind and type. so we need to ignore some linters.
 func (resolver *fieldResolver) setValue() error {
+	if lazy, ok := resolver.value.Addr().Interface().(lazyValue); ok {
+		// Defer conversion until the first Get call.
+		lazy.setLazy(resolver.field, resolver.rawValue)
+		return nil
+	}
+
+	if parse, ok := lookupParser(resolver.value.Type()); ok {
+		parsed, err := parse(resolver.rawValue)
+		if err != nil {
+			return fmt.Errorf("invalid %s for field '%s': %w", resolver.value.Type(), resolver.field.Name, err)
+		}
+
+		resolver.value.Set(parsed)
+
+		return nil
+	}
+
+	if unmarshaler, ok := resolver.value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalText([]byte(resolver.rawValue)); err != nil {
+			return fmt.Errorf("invalid %s for field '%s': %w", resolver.value.Type(), resolver.field.Name, err)
+		}
+
+		return nil
+	}
+
 	switch resolver.field.Type.Kind() {
 	case reflect.String:
 		return resolver.setString()
@@ -150,6 +466,12 @@ func (resolver *fieldResolver) setValue() error {
 
 	case reflect.Map:
 		return resolver.setMap()
+
+	case reflect.Interface:
+		return resolver.setImpl()
+
+	case reflect.Pointer:
+		return resolver.setPointer()
 	default:
 	}
 
@@ -166,12 +488,17 @@ func (resolver *fieldResolver) setString() error {
 // setIntOrDuration sets an integer or time.Duration.
 // Example: TIMEOUT=5s -> time.Duration(5 * time.Second).
 func (resolver *fieldResolver) setIntOrDuration() error {
-	if resolver.value.Type().PkgPath() == "time" && resolver.value.Type().Name() == "Duration" {
+	if isDurationType(resolver.value.Type()) {
 		return resolver.setDuration()
 	}
 	return resolver.setInt()
 }
 
+// isDurationType reports whether typ is time.Duration.
+func isDurationType(typ reflect.Type) bool {
+	return typ.PkgPath() == "time" && typ.Name() == "Duration"
+}
+
 // setDuration parses and sets a time.Duration value from a string.
 // It expects strings like "5s", "2m", "1h30m" etc., and sets the duration into the fieldVal.
 // Example: TIMEOUT="5s" -> fieldVal.Set(time.Duration(5 * time.Second)).
@@ -233,14 +560,16 @@ func (resolver *fieldResolver) setBool() error {
 }
 
 // setSlice sets a slice by splitting on commas and converting to appropriate types.
-// Supports: []string, []int, []int64, []float64, []bool
+// Supports: []string, []int, []int64, []float64, []bool, []time.Duration, defined types of those kinds,
+// element types implementing encoding.TextUnmarshaler (e.g. []net.IP), those with a [RegisterParser] parser
+// and interfaces with [RegisterImpl] constructors.
 // Example: TAGS=dev,prod,test -> []string{"dev", "prod", "test"}
 //
 //	PORTS=8080,9090,3000 -> []int{8080, 9090, 3000}.
 //
 //nolint:exhaustive // note: This function is used to set values into the given fieldVal based on its kind and type.
 func (resolver *fieldResolver) setSlice() error {
-	elemKind := resolver.value.Type().Elem().Kind()
+	elemType := resolver.value.Type().Elem()
 	parts := strings.Split(resolver.rawValue, ",")
 
 	// Trim spaces from all parts.
@@ -248,9 +577,13 @@ func (resolver *fieldResolver) setSlice() error {
 		parts[i] = strings.TrimSpace(parts[i])
 	}
 
-	switch elemKind {
+	if isDurationType(elemType) || reflect.PointerTo(elemType).Implements(textUnmarshalerType) || hasParser(elemType) || elemType.Kind() == reflect.Interface {
+		return resolver.setDecodedSlice(parts)
+	}
+
+	switch elemType.Kind() {
 	case reflect.String:
-		resolver.value.Set(reflect.ValueOf(parts))
+		resolver.setStringSlice(parts)
 		return nil
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -270,6 +603,23 @@ func (resolver *fieldResolver) setSlice() error {
 	}
 }
 
+// setStringSlice sets the parts as a string slice.
+// Defined element types (e.g. []Mode where type Mode string) are converted element by element.
+func (resolver *fieldResolver) setStringSlice(parts []string) {
+	sliceType := resolver.value.Type()
+	if sliceType.Elem() == stringType {
+		resolver.value.Set(reflect.ValueOf(parts).Convert(sliceType))
+		return
+	}
+
+	slice := reflect.MakeSlice(sliceType, len(parts), len(parts))
+	for i, part := range parts {
+		slice.Index(i).SetString(part)
+	}
+
+	resolver.value.Set(slice)
+}
+
 // [setIntSlice] converts string parts to integers and sets the slice.
 func (resolver *fieldResolver) setIntSlice(parts []string) error {
 	elemType := resolver.value.Type().Elem()
@@ -360,6 +710,42 @@ func (resolver *fieldResolver) setFloatSlice(parts []string) error {
 	return nil
 }
 
+// setDecodedSlice decodes every part with the same converters used for single fields,
+// so element types with their own parsing rules (e.g. []time.Duration) behave like scalar fields.
+func (resolver *fieldResolver) setDecodedSlice(parts []string) error {
+	elemType := resolver.value.Type().Elem()
+
+	// Filter out empty parts first to get correct slice size.
+	validParts := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part != "" {
+			validParts = append(validParts, part)
+		}
+	}
+
+	// Create slice with correct size (number of valid parts).
+	slice := reflect.MakeSlice(resolver.value.Type(), len(validParts), len(validParts))
+
+	// Process only valid parts with sequential indexes.
+	for i, part := range validParts {
+		element := fieldResolver{
+			field:    reflect.StructField{Name: resolver.field.Name, Type: elemType, Tag: resolver.field.Tag},
+			value:    slice.Index(i),
+			envMap:   resolver.envMap,
+			envKey:   resolver.envKey,
+			rawValue: part,
+		}
+
+		if err := element.setValue(); err != nil {
+			return fmt.Errorf("invalid element in slice for field '%s' at index %d: %w", resolver.field.Name, i, err)
+		}
+	}
+
+	resolver.value.Set(slice)
+
+	return nil
+}
+
 // setBoolSlice converts string parts to booleans and sets the slice.
 func (resolver *fieldResolver) setBoolSlice(parts []string) error {
 	// Filter out empty parts first to get correct slice size.
@@ -389,7 +775,8 @@ func (resolver *fieldResolver) setBoolSlice(parts []string) error {
 }
 
 // setMap sets a map by parsing comma-separated key:value pairs.
-// Supports: map[string]string, map[string]int, map[string]float64, map[string]bool
+// Supports: map[string]string, map[string]int, map[string]float64, map[string]bool,
+// and map[string]Struct where every value is a JSON object.
 // Example: SETTINGS=debug:true,theme:dark -> map[string]string{"debug":"true", "theme":"dark"}
 //
 //	PORTS=api:8080,db:5432 -> map[string]int{"api":8080, "db":5432}
@@ -403,6 +790,11 @@ func (resolver *fieldResolver) setMap() error {
 	}
 
 	pairs := strings.Split(resolver.rawValue, ",")
+	if valueKind == reflect.Struct {
+		// JSON values contain commas of their own, so split only outside of them.
+		pairs = splitOutsideJSON(resolver.rawValue)
+	}
+
 	mapType := resolver.value.Type()
 	result := reflect.MakeMap(mapType)
 
@@ -421,7 +813,7 @@ func (resolver *fieldResolver) setMap() error {
 			return fmt.Errorf("invalid map value for field '%s' key '%s': %w", resolver.field.Name, key, err)
 		}
 
-		keyVal := reflect.ValueOf(key)
+		keyVal := reflect.ValueOf(key).Convert(mapType.Key())
 		result.SetMapIndex(keyVal, convertedValue)
 	}
 
@@ -436,7 +828,7 @@ func (resolver *fieldResolver) setMap() error {
 func (resolver *fieldResolver) convertMapValue(value string, valueKind reflect.Kind) (reflect.Value, error) {
 	switch valueKind {
 	case reflect.String:
-		return reflect.ValueOf(value), nil
+		return reflect.ValueOf(value).Convert(resolver.value.Type().Elem()), nil
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		intVal, err := strconv.ParseInt(value, 10, 64)
@@ -464,9 +856,58 @@ func (resolver *fieldResolver) convertMapValue(value string, valueKind reflect.K
 		if err != nil {
 			return reflect.Value{}, err
 		}
-		return reflect.ValueOf(boolVal), nil
+		return reflect.ValueOf(boolVal).Convert(resolver.value.Type().Elem()), nil
+
+	case reflect.Struct:
+		structVal := reflect.New(resolver.value.Type().Elem())
+		if err := json.Unmarshal([]byte(value), structVal.Interface()); err != nil {
+			return reflect.Value{}, err
+		}
+		return structVal.Elem(), nil
+
+	case reflect.Interface:
+		if !resolver.inferTypes || resolver.value.Type().Elem().NumMethod() != 0 {
+			return reflect.Value{}, fmt.Errorf("%w: %v (use WithInferredTypes for map[string]any)", errUnsupportedMapValueType, valueKind)
+		}
+
+		return reflect.ValueOf(inferValue(value)), nil
 
 	default:
 		return reflect.Value{}, fmt.Errorf("%w: %v", errUnsupportedMapValueType, valueKind)
 	}
 }
+
+// splitOutsideJSON splits rawValue on commas that are not inside JSON objects, arrays or strings.
+// Example: a:{"x":1,"y":2},b:{"x":3} -> [a:{"x":1,"y":2} b:{"x":3}].
+func splitOutsideJSON(rawValue string) []string {
+	var (
+		parts    []string
+		depth    int
+		inString bool
+		escaped  bool
+		start    int
+	)
+
+	for i := range len(rawValue) {
+		char := rawValue[i]
+
+		switch {
+		case escaped:
+			escaped = false
+		case inString && char == '\\':
+			escaped = true
+		case char == '"':
+			inString = !inString
+		case inString:
+		case char == '{' || char == '[':
+			depth++
+		case char == '}' || char == ']':
+			depth--
+		case char == ',' && depth == 0:
+			parts = append(parts, rawValue[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, rawValue[start:])
+}
@@ -5,7 +5,10 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"net"
+	"net/netip"
 	"os"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
@@ -258,6 +261,35 @@ func BenchmarkLoadAndParse(b *testing.B) {
 	}
 }
 
+// BenchmarkPopulateStruct_StringFields measures the plain string fast path on a 200-field struct.
+func BenchmarkPopulateStruct_StringFields(b *testing.B) {
+	const fieldCount = 200
+
+	fields := make([]reflect.StructField, fieldCount)
+	envMap := make(map[string]string, fieldCount)
+
+	for i := range fieldCount {
+		key := fmt.Sprintf("KEY_%d", i)
+		fields[i] = reflect.StructField{
+			Name: fmt.Sprintf("Field%d", i),
+			Type: reflect.TypeFor[string](),
+			Tag:  reflect.StructTag(fmt.Sprintf(`env:"%s"`, key)),
+		}
+		envMap[key] = fmt.Sprintf("value_%d", i)
+	}
+
+	target := reflect.New(reflect.StructOf(fields)).Interface()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if err := populateStruct(envMap, target); err != nil {
+			b.Fatalf("populateStruct failed: %v", err)
+		}
+	}
+}
+
 // LoadEnv loads a .env file and decodes values into a struct using `env` tags.
 func LoadEnv_test(filePath string) error {
 	var (
@@ -776,3 +808,452 @@ func Test_MapDuplicateKeyHandling(t *testing.T) {
 	// This demonstrates the issue - silent overwrite.
 	t.Logf("Map with duplicates: %+v", config.Settings)
 }
+
+// Test_Parse_Concurrent runs Parse from many goroutines on distinct targets; run with -race.
+func Test_Parse_Concurrent(t *testing.T) {
+	type options struct {
+		Name    string        `env:"NAME"`
+		Limit   int           `env:"LIMIT" default:"10"`
+		Timeout time.Duration `env:"TIMEOUT"`
+		Tags    []string      `env:"TAGS"`
+		Lazy    Lazy[int]     `env:"LIMIT" default:"10"`
+	}
+
+	const workers = 32
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, workers)
+
+	for i := range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			envMap := map[string]string{
+				"NAME":    fmt.Sprintf("worker-%d", i),
+				"TIMEOUT": fmt.Sprintf("%ds", i),
+				"TAGS":    "a,b",
+			}
+
+			var target options
+			if err := Parse(envMap, &target); err != nil {
+				errs <- err
+				return
+			}
+
+			if target.Name != envMap["NAME"] || target.Timeout != time.Duration(i)*time.Second {
+				errs <- fmt.Errorf("worker %d got unexpected values: %+v", i, target)
+				return
+			}
+
+			if limit, err := target.Lazy.Get(); err != nil || limit != 10 {
+				errs <- fmt.Errorf("worker %d lazy value: %d (err: %v)", i, limit, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// Test_NamedTypes_FieldDecoding tests fields whose types are defined on top of builtin kinds.
+func Test_NamedTypes_FieldDecoding(t *testing.T) {
+	type (
+		Mode     string
+		Level    int
+		Enabled  bool
+		Tags     []string
+		Settings map[string]string
+	)
+
+	envMap := map[string]string{
+		"MODE":          "release",
+		"LEVEL":         "3",
+		"ENABLED":       "true",
+		"MODES":         "debug,release",
+		"LEVELS":        "1,2",
+		"TAGS":          "a,b",
+		"MODE_BY_NAME":  "api:debug,worker:release",
+		"FLAG_BY_MODE":  "debug:true",
+		"SETTINGS":      "theme:dark",
+		"LEVEL_BY_NAME": "api:2",
+	}
+
+	var config struct {
+		Mode        Mode             `env:"MODE"`
+		Level       Level            `env:"LEVEL"`
+		Enabled     Enabled          `env:"ENABLED"`
+		Modes       []Mode           `env:"MODES"`
+		Levels      []Level          `env:"LEVELS"`
+		Tags        Tags             `env:"TAGS"`
+		ModeByName  map[string]Mode  `env:"MODE_BY_NAME"`
+		FlagByMode  map[Mode]Enabled `env:"FLAG_BY_MODE"`
+		Settings    Settings         `env:"SETTINGS"`
+		LevelByName map[string]Level `env:"LEVEL_BY_NAME"`
+	}
+
+	if err := populateStruct(envMap, &config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := Tests[string]{
+		{"named string", string(config.Mode), "release"},
+		{"named int", fmt.Sprint(config.Level), "3"},
+		{"named bool", fmt.Sprint(config.Enabled), "true"},
+		{"slice of named string", fmt.Sprint(config.Modes), "[debug release]"},
+		{"slice of named int", fmt.Sprint(config.Levels), "[1 2]"},
+		{"named slice", fmt.Sprint(config.Tags), "[a b]"},
+		{"map of named string values", string(config.ModeByName["worker"]), "release"},
+		{"map with named keys and values", fmt.Sprint(config.FlagByMode[Mode("debug")]), "true"},
+		{"named map", config.Settings["theme"], "dark"},
+		{"map of named int values", fmt.Sprint(config.LevelByName["api"]), "2"},
+	}
+
+	tests.runTests(t)
+}
+
+// Test_DecodedSlice_FieldDecoding tests slices whose elements need their own parsing rules.
+func Test_DecodedSlice_FieldDecoding(t *testing.T) {
+	type LogLevel string
+
+	t.Run("duration and named elements", func(t *testing.T) {
+		envMap := map[string]string{
+			"BACKOFF": "1s, 5s,,1m",
+			"LEVELS":  "debug,info",
+		}
+
+		var config struct {
+			Backoff []time.Duration `env:"BACKOFF"`
+			Levels  []LogLevel      `env:"LEVELS"`
+		}
+
+		if err := populateStruct(envMap, &config); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := []time.Duration{time.Second, 5 * time.Second, time.Minute}
+		if fmt.Sprint(config.Backoff) != fmt.Sprint(expected) {
+			t.Errorf("Expected %v, got %v", expected, config.Backoff)
+		}
+
+		if len(config.Levels) != 2 || config.Levels[1] != "info" {
+			t.Errorf("Expected [debug info], got %v", config.Levels)
+		}
+	})
+
+	t.Run("invalid element reports index", func(t *testing.T) {
+		envMap := map[string]string{"BACKOFF": "1s,soon"}
+
+		var config struct {
+			Backoff []time.Duration `env:"BACKOFF"`
+		}
+
+		err := populateStruct(envMap, &config)
+		expectedSubstring := "invalid element in slice for field 'Backoff' at index 1"
+		if err == nil || !strings.Contains(err.Error(), expectedSubstring) {
+			t.Errorf("Expected error to contain '%s', got %v", expectedSubstring, err)
+		}
+	})
+}
+
+// colorName is a string enum validating itself through encoding.TextUnmarshaler.
+type colorName string
+
+func (color *colorName) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "red", "green":
+		*color = colorName(text)
+		return nil
+	default:
+		return fmt.Errorf("unknown color %q", text)
+	}
+}
+
+// Test_TextUnmarshaler_FieldDecoding tests types decoded through encoding.TextUnmarshaler.
+func Test_TextUnmarshaler_FieldDecoding(t *testing.T) {
+	type config struct {
+		IP      net.IP      `env:"IP"`
+		Addr    netip.Addr  `env:"ADDR"`
+		Since   time.Time   `env:"SINCE"`
+		Gateway *net.IP     `env:"GATEWAY"`
+		Peers   []net.IP    `env:"PEERS"`
+		Color   colorName   `env:"COLOR" default:"red"`
+		Colors  []colorName `env:"COLORS"`
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		envMap := map[string]string{
+			"IP":      "10.0.0.1",
+			"ADDR":    "::1",
+			"SINCE":   "2024-01-02T03:04:05Z",
+			"GATEWAY": "10.0.0.254",
+			"PEERS":   "10.0.0.2, 10.0.0.3",
+			"COLORS":  "green,red",
+		}
+
+		var cfg config
+		if err := populateStruct(envMap, &cfg); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !cfg.IP.Equal(net.IPv4(10, 0, 0, 1)) || cfg.Addr != netip.IPv6Loopback() || cfg.Since.Year() != 2024 {
+			t.Errorf("Unexpected values: %v %v %v", cfg.IP, cfg.Addr, cfg.Since)
+		}
+
+		if cfg.Gateway == nil || cfg.Gateway.String() != "10.0.0.254" || len(cfg.Peers) != 2 || cfg.Peers[1].String() != "10.0.0.3" {
+			t.Errorf("Unexpected addresses: %v %v", cfg.Gateway, cfg.Peers)
+		}
+
+		if cfg.Color != "red" || fmt.Sprint(cfg.Colors) != "[green red]" {
+			t.Errorf("Unexpected colors: %v %v", cfg.Color, cfg.Colors)
+		}
+	})
+
+	tests := []struct {
+		envMap   map[string]string
+		expected string
+	}{
+		{map[string]string{"IP": "10.0.0"}, "invalid net.IP for field 'IP'"},
+		{map[string]string{"COLOR": "blue"}, "invalid envload.colorName for field 'Color': unknown color \"blue\""},
+		{map[string]string{"COLORS": "red,blue"}, "invalid element in slice for field 'Colors' at index 1"},
+	}
+
+	for _, test := range tests {
+		var cfg config
+
+		err := populateStruct(test.envMap, &cfg)
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("Expected error containing %q, got %v", test.expected, err)
+		}
+	}
+}
+
+// Test_MapOfStruct_FieldDecoding tests map values decoded from JSON objects.
+func Test_MapOfStruct_FieldDecoding(t *testing.T) {
+	type endpoint struct {
+		URL     string   `json:"url"`
+		Retries int      `json:"retries"`
+		Tags    []string `json:"tags"`
+	}
+
+	t.Run("json values with commas", func(t *testing.T) {
+		envMap := map[string]string{
+			"ENDPOINTS": `search:{"url":"https://search.local/a,b","retries":3,"tags":["x","y"]}, auth:{"url":"https://auth.local"}`,
+		}
+
+		var config struct {
+			Endpoints map[string]endpoint `env:"ENDPOINTS"`
+		}
+
+		if err := populateStruct(envMap, &config); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(config.Endpoints) != 2 {
+			t.Fatalf("Expected 2 endpoints, got %d", len(config.Endpoints))
+		}
+
+		search := config.Endpoints["search"]
+		if search.URL != "https://search.local/a,b" || search.Retries != 3 || len(search.Tags) != 2 {
+			t.Errorf("Unexpected search endpoint: %+v", search)
+		}
+
+		if config.Endpoints["auth"].URL != "https://auth.local" {
+			t.Errorf("Unexpected auth endpoint: %+v", config.Endpoints["auth"])
+		}
+	})
+
+	t.Run("invalid json value", func(t *testing.T) {
+		envMap := map[string]string{"ENDPOINTS": `search:{"url":}`}
+
+		var config struct {
+			Endpoints map[string]endpoint `env:"ENDPOINTS"`
+		}
+
+		err := populateStruct(envMap, &config)
+		expectedSubstring := "invalid map value for field 'Endpoints' key 'search'"
+		if err == nil || !strings.Contains(err.Error(), expectedSubstring) {
+			t.Errorf("Expected error to contain '%s', got %v", expectedSubstring, err)
+		}
+	})
+}
+
+// Test_UniqueSlice tests the unique tag on slices.
+func Test_UniqueSlice(t *testing.T) {
+	t.Run("distinct elements", func(t *testing.T) {
+		envMap := map[string]string{"ALLOWED_HOSTS": "a.com,b.com", "PORTS": "80,443"}
+
+		var config struct {
+			AllowedHosts []string `env:"ALLOWED_HOSTS" unique:"true"`
+			Ports        []int    `env:"PORTS"         unique:"true"`
+		}
+
+		if err := populateStruct(envMap, &config); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("duplicate element", func(t *testing.T) {
+		envMap := map[string]string{"ALLOWED_HOSTS": "a.com,b.com,a.com"}
+
+		var config struct {
+			AllowedHosts []string `env:"ALLOWED_HOSTS" unique:"true"`
+		}
+
+		err := populateStruct(envMap, &config)
+		if !errors.Is(err, errDuplicateElement) {
+			t.Fatalf("Expected errDuplicateElement, got %v", err)
+		}
+
+		expectedSubstring := "field 'AllowedHosts': a.com at index 2 (first at index 0)"
+		if !strings.Contains(err.Error(), expectedSubstring) {
+			t.Errorf("Expected error to contain '%s', got '%s'", expectedSubstring, err.Error())
+		}
+	})
+
+	t.Run("duplicates allowed without tag", func(t *testing.T) {
+		envMap := map[string]string{"PORTS": "80,80"}
+
+		var config struct {
+			Ports []int `env:"PORTS"`
+		}
+
+		if err := populateStruct(envMap, &config); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
+
+func Test_EmptyAsUnset(t *testing.T) {
+	envMap := map[string]string{"HOST": "", "PORT": "", "LEGACY_PORT": "9090", "NAME": ""}
+
+	t.Run("empty values kept by default", func(t *testing.T) {
+		var config struct {
+			Host string `env:"HOST" default:"localhost"`
+			Port int    `env:"PORT" alias:"LEGACY_PORT" default:"8080"`
+		}
+
+		if err := Parse(envMap, &config); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if config.Host != "" || config.Port != 0 {
+			t.Errorf("Expected empty values to be kept, got %+v", config)
+		}
+	})
+
+	t.Run("option", func(t *testing.T) {
+		var config struct {
+			Host string `env:"HOST" default:"localhost"`
+			Port int    `env:"PORT" alias:"LEGACY_PORT" default:"8080"`
+			Name string `env:"NAME" required:"true"`
+		}
+
+		err := Parse(envMap, &config, WithEmptyAsUnset())
+		if !errors.Is(err, errMissingRequiredField) {
+			t.Fatalf("Expected errMissingRequiredField, got %v", err)
+		}
+
+		if config.Host != "localhost" || config.Port != 9090 {
+			t.Errorf("Expected default and alias values, got %+v", config)
+		}
+	})
+
+	t.Run("tag", func(t *testing.T) {
+		var config struct {
+			Host string `env:"HOST" default:"localhost" emptyunset:"true"`
+			Name string `env:"NAME" default:"app"`
+		}
+
+		if err := Parse(envMap, &config, WithStrictTags()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if config.Host != "localhost" || config.Name != "" {
+			t.Errorf("Expected only the tagged field to fall back, got %+v", config)
+		}
+	})
+}
+
+func Test_WhitespaceOnlyValues(t *testing.T) {
+	type Config struct {
+		Name     string            `env:"BLANK" default:"app"`
+		Count    int               `env:"BLANK" default:"1"`
+		Ratio    float64           `env:"BLANK"`
+		Enabled  bool              `env:"BLANK"`
+		Timeout  time.Duration     `env:"BLANK"`
+		Level    LogLevel          `env:"BLANK"`
+		Tags     []string          `env:"BLANK"`
+		Ports    []int             `env:"BLANK"`
+		Limits   map[string]int    `env:"BLANK"`
+		Settings map[string]string `env:"BLANK"`
+	}
+
+	envMap := map[string]string{"BLANK": " \t "}
+
+	t.Run("non-string types treat whitespace as empty", func(t *testing.T) {
+		var config Config
+		if err := Parse(envMap, &config); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if config.Name != " \t " {
+			t.Errorf("Expected string field to keep whitespace, got %q", config.Name)
+		}
+
+		if config.Count != 0 || config.Ratio != 0 ||
+			config.Enabled || config.Timeout != 0 || config.Level != LogLevel(0) ||
+			config.Tags != nil || config.Ports != nil || config.Limits != nil || config.Settings != nil {
+			t.Errorf("Expected non-string fields to stay at zero values, got %+v", config)
+		}
+	})
+
+	t.Run("required non-string field", func(t *testing.T) {
+		var config struct {
+			Port int `env:"BLANK" required:"true"`
+		}
+
+		if err := Parse(envMap, &config); !errors.Is(err, errMissingRequiredField) {
+			t.Errorf("Expected errMissingRequiredField, got %v", err)
+		}
+	})
+
+	t.Run("WithWhitespaceAsEmpty", func(t *testing.T) {
+		var config Config
+		if err := Parse(envMap, &config, WithWhitespaceAsEmpty()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if config.Name != "" {
+			t.Errorf("Expected empty string, got %q", config.Name)
+		}
+	})
+
+	t.Run("with WithEmptyAsUnset", func(t *testing.T) {
+		var config Config
+		if err := Parse(envMap, &config, WithWhitespaceAsEmpty(), WithEmptyAsUnset()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if config.Name != "app" || config.Count != 1 {
+			t.Errorf("Expected defaults, got %+v", config)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		port, err := GetInt(envMap, "BLANK", 8080)
+		if err != nil || port != 8080 {
+			t.Errorf("Expected default 8080, got %d (%v)", port, err)
+		}
+
+		if name := GetString(envMap, "BLANK", "app"); name != " \t " {
+			t.Errorf("Expected string value to keep whitespace, got %q", name)
+		}
+	})
+}
@@ -0,0 +1,288 @@
+package envloadtest
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-fynx/envload"
+)
+
+type (
+	// EnvBuilder builds env maps for tests from Go values, formatting each value the way
+	// envload parses it so tests do not hand-write "a:1,b:2" strings. The zero value is
+	// not usable; create builders with [NewEnvBuilder].
+	//
+	// Example:
+	//
+	//	envMap := envloadtest.NewEnvBuilder().
+	//		Set("PORT", 8080).
+	//		SetDuration("TIMEOUT", 5*time.Second).
+	//		Set("HOSTS", []string{"a", "b"}).
+	//		Map()
+	EnvBuilder struct {
+		envMap map[string]string
+	}
+)
+
+var (
+	errUnformattableValue = errors.New("cannot format value")
+
+	// [typeFormatters] format types whose env representation is not derived from their kind.
+	typeFormatters = map[reflect.Type]func(value reflect.Value) string{
+		reflect.TypeFor[time.Duration](): func(value reflect.Value) string {
+			return time.Duration(value.Int()).String()
+		},
+		reflect.TypeFor[envload.LogLevel]():      formatStringer,
+		reflect.TypeFor[envload.RateLimit]():     formatStringer,
+		reflect.TypeFor[envload.Quantity]():      formatStringer,
+		reflect.TypeFor[envload.Money]():         formatStringer,
+		reflect.TypeFor[envload.DurationRange](): formatStringer,
+		reflect.TypeFor[envload.WeightedList](): func(value reflect.Value) string {
+			var entries []string
+			for _, entry := range value.Interface().(envload.WeightedList) { //nolint:forcetypeassert // note: keyed by type.
+				entries = append(entries, fmt.Sprintf("%s=%d", entry.Name, entry.Weight))
+			}
+
+			return strings.Join(entries, ",")
+		},
+		reflect.TypeFor[envload.OrderedMap](): func(value reflect.Value) string {
+			var entries []string
+			for _, pair := range value.Interface().(envload.OrderedMap) { //nolint:forcetypeassert // note: keyed by type.
+				entries = append(entries, pair.Key+":"+pair.Value)
+			}
+
+			return strings.Join(entries, ",")
+		},
+	}
+)
+
+// NewEnvBuilder returns an empty [EnvBuilder].
+func NewEnvBuilder() *EnvBuilder {
+	return &EnvBuilder{envMap: make(map[string]string)}
+}
+
+// Set stores value under key in the form envload parses into a field of value's type:
+// numbers and booleans with strconv, slices as comma-separated elements, maps as
+// comma-separated key:value pairs sorted by key (struct values as JSON), time.Duration
+// as "5s", envload's value types (LogLevel, RateLimit, Money, ...) in their own format
+// and other types such as net.IP or url.URL through MarshalText or String. Pointers are
+// formatted as the value they point to.
+//
+// Set panics when value cannot be represented, for example a slice element containing
+// a comma or a slice of slices, since the test itself is then wrong.
+func (builder *EnvBuilder) Set(key string, value any) *EnvBuilder {
+	rawValue, err := formatValue(reflect.ValueOf(value), false)
+	if err != nil {
+		panic(fmt.Sprintf("envloadtest: Set(%q): %v", key, err))
+	}
+
+	builder.envMap[key] = rawValue
+
+	return builder
+}
+
+// SetDuration stores value under key in [time.Duration.String] form.
+func (builder *EnvBuilder) SetDuration(key string, value time.Duration) *EnvBuilder {
+	builder.envMap[key] = value.String()
+
+	return builder
+}
+
+// SetRaw stores rawValue under key unchanged, for testing malformed input.
+func (builder *EnvBuilder) SetRaw(key, rawValue string) *EnvBuilder {
+	builder.envMap[key] = rawValue
+
+	return builder
+}
+
+// Unset removes key from the map being built.
+func (builder *EnvBuilder) Unset(key string) *EnvBuilder {
+	delete(builder.envMap, key)
+
+	return builder
+}
+
+// Map returns a copy of the env map built so far, ready for [envload.Parse].
+func (builder *EnvBuilder) Map() map[string]string {
+	return maps.Clone(builder.envMap)
+}
+
+// formatValue returns the raw env form of value. Elements are formatted as slice
+// elements or map values, which cannot contain the separators around them.
+func formatValue(value reflect.Value, element bool) (string, error) {
+	if !value.IsValid() {
+		return "", fmt.Errorf("%w: nil", errUnformattableValue)
+	}
+
+	if format, ok := typeFormatters[value.Type()]; ok {
+		return checkElement(format(value), element)
+	}
+
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return "", fmt.Errorf("%w: nil %s", errUnformattableValue, value.Type())
+		}
+
+		return formatValue(value.Elem(), element)
+	}
+
+	if rawValue, ok, err := formatMarshaler(value); ok {
+		if err != nil {
+			return "", err
+		}
+
+		return checkElement(rawValue, element)
+	}
+
+	return formatKind(value, element)
+}
+
+// formatMarshaler formats values that describe their own text form, such as net.IP,
+// url.URL and net.IPNet: [encoding.TextMarshaler] first, as envload decodes with the
+// matching TextUnmarshaler, then [fmt.Stringer] for structs, whose kind has no env form.
+// Methods with pointer receivers are found through an addressable copy of value.
+func formatMarshaler(value reflect.Value) (string, bool, error) {
+	if !value.CanAddr() {
+		addressable := reflect.New(value.Type()).Elem()
+		addressable.Set(value)
+		value = addressable
+	}
+
+	target := value.Addr().Interface()
+
+	if marshaler, ok := target.(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return "", true, fmt.Errorf("%w: %w", errUnformattableValue, err)
+		}
+
+		return string(text), true, nil
+	}
+
+	if stringer, ok := target.(fmt.Stringer); ok && value.Kind() == reflect.Struct {
+		return stringer.String(), true, nil
+	}
+
+	return "", false, nil
+}
+
+// formatKind returns the raw env form of value based on its kind alone.
+//
+//nolint:exhaustive // note: Unsupported kinds are reported by the default case.
+func formatKind(value reflect.Value, element bool) (string, error) {
+	switch value.Kind() {
+	case reflect.String:
+		return checkElement(value.String(), element)
+
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, value.Type().Bits()), nil
+
+	case reflect.Slice:
+		if element {
+			return "", fmt.Errorf("%w: nested %s", errUnformattableValue, value.Type())
+		}
+
+		return formatSlice(value)
+
+	case reflect.Map:
+		if element {
+			return "", fmt.Errorf("%w: nested %s", errUnformattableValue, value.Type())
+		}
+
+		return formatMap(value)
+
+	default:
+		return "", fmt.Errorf("%w: unsupported type %s", errUnformattableValue, value.Type())
+	}
+}
+
+// formatSlice joins the formatted elements of value with commas.
+func formatSlice(value reflect.Value) (string, error) {
+	elements := make([]string, value.Len())
+
+	for i := range elements {
+		element, err := formatValue(value.Index(i), true)
+		if err != nil {
+			return "", fmt.Errorf("element %d: %w", i, err)
+		}
+
+		elements[i] = element
+	}
+
+	return strings.Join(elements, ","), nil
+}
+
+// formatMap joins the key:value pairs of value, sorted by key, with commas.
+func formatMap(value reflect.Value) (string, error) {
+	if value.Type().Key().Kind() != reflect.String {
+		return "", fmt.Errorf("%w: map keys of %s must be strings", errUnformattableValue, value.Type())
+	}
+
+	keys := value.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	pairs := make([]string, len(keys))
+
+	for i, key := range keys {
+		if strings.ContainsAny(key.String(), ",:") {
+			return "", fmt.Errorf("%w: map key %q contains a separator", errUnformattableValue, key.String())
+		}
+
+		formatted, err := formatMapValue(value.MapIndex(key))
+		if err != nil {
+			return "", fmt.Errorf("key %q: %w", key.String(), err)
+		}
+
+		pairs[i] = key.String() + ":" + formatted
+	}
+
+	return strings.Join(pairs, ","), nil
+}
+
+// formatMapValue formats one map value. envload converts map values by kind only,
+// so types with their own format (such as time.Duration) are written by kind too.
+func formatMapValue(value reflect.Value) (string, error) {
+	if value.Kind() != reflect.Struct {
+		return formatKind(value, true)
+	}
+
+	encoded, err := json.Marshal(value.Interface())
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", errUnformattableValue, err)
+	}
+
+	return string(encoded), nil
+}
+
+// checkElement rejects element values containing the comma that separates them.
+func checkElement(rawValue string, element bool) (string, error) {
+	if element && strings.Contains(rawValue, ",") {
+		return "", fmt.Errorf("%w: element %q contains a comma", errUnformattableValue, rawValue)
+	}
+
+	return rawValue, nil
+}
+
+// formatStringer formats values whose String method returns their env form.
+func formatStringer(value reflect.Value) string {
+	return value.Interface().(fmt.Stringer).String() //nolint:forcetypeassert // note: only registered for Stringers.
+}
@@ -0,0 +1,178 @@
+package envloadtest
+
+import (
+	"maps"
+	"net"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-fynx/envload"
+)
+
+func Test_EnvBuilder(t *testing.T) {
+	type Endpoint struct {
+		URL     string `json:"url"`
+		Retries int    `json:"retries"`
+	}
+
+	type Config struct {
+		Port      int                      `env:"PORT"`
+		Ratio     float64                  `env:"RATIO"`
+		Debug     bool                     `env:"DEBUG"`
+		Timeout   time.Duration            `env:"TIMEOUT"`
+		Hosts     []string                 `env:"HOSTS"`
+		Backoff   []time.Duration          `env:"BACKOFF"`
+		Limits    map[string]int           `env:"LIMITS"`
+		Waits     map[string]time.Duration `env:"WAITS"`
+		Endpoints map[string]Endpoint      `env:"ENDPOINTS"`
+		Level     envload.LogLevel         `env:"LOG_LEVEL"`
+		Rate      envload.RateLimit        `env:"RATE"`
+		Upstreams envload.WeightedList     `env:"UPSTREAMS"`
+		Pipeline  envload.OrderedMap       `env:"PIPELINE"`
+		Name      string                   `env:"NAME" default:"fallback"`
+	}
+
+	expected := Config{
+		Port:      8080,
+		Ratio:     0.25,
+		Debug:     true,
+		Timeout:   5 * time.Second,
+		Hosts:     []string{"a", "b"},
+		Backoff:   []time.Duration{time.Second, 1500 * time.Millisecond},
+		Limits:    map[string]int{"api": 10, "db": 5},
+		Waits:     map[string]time.Duration{"api": time.Minute},
+		Endpoints: map[string]Endpoint{"search": {URL: "https://search.local", Retries: 3}},
+		Level:     envload.LogLevelWarn,
+		Rate:      envload.RateLimit{Count: 100, Per: time.Second},
+		Upstreams: envload.WeightedList{{Name: "a", Weight: 3}, {Name: "b", Weight: 1}},
+		Pipeline:  envload.OrderedMap{{Key: "auth", Value: "on"}, {Key: "gzip", Value: "off"}},
+		Name:      "fallback",
+	}
+
+	envMap := NewEnvBuilder().
+		Set("PORT", expected.Port).
+		Set("RATIO", expected.Ratio).
+		Set("DEBUG", expected.Debug).
+		SetDuration("TIMEOUT", expected.Timeout).
+		Set("HOSTS", expected.Hosts).
+		Set("BACKOFF", expected.Backoff).
+		Set("LIMITS", expected.Limits).
+		Set("WAITS", expected.Waits).
+		Set("ENDPOINTS", expected.Endpoints).
+		Set("LOG_LEVEL", expected.Level).
+		Set("RATE", expected.Rate).
+		Set("UPSTREAMS", expected.Upstreams).
+		Set("PIPELINE", expected.Pipeline).
+		Set("NAME", "temporary").
+		Unset("NAME").
+		Map()
+
+	if envMap["LIMITS"] != "api:10,db:5" {
+		t.Errorf("Expected sorted map pairs, got %q", envMap["LIMITS"])
+	}
+
+	var cfg Config
+	if err := envload.Parse(envMap, &cfg); err != nil {
+		t.Fatalf("Built env %v does not parse: %v", envMap, err)
+	}
+
+	if cfg.Port != expected.Port || cfg.Ratio != expected.Ratio || !cfg.Debug || cfg.Timeout != expected.Timeout ||
+		cfg.Level != expected.Level || cfg.Rate != expected.Rate || cfg.Name != expected.Name {
+		t.Errorf("Expected %+v, got %+v", expected, cfg)
+	}
+
+	if !slices.Equal(cfg.Hosts, expected.Hosts) || !slices.Equal(cfg.Backoff, expected.Backoff) ||
+		!slices.Equal(cfg.Upstreams, expected.Upstreams) || !slices.Equal(cfg.Pipeline, expected.Pipeline) {
+		t.Errorf("Expected %+v, got %+v", expected, cfg)
+	}
+
+	if !maps.Equal(cfg.Limits, expected.Limits) || !maps.Equal(cfg.Waits, expected.Waits) ||
+		!maps.Equal(cfg.Endpoints, expected.Endpoints) {
+		t.Errorf("Expected %+v, got %+v", expected, cfg)
+	}
+}
+
+func Test_EnvBuilder_TextValues(t *testing.T) {
+	type Config struct {
+		Addr     net.IP    `env:"ADDR"`
+		Peers    []net.IP  `env:"PEERS"`
+		Endpoint url.URL   `env:"ENDPOINT"`
+		Proxy    *url.URL  `env:"PROXY"`
+		Subnet   net.IPNet `env:"SUBNET"`
+		Started  time.Time `env:"STARTED"`
+	}
+
+	endpoint, _ := url.Parse("https://api.example.com/v1")
+	proxy, _ := url.Parse("http://proxy.local:3128")
+	_, subnet, _ := net.ParseCIDR("10.0.0.0/8")
+	started := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	envMap := NewEnvBuilder().
+		Set("ADDR", net.ParseIP("10.0.0.1")).
+		Set("PEERS", []net.IP{net.ParseIP("10.0.0.2"), net.ParseIP("::1")}).
+		Set("ENDPOINT", *endpoint).
+		Set("PROXY", proxy).
+		Set("SUBNET", *subnet).
+		Set("STARTED", started).
+		Map()
+
+	if envMap["ADDR"] != "10.0.0.1" || envMap["PEERS"] != "10.0.0.2,::1" || envMap["SUBNET"] != "10.0.0.0/8" {
+		t.Errorf("Expected text forms, got %v", envMap)
+	}
+
+	var cfg Config
+	if err := envload.Parse(envMap, &cfg); err != nil {
+		t.Fatalf("Built env %v does not parse: %v", envMap, err)
+	}
+
+	if !cfg.Addr.Equal(net.ParseIP("10.0.0.1")) || len(cfg.Peers) != 2 || !cfg.Peers[1].Equal(net.IPv6loopback) {
+		t.Errorf("Expected IPs to round-trip, got %v and %v", cfg.Addr, cfg.Peers)
+	}
+
+	if cfg.Endpoint.String() != endpoint.String() || cfg.Proxy == nil || cfg.Proxy.String() != proxy.String() {
+		t.Errorf("Expected URLs to round-trip, got %v and %v", cfg.Endpoint, cfg.Proxy)
+	}
+
+	if cfg.Subnet.String() != subnet.String() || !cfg.Started.Equal(started) {
+		t.Errorf("Expected %v and %v, got %v and %v", subnet, started, cfg.Subnet, cfg.Started)
+	}
+}
+
+func Test_EnvBuilder_SetRaw(t *testing.T) {
+	builder := NewEnvBuilder().SetRaw("PORT", "not-a-number")
+	envMap := builder.Map()
+	envMap["PORT"] = "changed"
+
+	if builder.Map()["PORT"] != "not-a-number" {
+		t.Error("Expected Map to return a copy")
+	}
+}
+
+func Test_EnvBuilder_Unformattable(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{"comma in element", []string{"a,b"}},
+		{"nested slice", [][]int{{1}}},
+		{"separator in map key", map[string]int{"a:b": 1}},
+		{"non-string map key", map[int]int{1: 1}},
+		{"unsupported type", make(chan int)},
+		{"nil", nil},
+		{"nil pointer", (*url.URL)(nil)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected Set to panic for %v", test.value)
+				}
+			}()
+
+			NewEnvBuilder().Set("KEY", test.value)
+		})
+	}
+}
@@ -0,0 +1,667 @@
+package envloadtest
+
+import (
+	"encoding"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-fynx/envload"
+	"github.com/go-fynx/envload/internal/envfield"
+)
+
+type (
+	// valueBounds holds the `validate` rules generated values are kept within: min and max
+	// for numbers and durations, len, minlen and maxlen for slices and maps, the format
+	// rule strings must match and freeport for ports.
+	valueBounds struct {
+		min, max string
+		minLen   int
+		maxLen   int // -1 without a limit.
+		format   string
+		freePort bool
+	}
+)
+
+const (
+	// [maxGeneratedElements] bounds the number of slice and map entries generated per field.
+	maxGeneratedElements = 4
+
+	// [omitOneIn] is the chance (1 in n) that an optional field is left out of a generated map.
+	omitOneIn = 4
+
+	// [alphabet] is used for generated strings, which never contain separators.
+	alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_"
+)
+
+var (
+	errUnsupportedField = errors.New("cannot generate values for field")
+
+	// [noBounds] leaves generated values unconstrained.
+	noBounds = valueBounds{maxLen: -1}
+
+	durationType = reflect.TypeFor[time.Duration]()
+
+	// [textUnmarshalerType] is used to detect types that decode their own text format.
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+	generatorsMu sync.RWMutex
+
+	// [generators] holds the generators added with [RegisterGenerator].
+	generators = make(map[reflect.Type]func(rng *rand.Rand) string)
+
+	// [formatGenerators] produce strings matching the format rules of `validate` tags.
+	formatGenerators = map[string]func(rng *rand.Rand) string{
+		"hostname": func(rng *rand.Rand) string {
+			return randomHostname(rng, 1+rng.IntN(3))
+		},
+		"fqdn": func(rng *rand.Rand) string {
+			return randomHostname(rng, 1+rng.IntN(2)) + "." + pick(rng, "com", "net", "io", "internal")
+		},
+		"email": func(rng *rand.Rand) string {
+			return randomLabel(rng) + "@" + randomHostname(rng, 1) + "." + pick(rng, "com", "org")
+		},
+		"uuid": func(rng *rand.Rand) string {
+			return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x",
+				rng.Uint32(), rng.Uint32N(1<<16), rng.Uint32N(1<<16), rng.Uint32N(1<<16), rng.Uint64N(1<<48))
+		},
+		"base64": func(rng *rand.Rand) string {
+			data := make([]byte, 1+rng.IntN(32))
+			for i := range data {
+				data[i] = byte(rng.UintN(256))
+			}
+
+			return base64.StdEncoding.EncodeToString(data)
+		},
+	}
+
+	// [typeGenerators] produce valid raw values for types with their own text format.
+	typeGenerators = map[reflect.Type]func(rng *rand.Rand) string{
+		durationType: func(rng *rand.Rand) string {
+			return randomDuration(rng).String()
+		},
+		reflect.TypeFor[envload.LogLevel](): func(rng *rand.Rand) string {
+			return pick(rng, "debug", "info", "warn", "error")
+		},
+		reflect.TypeFor[envload.RateLimit](): func(rng *rand.Rand) string {
+			return fmt.Sprintf("%d/%s", 1+rng.IntN(1000), pick(rng, "s", "m", "h", "500ms"))
+		},
+		reflect.TypeFor[envload.Quantity](): func(rng *rand.Rand) string {
+			return fmt.Sprintf("%d%s", rng.IntN(1000), pick(rng, "", "m", "k", "M", "Ki", "Mi", "Gi"))
+		},
+		reflect.TypeFor[envload.Money](): func(rng *rand.Rand) string {
+			return fmt.Sprintf("%d.%02d %s", rng.IntN(10000), rng.IntN(100), pick(rng, "USD", "EUR", "GBP"))
+		},
+		reflect.TypeFor[envload.DurationRange](): func(rng *rand.Rand) string {
+			low := randomDuration(rng)
+			return fmt.Sprintf("%s..%s", low, low+randomDuration(rng))
+		},
+		reflect.TypeFor[envload.WeightedList](): func(rng *rand.Rand) string {
+			return randomEntries(rng, 1, maxGeneratedElements, func(name string) string {
+				return fmt.Sprintf("%s=%d", name, 1+rng.IntN(10))
+			})
+		},
+		reflect.TypeFor[envload.OrderedMap](): func(rng *rand.Rand) string {
+			return randomEntries(rng, 0, maxGeneratedElements, func(key string) string {
+				return key + ":" + randomString(rng)
+			})
+		},
+		reflect.TypeFor[url.URL](): func(rng *rand.Rand) string {
+			return fmt.Sprintf("%s://%s.internal:%d/%s", pick(rng, "http", "https"), randomString(rng), 1+rng.IntN(65535), randomString(rng))
+		},
+		reflect.TypeFor[net.IP](): func(rng *rand.Rand) string {
+			return fmt.Sprintf("10.%d.%d.%d", rng.IntN(256), rng.IntN(256), rng.IntN(256))
+		},
+		reflect.TypeFor[net.IPNet](): func(rng *rand.Rand) string {
+			return fmt.Sprintf("10.%d.0.0/%d", rng.IntN(256), 16+rng.IntN(17))
+		},
+		reflect.TypeFor[time.Time](): func(rng *rand.Rand) string {
+			return time.Unix(rng.Int64N(1<<32), 0).UTC().Format(time.RFC3339)
+		},
+	}
+)
+
+// RegisterGenerator registers generate to produce raw values of type T for [GenerateEnv],
+// for types it cannot generate on its own: enums and other types decoded with
+// UnmarshalText, and types with an [envload.RegisterParser] parser. generate must return
+// values that parse into T; they are used as is, so `validate` rules other than dive are
+// not applied to them. Registering a second generator for T replaces the previous one.
+//
+// Example:
+//
+//	envloadtest.RegisterGenerator[Mode](func(rng *rand.Rand) string {
+//		return []string{"fast", "safe"}[rng.IntN(2)]
+//	})
+func RegisterGenerator[T any](generate func(rng *rand.Rand) string) {
+	generatorsMu.Lock()
+	defer generatorsMu.Unlock()
+
+	generators[reflect.TypeFor[T]()] = generate
+}
+
+// lookupGenerator returns the generator for typ: one added with [RegisterGenerator],
+// otherwise one of [typeGenerators].
+func lookupGenerator(typ reflect.Type) (func(rng *rand.Rand) string, bool) {
+	generatorsMu.RLock()
+	generate, ok := generators[typ]
+	generatorsMu.RUnlock()
+
+	if !ok {
+		generate, ok = typeGenerators[typ]
+	}
+
+	return generate, ok
+}
+
+// GenerateEnv returns a random env map that target (a struct or pointer to one) parses
+// without error, for property-based tests of code consuming the config. Every field with
+// an `env` tag gets a value valid for its type; optional fields are sometimes left out so
+// defaults are exercised too. Interface fields pick one of their `impl` names, `unique`
+// slices get distinct elements, and nested and embedded structs are filled in too.
+//
+// Values satisfy the min, max, len, minlen, maxlen, hostname, fqdn, email, uuid, base64
+// and freeport rules of `validate` tags (after "dive" for elements). Fields with any other
+// rule (file and dir rules, cross-field rules, custom validators), fields of types that
+// decode their own format (enums with UnmarshalText, types with an envload parser) unless
+// a generator was added with [RegisterGenerator], types GenerateEnv cannot produce at all
+// (such as maps of structs), and rules that cannot be met are reported as an error.
+//
+// Example:
+//
+//	rng := rand.New(rand.NewPCG(seed, seed))
+//	envMap, err := envloadtest.GenerateEnv(rng, &Config{})
+func GenerateEnv(rng *rand.Rand, target any) (map[string]string, error) {
+	typ := reflect.TypeOf(target)
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: target must be a struct or pointer to struct", errUnsupportedField)
+	}
+
+	envMap := make(map[string]string)
+
+	if err := generateStruct(rng, typ, "", envMap); err != nil {
+		return nil, err
+	}
+
+	return envMap, nil
+}
+
+// generateStruct adds values for the env-tagged fields of typ to envMap, with their
+// names behind prefix, descending into structs nested with an `envPrefix` tag.
+func generateStruct(rng *rand.Rand, typ reflect.Type, prefix string, envMap map[string]string) error {
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+
+		if envfield.IsNestedStruct(field) {
+			if err := generateStruct(rng, field.Type, prefix+field.Tag.Get("envPrefix"), envMap); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		envKey := field.Tag.Get("env")
+		if envKey == "" || envKey == "-" || !field.IsExported() {
+			continue
+		}
+
+		// Generate before deciding to omit the field, so unsupported fields always fail.
+		rawValue, err := generateField(rng, field)
+		if err != nil {
+			return err
+		}
+
+		if field.Tag.Get("required") != "true" && rng.IntN(omitOneIn) == 0 {
+			continue
+		}
+
+		envMap[prefix+envKey] = rawValue
+	}
+
+	return nil
+}
+
+// generateField returns a raw value for field, honoring its `impl` and `unique` tags.
+func generateField(rng *rand.Rand, field reflect.StructField) (string, error) {
+	typ := field.Type
+
+	if inner, ok := envfield.LazyValueType(typ); ok {
+		typ = inner
+	}
+
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() == reflect.Interface {
+		names := strings.Split(field.Tag.Get("impl"), "|")
+		if names[0] == "" {
+			return "", fmt.Errorf("%w '%s': interface fields need an impl tag", errUnsupportedField, field.Name)
+		}
+
+		return pick(rng, names...), nil
+	}
+
+	bounds, elementBounds, err := parseBounds(field.Tag.Get("validate"))
+	if err != nil {
+		return "", fmt.Errorf("%w '%s': %w", errUnsupportedField, field.Name, err)
+	}
+
+	rawValue, ok := generateValue(rng, typ, field.Tag.Get("unique") == "true", bounds, elementBounds)
+	if !ok {
+		return "", fmt.Errorf("%w '%s': unsupported type %s or unsatisfiable validate rules", errUnsupportedField, field.Name, field.Type)
+	}
+
+	return rawValue, nil
+}
+
+// parseBounds returns the bounds the `validate` tag sets on a value and, after "dive",
+// on its elements, or an error naming a rule GenerateEnv cannot satisfy.
+func parseBounds(tag string) (valueBounds, valueBounds, error) {
+	bounds, elementBounds := noBounds, noBounds
+	current := &bounds
+
+	if tag == "" {
+		return bounds, elementBounds, nil
+	}
+
+	for entry := range strings.SplitSeq(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		length, _ := strconv.Atoi(param)
+
+		switch name {
+		case "dive":
+			current = &elementBounds
+		case "min":
+			current.min = param
+		case "max":
+			current.max = param
+		case "len":
+			current.minLen, current.maxLen = length, length
+		case "minlen":
+			current.minLen = length
+		case "maxlen":
+			current.maxLen = length
+		case "freeport":
+			current.freePort = true
+		case "hostname", "fqdn", "email", "uuid", "base64":
+			// Every fqdn is a hostname; other formats exclude each other.
+			switch {
+			case current.format == "" || current.format == name || current.format == "hostname" && name == "fqdn":
+				current.format = name
+			case current.format != "fqdn" || name != "hostname":
+				return noBounds, noBounds, fmt.Errorf("validate rules %s and %s cannot both be met", current.format, name)
+			}
+		default:
+			return noBounds, noBounds, fmt.Errorf("cannot generate values for validate rule %q", name)
+		}
+	}
+
+	return bounds, elementBounds, nil
+}
+
+// generateValue returns a raw value for typ within bounds, with slice elements and map
+// values within elementBounds, or false when typ is not supported or the bounds
+// cannot be met.
+func generateValue(rng *rand.Rand, typ reflect.Type, unique bool, bounds, elementBounds valueBounds) (string, bool) {
+	if bounds.format != "" && typ.Kind() != reflect.String || bounds.freePort && !isInteger(typ) {
+		return "", false
+	}
+
+	if generate, ok := lookupGenerator(typ); ok && (typ != durationType || !bounds.hasRange()) {
+		return generate(rng), true
+	}
+
+	if typ == durationType {
+		return generateDuration(rng, bounds)
+	}
+
+	// Values of these types must be in their own format, which only a generator knows.
+	if envfield.HasParser(typ) || reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		return "", false
+	}
+
+	if bounds.freePort {
+		// Port 0 asks for any free port, so it is the one port always accepted.
+		if _, _, ok := bounds.numberRange(0, 0); !ok {
+			return "", false
+		}
+
+		return "0", true
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		if bounds.format != "" {
+			return formatGenerators[bounds.format](rng), true
+		}
+
+		return randomString(rng), true
+
+	case reflect.Bool:
+		return strconv.FormatBool(rng.IntN(2) == 0), true
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if bounds.hasRange() {
+			return generateInt(rng, typ, bounds)
+		}
+
+		// An arithmetic shift keeps the sign and covers the full range of the type.
+		return strconv.FormatInt(int64(rng.Uint64())>>(64-typ.Bits()), 10), true //nolint:gosec // note: bit pattern only.
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if bounds.hasRange() {
+			return generateUint(rng, typ, bounds)
+		}
+
+		return strconv.FormatUint(rng.Uint64()>>(64-typ.Bits()), 10), true
+
+	case reflect.Float32, reflect.Float64:
+		if bounds.hasRange() {
+			return generateFloat(rng, typ, bounds)
+		}
+
+		return strconv.FormatFloat((rng.Float64()-0.5)*math.Pow10(rng.IntN(7)), 'g', -1, typ.Bits()), true
+
+	case reflect.Slice:
+		return generateSlice(rng, typ.Elem(), unique, bounds, elementBounds)
+
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			return "", false
+		}
+
+		if _, ok := generateValue(rng, typ.Elem(), false, elementBounds, noBounds); !ok || typ.Elem().Kind() == reflect.Slice {
+			return "", false
+		}
+
+		minCount, maxCount, ok := bounds.count()
+		if !ok {
+			return "", false
+		}
+
+		return randomEntries(rng, minCount, maxCount, func(key string) string {
+			value, _ := generateValue(rng, typ.Elem(), false, elementBounds, noBounds)
+			return key + ":" + value
+		}), true
+
+	default:
+		return "", false
+	}
+}
+
+// generateInt returns an integer of typ within the min and max of bounds.
+func generateInt(rng *rand.Rand, typ reflect.Type, bounds valueBounds) (string, bool) {
+	lowest := int64(-1) << (typ.Bits() - 1)
+	highest := -(lowest + 1)
+
+	low, high, ok := bounds.numberRange(float64(lowest), float64(highest))
+	if !ok {
+		return "", false
+	}
+
+	// Limits at or beyond the range of the type do not convert exactly, so keep the range.
+	lowInt, highInt := lowest, highest
+	if low > float64(lowest) {
+		lowInt = int64(math.Ceil(low))
+	}
+
+	if high < float64(highest) {
+		highInt = int64(math.Floor(high))
+	}
+
+	if lowInt > highInt {
+		return "", false
+	}
+
+	span := uint64(highInt - lowInt) //nolint:gosec // note: two's complement distance.
+	if span == math.MaxUint64 {
+		return strconv.FormatInt(int64(rng.Uint64()), 10), true //nolint:gosec // note: bit pattern only.
+	}
+
+	return strconv.FormatInt(lowInt+int64(rng.Uint64N(span+1)), 10), true //nolint:gosec // note: within span.
+}
+
+// generateUint returns an unsigned integer of typ within the min and max of bounds.
+func generateUint(rng *rand.Rand, typ reflect.Type, bounds valueBounds) (string, bool) {
+	highest := uint64(math.MaxUint64) >> (64 - typ.Bits())
+
+	low, high, ok := bounds.numberRange(0, float64(highest))
+	if !ok {
+		return "", false
+	}
+
+	lowUint := uint64(math.Ceil(max(low, 0)))
+	highUint := highest
+
+	if high < float64(highest) {
+		highUint = uint64(math.Floor(high))
+	}
+
+	if lowUint > highUint {
+		return "", false
+	}
+
+	if highUint-lowUint == math.MaxUint64 {
+		return strconv.FormatUint(rng.Uint64(), 10), true
+	}
+
+	return strconv.FormatUint(lowUint+rng.Uint64N(highUint-lowUint+1), 10), true
+}
+
+// generateFloat returns a float of typ within the min and max of bounds.
+func generateFloat(rng *rand.Rand, typ reflect.Type, bounds valueBounds) (string, bool) {
+	low, high, ok := bounds.numberRange(math.Inf(-1), math.Inf(1))
+	if !ok {
+		return "", false
+	}
+
+	// Keep open ends within a few orders of magnitude of the bound that is set.
+	switch {
+	case math.IsInf(low, -1):
+		low = high - math.Pow10(rng.IntN(7))
+	case math.IsInf(high, 1):
+		high = low + math.Pow10(rng.IntN(7))
+	}
+
+	value := low + rng.Float64()*(high-low)
+	if typ.Bits() == 32 && float64(float32(value)) >= low && float64(float32(value)) <= high {
+		value = float64(float32(value))
+	} else if typ.Bits() == 32 {
+		value = low
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, typ.Bits()), true
+}
+
+// generateDuration returns a duration within the min and max of bounds, in whole
+// milliseconds when the bounds allow.
+func generateDuration(rng *rand.Rand, bounds valueBounds) (string, bool) {
+	low, high := time.Duration(0), time.Duration(math.MaxInt64)
+
+	if bounds.min != "" {
+		limit, err := time.ParseDuration(bounds.min)
+		if err != nil {
+			return "", false
+		}
+
+		low = limit
+	}
+
+	if bounds.max != "" {
+		limit, err := time.ParseDuration(bounds.max)
+		if err != nil {
+			return "", false
+		}
+
+		high = limit
+	} else {
+		high = low + randomDuration(rng)
+	}
+
+	if bounds.min == "" {
+		low = max(high-randomDuration(rng), 0)
+	}
+
+	if low > high {
+		return "", false
+	}
+
+	span := int64(high - low)
+	if span < math.MaxInt64 {
+		span++
+	}
+
+	return (low + time.Duration(rng.Int64N(span))).String(), true
+}
+
+// hasRange reports whether bounds sets a min or max.
+func (bounds valueBounds) hasRange() bool {
+	return bounds.min != "" || bounds.max != ""
+}
+
+// numberRange returns the min and max of bounds as numbers, defaulting to lowest and
+// highest, or false when a limit is not a number or the range is empty.
+func (bounds valueBounds) numberRange(lowest, highest float64) (float64, float64, bool) {
+	low, high := lowest, highest
+
+	if bounds.min != "" {
+		limit, err := strconv.ParseFloat(bounds.min, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+
+		low = max(low, limit)
+	}
+
+	if bounds.max != "" {
+		limit, err := strconv.ParseFloat(bounds.max, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+
+		high = min(high, limit)
+	}
+
+	return low, high, low <= high
+}
+
+// count returns the range of element counts allowed by bounds, within
+// maxGeneratedElements unless the minimum length needs more.
+func (bounds valueBounds) count() (int, int, bool) {
+	highest := max(bounds.minLen, maxGeneratedElements)
+	if bounds.maxLen >= 0 {
+		highest = bounds.maxLen
+	}
+
+	return bounds.minLen, highest, bounds.minLen <= highest
+}
+
+// generateSlice returns comma-separated elements of elem, distinct when unique is set,
+// as many as bounds allows and each within elementBounds.
+func generateSlice(rng *rand.Rand, elem reflect.Type, unique bool, bounds, elementBounds valueBounds) (string, bool) {
+	if elem.Kind() == reflect.Slice || elem.Kind() == reflect.Map {
+		return "", false
+	}
+
+	minCount, maxCount, ok := bounds.count()
+	if !ok {
+		return "", false
+	}
+
+	count := minCount + rng.IntN(maxCount-minCount+1)
+	seen := make(map[string]bool, count)
+	elements := make([]string, 0, count)
+
+	// Duplicates of unique slices are drawn again, a bounded number of times.
+	for attempt := 0; len(elements) < count && attempt < 10*count; attempt++ {
+		element, ok := generateValue(rng, elem, false, elementBounds, noBounds)
+		if !ok {
+			return "", false
+		}
+
+		if unique && seen[element] {
+			continue
+		}
+
+		seen[element] = true
+		elements = append(elements, element)
+	}
+
+	return strings.Join(elements, ","), true
+}
+
+// randomEntries joins between minCount and maxCount entries built from distinct keys.
+func randomEntries(rng *rand.Rand, minCount, maxCount int, entry func(key string) string) string {
+	count := minCount + rng.IntN(maxCount-minCount+1)
+	entries := make([]string, count)
+
+	for i := range entries {
+		entries[i] = entry(fmt.Sprintf("%s%d", randomString(rng), i))
+	}
+
+	return strings.Join(entries, ",")
+}
+
+// isInteger reports whether typ is a signed or unsigned integer type.
+func isInteger(typ reflect.Type) bool {
+	switch typ.Kind() { //nolint:exhaustive // note: Other kinds are not integers.
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+// randomHostname returns labels RFC 1123 labels joined with dots.
+func randomHostname(rng *rand.Rand, labels int) string {
+	parts := make([]string, labels)
+	for i := range parts {
+		parts[i] = randomLabel(rng)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// randomLabel returns a lowercase hostname label, with an inner hyphen at times.
+func randomLabel(rng *rand.Rand) string {
+	label := strings.ToLower(strings.ReplaceAll(randomString(rng), "_", "x"))
+	if len(label) > 2 && rng.IntN(3) == 0 {
+		label = label[:1] + "-" + label[2:]
+	}
+
+	return label
+}
+
+// randomString returns 1 to 12 characters from [alphabet].
+func randomString(rng *rand.Rand) string {
+	var text strings.Builder
+	for range 1 + rng.IntN(12) {
+		text.WriteByte(alphabet[rng.IntN(len(alphabet))])
+	}
+
+	return text.String()
+}
+
+// randomDuration returns a positive whole-millisecond duration below one hour.
+func randomDuration(rng *rand.Rand) time.Duration {
+	return time.Duration(1+rng.Int64N(int64(time.Hour/time.Millisecond))) * time.Millisecond
+}
+
+// pick returns one of choices at random.
+func pick(rng *rand.Rand, choices ...string) string {
+	return choices[rng.IntN(len(choices))]
+}
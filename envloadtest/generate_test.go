@@ -0,0 +1,246 @@
+package envloadtest
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-fynx/envload"
+)
+
+func Test_GenerateEnv(t *testing.T) {
+	type Config struct {
+		Name      string                 `env:"APP_NAME" required:"true"`
+		Port      uint16                 `env:"PORT" default:"8080"`
+		Offset    int64                  `env:"OFFSET"`
+		Small     int8                   `env:"SMALL"`
+		Ratio     float32                `env:"RATIO"`
+		Debug     bool                   `env:"DEBUG"`
+		Timeout   time.Duration          `env:"TIMEOUT"`
+		Hosts     []string               `env:"HOSTS" unique:"true"`
+		Retries   []int                  `env:"RETRIES"`
+		Backoff   []time.Duration        `env:"BACKOFF"`
+		Labels    map[string]string      `env:"LABELS"`
+		Limits    map[string]int         `env:"LIMITS"`
+		Level     envload.LogLevel       `env:"LOG_LEVEL"`
+		Rate      envload.RateLimit      `env:"RATE"`
+		Memory    envload.Quantity       `env:"MEMORY"`
+		Price     envload.Money          `env:"PRICE"`
+		Jitter    envload.DurationRange  `env:"JITTER"`
+		Upstreams envload.WeightedList   `env:"UPSTREAMS"`
+		Pipeline  envload.OrderedMap     `env:"PIPELINE"`
+		Features  envload.Features       `env:"FEATURES"`
+		Token     envload.Lazy[[]string] `env:"TOKEN"`
+		Endpoint  *url.URL               `env:"ENDPOINT"`
+		BindIP    net.IP                 `env:"BIND_IP"`
+		Allowlist []net.IPNet            `env:"ALLOWLIST"`
+		Ignored   chan int               `env:"-"`
+	}
+
+	for seed := range uint64(200) {
+		rng := rand.New(rand.NewPCG(seed, seed))
+
+		envMap, err := GenerateEnv(rng, &Config{})
+		if err != nil {
+			t.Fatalf("seed %d: Unexpected error: %v", seed, err)
+		}
+
+		if envMap["APP_NAME"] == "" {
+			t.Fatalf("seed %d: Expected required APP_NAME to be generated", seed)
+		}
+
+		var cfg Config
+		if err := envload.Parse(envMap, &cfg); err != nil {
+			t.Fatalf("seed %d: generated env %v does not parse: %v", seed, envMap, err)
+		}
+
+		if _, err := cfg.Token.Get(); err != nil {
+			t.Fatalf("seed %d: generated lazy value %q does not parse: %v", seed, envMap["TOKEN"], err)
+		}
+	}
+
+	t.Run("validate bounds", func(t *testing.T) {
+		type Bounded struct {
+			Port     int               `env:"PORT" validate:"min=1,max=65535"`
+			Workers  uint8             `env:"WORKERS" validate:"min=2"`
+			Offset   int64             `env:"OFFSET" validate:"max=-10"`
+			Ratio    float32           `env:"RATIO" validate:"min=0,max=1"`
+			Timeout  time.Duration     `env:"TIMEOUT" validate:"min=1s,max=30s"`
+			Delay    time.Duration     `env:"DELAY" validate:"max=5s"`
+			Hosts    []string          `env:"HOSTS" validate:"len=6"`
+			Retries  []int             `env:"RETRIES" validate:"minlen=1,maxlen=2,dive,min=0,max=5"`
+			Limits   map[string]int    `env:"LIMITS" validate:"maxlen=1,dive,min=100"`
+			Backoffs []time.Duration   `env:"BACKOFFS" validate:"dive,min=10ms,max=1s"`
+			Labels   map[string]string `env:"LABELS" validate:"minlen=5"`
+		}
+
+		for seed := range uint64(200) {
+			rng := rand.New(rand.NewPCG(seed, seed))
+
+			envMap, err := GenerateEnv(rng, &Bounded{})
+			if err != nil {
+				t.Fatalf("seed %d: Unexpected error: %v", seed, err)
+			}
+
+			var cfg Bounded
+			if err := envload.Parse(envMap, &cfg); err != nil {
+				t.Fatalf("seed %d: generated env %v does not parse: %v", seed, envMap, err)
+			}
+		}
+	})
+
+	t.Run("unsupported validate rules", func(t *testing.T) {
+		type File struct {
+			Path string `env:"PATH" validate:"file"`
+		}
+
+		type CrossField struct {
+			Min int `env:"MIN"`
+			Max int `env:"MAX" validate:"gtfield=Min"`
+		}
+
+		type Conflicting struct {
+			Contact string `env:"CONTACT" validate:"email,uuid"`
+		}
+
+		type NotAString struct {
+			Count int `env:"COUNT" validate:"hostname"`
+		}
+
+		for _, target := range []any{&File{}, &CrossField{}, &Conflicting{}, &NotAString{}} {
+			if _, err := GenerateEnv(rand.New(rand.NewPCG(1, 1)), target); !errors.Is(err, errUnsupportedField) {
+				t.Errorf("Expected errUnsupportedField for %T, got %v", target, err)
+			}
+		}
+	})
+
+	t.Run("unsupported field", func(t *testing.T) {
+		type Unsupported struct {
+			Routes map[string]struct{ Path string } `env:"ROUTES" required:"true"`
+		}
+
+		_, err := GenerateEnv(rand.New(rand.NewPCG(1, 1)), &Unsupported{})
+		if !errors.Is(err, errUnsupportedField) {
+			t.Errorf("Expected errUnsupportedField, got %v", err)
+		}
+	})
+
+	t.Run("interface without impl tag", func(t *testing.T) {
+		type Unsupported struct {
+			Store any `env:"STORE" required:"true"`
+		}
+
+		_, err := GenerateEnv(rand.New(rand.NewPCG(1, 1)), &Unsupported{})
+		if !errors.Is(err, errUnsupportedField) {
+			t.Errorf("Expected errUnsupportedField, got %v", err)
+		}
+	})
+}
+
+// generatedMode is an enum decoded with UnmarshalText, so values must be registered.
+type generatedMode string
+
+func (mode *generatedMode) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "fast", "safe":
+		*mode = generatedMode(text)
+		return nil
+	default:
+		return fmt.Errorf("unknown mode %q", text)
+	}
+}
+
+// generatedShard is decoded by a parser registered with envload.RegisterParser.
+type generatedShard int
+
+// ungeneratedMode and ungeneratedShard have no generator registered.
+type (
+	ungeneratedMode  string
+	ungeneratedShard int
+)
+
+func (mode *ungeneratedMode) UnmarshalText(text []byte) error {
+	return (*generatedMode)(mode).UnmarshalText(text)
+}
+
+func Test_GenerateEnv_NoGenerator(t *testing.T) {
+	envload.RegisterParser(func(rawValue string) (ungeneratedShard, error) {
+		n, err := strconv.Atoi(rawValue)
+		return ungeneratedShard(n), err
+	})
+
+	type Enum struct {
+		Mode []ungeneratedMode `env:"MODE"`
+	}
+
+	type Parsed struct {
+		Shard ungeneratedShard `env:"SHARD"`
+	}
+
+	for _, target := range []any{&Enum{}, &Parsed{}} {
+		if _, err := GenerateEnv(rand.New(rand.NewPCG(1, 1)), target); !errors.Is(err, errUnsupportedField) {
+			t.Errorf("Expected errUnsupportedField for %T, got %v", target, err)
+		}
+	}
+}
+
+func Test_GenerateEnv_RoundTrip(t *testing.T) {
+	envload.RegisterParser(func(rawValue string) (generatedShard, error) {
+		n, err := strconv.Atoi(strings.TrimPrefix(rawValue, "shard-"))
+		return generatedShard(n), err
+	})
+
+	type Database struct {
+		Host string `env:"HOST" validate:"hostname" required:"true"`
+		Port int    `env:"PORT" validate:"min=1,max=65535"`
+	}
+
+	type Config struct {
+		Database  `envPrefix:"DB_"`
+		Domain    string                  `env:"DOMAIN" validate:"fqdn,hostname"`
+		Contact   string                  `env:"CONTACT" validate:"email"`
+		RequestID string                  `env:"REQUEST_ID" validate:"uuid"`
+		Key       []byte                  `env:"KEY"`
+		KeyText   string                  `env:"KEY_TEXT" validate:"base64"`
+		Listen    uint16                  `env:"LISTEN" validate:"freeport"`
+		Peers     []string                `env:"PEERS" validate:"maxlen=3,dive,hostname" unique:"true"`
+		Owners    map[string]string       `env:"OWNERS" validate:"dive,email"`
+		Mode      generatedMode           `env:"MODE"`
+		Modes     []generatedMode         `env:"MODES"`
+		Shard     generatedShard          `env:"SHARD"`
+		Started   time.Time               `env:"STARTED"`
+		Token     envload.Lazy[string]    `env:"TOKEN" validate:"uuid"`
+		Windows   []envload.DurationRange `env:"WINDOWS"`
+	}
+
+	RegisterGenerator[generatedMode](func(rng *rand.Rand) string {
+		return pick(rng, "fast", "safe")
+	})
+	RegisterGenerator[generatedShard](func(rng *rand.Rand) string {
+		return fmt.Sprintf("shard-%d", rng.IntN(100))
+	})
+
+	for seed := range uint64(500) {
+		rng := rand.New(rand.NewPCG(seed, seed))
+
+		envMap, err := GenerateEnv(rng, &Config{})
+		if err != nil {
+			t.Fatalf("seed %d: Unexpected error: %v", seed, err)
+		}
+
+		var cfg Config
+		if err := envload.Parse(envMap, &cfg); err != nil {
+			t.Fatalf("seed %d: generated env %v does not parse: %v", seed, envMap, err)
+		}
+
+		if _, err := cfg.Token.Get(); err != nil {
+			t.Fatalf("seed %d: generated lazy value %q does not parse: %v", seed, envMap["TOKEN"], err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Package envloadtest provides test helpers for code configured with envload.
+package envloadtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-fynx/envload"
+)
+
+var (
+	// [update] rewrites golden files instead of comparing against them: go test ./... -update.
+	update = flag.Bool("update", false, "update envloadtest golden files")
+)
+
+// Golden compares the resolved config in target against the JSON golden file at path.
+// The config is serialized with [envload.Redacted], so secret fields never reach the
+// golden file. Run the tests with -update to create or refresh golden files.
+//
+// Test packages importing envloadtest must not register their own -update flag.
+//
+// Example:
+//
+//	func TestConfig(t *testing.T) {
+//		var cfg Config
+//		if err := envload.LoadAndParse("testdata/app.env", &cfg); err != nil {
+//			t.Fatal(err)
+//		}
+//
+//		envloadtest.Golden(t, &cfg, "testdata/config.golden.json")
+//	}
+func Golden(t testing.TB, target any, path string) {
+	t.Helper()
+
+	got, err := json.MarshalIndent(envload.Redacted(target), "", "  ")
+	if err != nil {
+		t.Fatalf("envloadtest: encode config: %v", err)
+	}
+
+	got = append(got, '\n')
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("envloadtest: create golden directory: %v", err)
+		}
+
+		if err := os.WriteFile(path, got, 0o600); err != nil {
+			t.Fatalf("envloadtest: write golden file: %v", err)
+		}
+
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("envloadtest: read golden file (run with -update to create it): %v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("envloadtest: config does not match %s (run with -update to accept):\n--- want\n%s--- got\n%s",
+			path, want, got)
+	}
+}
@@ -0,0 +1,98 @@
+package envloadtest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-fynx/envload"
+)
+
+// recorder captures failures reported by a helper instead of failing the test.
+type recorder struct {
+	testing.TB
+
+	failures []string
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+// record runs fn against a recorder and returns the reported failures.
+func record(t *testing.T, fn func(tb testing.TB)) []string {
+	t.Helper()
+
+	rec := &recorder{TB: t}
+
+	var wg sync.WaitGroup
+	wg.Go(func() { fn(rec) })
+	wg.Wait()
+
+	return rec.failures
+}
+
+func Test_Golden(t *testing.T) {
+	type Config struct {
+		Name     string        `env:"APP_NAME"`
+		Password string        `env:"DB_PASSWORD" secret:"true"`
+		Timeout  time.Duration `env:"TIMEOUT"`
+	}
+
+	var cfg Config
+
+	err := envload.Parse(map[string]string{"APP_NAME": "service", "DB_PASSWORD": "hunter2", "TIMEOUT": "5s"}, &cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "testdata", "config.golden.json")
+
+	t.Run("missing golden file", func(t *testing.T) {
+		failures := record(t, func(tb testing.TB) { Golden(tb, &cfg, path) })
+		if len(failures) != 1 || !strings.Contains(failures[0], "-update") {
+			t.Errorf("Expected a hint to run with -update, got %q", failures)
+		}
+	})
+
+	t.Run("update writes redacted golden file", func(t *testing.T) {
+		*update = true
+		defer func() { *update = false }()
+
+		Golden(t, &cfg, path)
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := "{\n  \"APP_NAME\": \"service\",\n  \"DB_PASSWORD\": \"[REDACTED]\",\n  \"TIMEOUT\": \"5s\"\n}\n"
+		if string(content) != expected {
+			t.Errorf("Expected golden file %q, got %q", expected, content)
+		}
+	})
+
+	t.Run("matching config", func(t *testing.T) {
+		Golden(t, &cfg, path)
+	})
+
+	t.Run("changed config", func(t *testing.T) {
+		changed := cfg
+		changed.Timeout = 10 * time.Second
+
+		failures := record(t, func(tb testing.TB) { Golden(tb, &changed, path) })
+		if len(failures) != 1 || !strings.Contains(failures[0], `"TIMEOUT": "10s"`) {
+			t.Errorf("Expected a diff showing the new timeout, got %q", failures)
+		}
+	})
+}
@@ -0,0 +1,267 @@
+package envload
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+type (
+	// envrcParser reads the key/value subset of direnv's .envrc files.
+	envrcParser struct {
+		filePath string
+		envMap   map[string]string
+		line     string // Current line, without the line break.
+		number   int    // 1-based number of the current line.
+	}
+)
+
+var (
+	errUnsupportedEnvrc = errors.New("unsupported .envrc syntax")
+
+	// [envrcAssignmentPattern] matches the start of an assignment, after an optional export.
+	envrcAssignmentPattern = regexp.MustCompile(`^(\s*(?:export\s+)?)([A-Za-z_][A-Za-z0-9_]*)=`)
+
+	// [envrcVariablePattern] matches the name of a $VAR reference.
+	envrcVariablePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*`)
+
+	// [direnvCommands] lists direnv stdlib commands, named in errors for clearer messages.
+	direnvCommands = []string{
+		"dotenv", "dotenv_if_exists", "source_env", "source_env_if_exists", "source_up",
+		"PATH_add", "path_add", "use", "layout", "watch_file", "env_vars_required",
+	}
+)
+
+// EnvrcMap reads a direnv .envrc file into the env map form accepted by [Parse], for
+// projects whose settings live in the shell script direnv loads. Only the subset that is
+// plain key/value data is accepted:
+//   - KEY=value and export KEY=value assignments, blank lines and # comments.
+//   - Single quotes (literal), double quotes and backslash escapes as in the shell.
+//   - $VAR and ${VAR} references to keys assigned earlier in the file or, failing
+//     that, to the process environment; unknown variables expand to "".
+//
+// Anything else, such as direnv commands (dotenv, PATH_add, use), command substitution,
+// ${VAR:-default} or multi-line values, is reported as a [ParseError] pointing at the line.
+//
+// Example:
+//
+//	envMap, err := envload.EnvrcMap(".envrc")
+//	if err != nil {
+//		return err
+//	}
+//	err = envload.Parse(envMap, &cfg)
+func EnvrcMap(filePath string) (map[string]string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(content)
+
+	return parseEnvrc(filePath, content)
+}
+
+// parseEnvrc parses the content of an .envrc file line by line.
+func parseEnvrc(filePath string, content []byte) (map[string]string, error) {
+	parser := &envrcParser{filePath: filePath, envMap: make(map[string]string)}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, len(content)+1)
+
+	for scanner.Scan() {
+		parser.number++
+		parser.line = strings.TrimSuffix(scanner.Text(), "\r")
+
+		if err := parser.parseLine(); err != nil {
+			return nil, err
+		}
+	}
+
+	return parser.envMap, scanner.Err()
+}
+
+// parseLine parses the current line into envMap.
+func (parser *envrcParser) parseLine() error {
+	trimmed := strings.TrimSpace(parser.line)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return nil
+	}
+
+	match := envrcAssignmentPattern.FindStringSubmatchIndex(parser.line)
+	if match == nil {
+		command, _, _ := strings.Cut(trimmed, " ")
+		if strings.Contains(trimmed, "=") || !slices.Contains(direnvCommands, command) {
+			return parser.error(strings.Index(parser.line, trimmed), "not a KEY=value assignment")
+		}
+
+		return parser.error(strings.Index(parser.line, trimmed), "direnv command "+command+" is not supported")
+	}
+
+	key := parser.line[match[4]:match[5]]
+
+	value, err := parser.parseValue(match[1])
+	if err != nil {
+		return err
+	}
+
+	parser.envMap[key] = value
+
+	return nil
+}
+
+// parseValue parses the value starting at offset of the current line: unquoted, quoted
+// and expanded parts up to the first unquoted whitespace, optionally followed by a comment.
+func (parser *envrcParser) parseValue(offset int) (string, error) {
+	var value strings.Builder
+
+	line := parser.line
+	i := offset
+
+	for i < len(line) {
+		switch char := line[i]; {
+		case char == ' ' || char == '\t':
+			if rest := strings.TrimSpace(line[i:]); rest != "" && !strings.HasPrefix(rest, "#") {
+				return "", parser.error(strings.Index(line[i:], rest)+i, "only one value per line is supported")
+			}
+
+			return value.String(), nil
+
+		case char == '\'':
+			end := strings.IndexByte(line[i+1:], '\'')
+			if end < 0 {
+				return "", parser.error(i, "unterminated quoted value (multi-line values are not supported)")
+			}
+
+			value.WriteString(line[i+1 : i+1+end])
+			i += end + 2
+
+		case char == '"':
+			next, err := parser.parseDoubleQuoted(&value, i+1)
+			if err != nil {
+				return "", err
+			}
+
+			i = next
+
+		case char == '\\':
+			if i+1 >= len(line) {
+				return "", parser.error(i, "line continuations are not supported")
+			}
+
+			value.WriteByte(line[i+1])
+			i += 2
+
+		case char == '$':
+			next, err := parser.expand(&value, i)
+			if err != nil {
+				return "", err
+			}
+
+			i = next
+
+		case char == '`' || char == ';' || char == '|' || char == '&' || char == '(' || char == ')' || char == '<' || char == '>':
+			return "", parser.error(i, "shell operator "+string(char)+" is not supported")
+
+		default:
+			value.WriteByte(char)
+			i++
+		}
+	}
+
+	return value.String(), nil
+}
+
+// parseDoubleQuoted appends the double-quoted part starting at offset, just after the
+// opening quote, to value and returns the offset after the closing quote.
+func (parser *envrcParser) parseDoubleQuoted(value *strings.Builder, offset int) (int, error) {
+	line := parser.line
+
+	for i := offset; i < len(line); {
+		switch char := line[i]; char {
+		case '"':
+			return i + 1, nil
+
+		case '\\':
+			if i+1 < len(line) && strings.IndexByte(shellEscapable, line[i+1]) >= 0 {
+				value.WriteByte(line[i+1])
+				i += 2
+			} else {
+				value.WriteByte(char)
+				i++
+			}
+
+		case '$':
+			next, err := parser.expand(value, i)
+			if err != nil {
+				return 0, err
+			}
+
+			i = next
+
+		case '`':
+			return 0, parser.error(i, "command substitution is not supported")
+
+		default:
+			value.WriteByte(char)
+			i++
+		}
+	}
+
+	return 0, parser.error(offset-1, "unterminated quoted value (multi-line values are not supported)")
+}
+
+// expand appends the value of the $VAR or ${VAR} reference at offset to value and
+// returns the offset after it. A "$" not starting a reference is kept literally.
+func (parser *envrcParser) expand(value *strings.Builder, offset int) (int, error) {
+	rest := parser.line[offset+1:]
+
+	if strings.HasPrefix(rest, "(") {
+		return 0, parser.error(offset, "command substitution is not supported")
+	}
+
+	if braced, ok := strings.CutPrefix(rest, "{"); ok {
+		name := envrcVariablePattern.FindString(braced)
+		if name == "" || !strings.HasPrefix(braced[len(name):], "}") {
+			return 0, parser.error(offset, "only ${VAR} references are supported, not parameter expansion")
+		}
+
+		value.WriteString(parser.lookup(name))
+
+		return offset + len("${}") + len(name), nil
+	}
+
+	name := envrcVariablePattern.FindString(rest)
+	if name == "" {
+		value.WriteByte('$')
+		return offset + 1, nil
+	}
+
+	value.WriteString(parser.lookup(name))
+
+	return offset + 1 + len(name), nil
+}
+
+// lookup returns the value of name assigned earlier in the file or in the process environment.
+func (parser *envrcParser) lookup(name string) string {
+	if value, ok := parser.envMap[name]; ok {
+		return value
+	}
+
+	return os.Getenv(name)
+}
+
+// error builds a [ParseError] for the byte offset of the current line.
+func (parser *envrcParser) error(offset int, reason string) error {
+	return &ParseError{
+		File:    parser.filePath,
+		Line:    parser.number,
+		Column:  utf8.RuneCountInString(parser.line[:offset]) + 1,
+		Excerpt: truncateExcerpt(redactExcerpt(parser.line)),
+		Reason:  reason,
+		Err:     errUnsupportedEnvrc,
+	}
+}
@@ -0,0 +1,104 @@
+package envload
+
+import (
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_parseEnvrc(t *testing.T) {
+	t.Setenv("ENVRC_TEST_HOME", "/home/dev")
+
+	content := "# project settings\n" +
+		"export APP_NAME=orders\n" +
+		"PORT=8080 # default port\n" +
+		"\n" +
+		"export DATA_DIR=\"$ENVRC_TEST_HOME/data\"\n" +
+		"CACHE_DIR=${DATA_DIR}/cache\n" +
+		"LITERAL='$NOT_EXPANDED \\n'\n" +
+		"ESCAPED=\"say \\\"hi\\\" \\$5\"\n" +
+		"JOINED=a'b c'\"d\"\n" +
+		"ESCAPED_SPACE=a\\ b\n" +
+		"DOLLAR=cost$\n" +
+		"UNKNOWN=$ENVRC_TEST_UNSET\n" +
+		"EMPTY=\r\n"
+
+	got, err := parseEnvrc(".envrc", []byte(content))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"APP_NAME":      "orders",
+		"PORT":          "8080",
+		"DATA_DIR":      "/home/dev/data",
+		"CACHE_DIR":     "/home/dev/data/cache",
+		"LITERAL":       `$NOT_EXPANDED \n`,
+		"ESCAPED":       `say "hi" $5`,
+		"JOINED":        "ab cd",
+		"ESCAPED_SPACE": "a b",
+		"DOLLAR":        "cost$",
+		"UNKNOWN":       "",
+		"EMPTY":         "",
+	}
+
+	if !maps.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func Test_parseEnvrcUnsupported(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		column int
+		reason string
+	}{
+		{"direnv command", "  dotenv .env.local", 3, "direnv command dotenv is not supported"},
+		{"shell statement", "if [ -f x ]; then", 1, "not a KEY=value assignment"},
+		{"command substitution", "REV=$(git rev-parse HEAD)", 5, "command substitution is not supported"},
+		{"backticks", `REV="` + "`git rev-parse HEAD`" + `"`, 6, "command substitution is not supported"},
+		{"parameter expansion", "PORT=${PORT:-8080}", 6, "only ${VAR} references are supported, not parameter expansion"},
+		{"several words", "NAME=a b", 8, "only one value per line is supported"},
+		{"operator", "A=1; B=2", 4, "shell operator ; is not supported"},
+		{"unterminated quote", `MOTD="line one`, 6, "unterminated quoted value (multi-line values are not supported)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseEnvrc(".envrc", []byte("OK=1\n"+test.line+"\n"))
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) || !errors.Is(err, errUnsupportedEnvrc) {
+				t.Fatalf("Expected ParseError wrapping errUnsupportedEnvrc, got %v", err)
+			}
+
+			if parseErr.Line != 2 || parseErr.Column != test.column || parseErr.Reason != test.reason {
+				t.Errorf("Expected line 2, column %d, reason %q; got %d, %d, %q",
+					test.column, test.reason, parseErr.Line, parseErr.Column, parseErr.Reason)
+			}
+		})
+	}
+}
+
+func Test_EnvrcMap(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), ".envrc")
+	if err := os.WriteFile(filePath, []byte("export PORT=9090\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	envMap, err := EnvrcMap(filePath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if envMap["PORT"] != "9090" {
+		t.Errorf("Expected PORT=9090, got %v", envMap)
+	}
+
+	if _, err := EnvrcMap(filepath.Join(t.TempDir(), "missing")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected os.ErrNotExist, got %v", err)
+	}
+}
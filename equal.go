@@ -0,0 +1,99 @@
+package envload
+
+import (
+	"reflect"
+)
+
+var (
+	// [lazyRawValueType] is used to compare [Lazy] fields by their raw value.
+	lazyRawValueType = reflect.TypeFor[lazyRawValue]()
+)
+
+// Equal reports whether a and b, structs or pointers to structs of the same type, hold
+// equal values in every exported field with an `env` tag, including the fields of
+// nested `envPrefix` structs. Fields without a tag or tagged `env:"-"` are ignored, and
+// [Lazy] fields compare by their raw value.
+//
+// Example:
+//
+//	if envload.Equal(&previous, &current) {
+//		return // nothing changed, skip notifying subscribers
+//	}
+func Equal(a, b any) bool {
+	valueA, okA := structValue(a)
+	valueB, okB := structValue(b)
+
+	if !okA || !okB || valueA.Type() != valueB.Type() {
+		return false
+	}
+
+	fieldsB := structFields(valueB)
+
+	for i, field := range structFields(valueA) {
+		if !isTaggedField(field.StructField) {
+			continue
+		}
+
+		fieldA, fieldB := field.value, fieldsB[i].value
+
+		if field.Type.Implements(lazyRawValueType) {
+			if lazyRawOf(fieldA) != lazyRawOf(fieldB) {
+				return false
+			}
+
+			continue
+		}
+
+		if !reflect.DeepEqual(fieldA.Interface(), fieldB.Interface()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Hash returns a fingerprint of the same fields [Equal] compares: configs that are
+// Equal have the same hash. Like Equal, pointers are followed wherever they appear,
+// including inside interfaces, slices and maps; map entries are hashed independently
+// of iteration order, and funcs and channels by identity. A target that is not a
+// struct or a pointer to one is hashed as a whole.
+func Hash(target any) uint64 {
+	value, ok := structValue(target)
+	if !ok {
+		return fingerprintOf(reflect.ValueOf(target))
+	}
+
+	f := newFingerprinter()
+
+	for _, field := range structFields(value) {
+		if !isTaggedField(field.StructField) {
+			continue
+		}
+
+		f.Write([]byte(field.Name))
+		f.write(field.value)
+	}
+
+	return f.Sum64()
+}
+
+// structValue dereferences target down to a struct value.
+func structValue(target any) (reflect.Value, bool) {
+	value := reflect.ValueOf(target)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	return value, value.Kind() == reflect.Struct
+}
+
+// isTaggedField reports whether field is exported and carries an `env` tag other than "-".
+func isTaggedField(field reflect.StructField) bool {
+	key := field.Tag.Get("env")
+	return field.IsExported() && key != "" && key != "-"
+}
+
+// lazyRawOf returns the raw value captured by a [Lazy] field.
+func lazyRawOf(value reflect.Value) string {
+	return value.Interface().(lazyRawValue).lazyRaw() //nolint:forcetypeassert // note: checked with Implements.
+}
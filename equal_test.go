@@ -0,0 +1,116 @@
+package envload
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func Test_Equal_Hash(t *testing.T) {
+	type Config struct {
+		Name     string            `env:"APP_NAME"`
+		Timeout  time.Duration     `env:"TIMEOUT"`
+		Labels   map[string]string `env:"LABELS"`
+		Token    Lazy[string]      `env:"TOKEN"`
+		Ignored  string            `env:"-"`
+		Untagged int
+	}
+
+	envMap := map[string]string{
+		"APP_NAME": "service",
+		"TIMEOUT":  "5s",
+		"LABELS":   "team:core,tier:1,zone:a,region:eu",
+		"TOKEN":    "secret",
+	}
+
+	parse := func(t *testing.T, envMap map[string]string) Config {
+		t.Helper()
+
+		var cfg Config
+		if err := Parse(envMap, &cfg); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		return cfg
+	}
+
+	t.Run("equal configs", func(t *testing.T) {
+		a, b := parse(t, envMap), parse(t, envMap)
+		a.Ignored, b.Untagged = "x", 42
+
+		// Resolving one lazy field must not make the configs differ.
+		if _, err := a.Token.Get(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !Equal(&a, &b) || !Equal(a, b) {
+			t.Errorf("Expected configs to be equal")
+		}
+
+		if Hash(&a) != Hash(b) {
+			t.Errorf("Expected equal hashes, got %d and %d", Hash(&a), Hash(b))
+		}
+	})
+
+	changes := map[string]string{
+		"APP_NAME": "other",
+		"LABELS":   "team:core,tier:2,zone:a,region:eu",
+		"TOKEN":    "rotated",
+	}
+
+	for key, value := range changes {
+		t.Run("different "+key, func(t *testing.T) {
+			changed := map[string]string{}
+			for k, v := range envMap {
+				changed[k] = v
+			}
+			changed[key] = value
+
+			a, b := parse(t, envMap), parse(t, changed)
+
+			if Equal(&a, &b) {
+				t.Errorf("Expected configs to differ")
+			}
+
+			if Hash(&a) == Hash(&b) {
+				t.Errorf("Expected different hashes")
+			}
+		})
+	}
+
+	t.Run("mismatched types", func(t *testing.T) {
+		type Other struct {
+			Name string `env:"APP_NAME"`
+		}
+
+		if Equal(&Config{}, &Other{}) || Equal(&Config{}, "config") {
+			t.Errorf("Expected values of different types to differ")
+		}
+	})
+}
+
+func Test_Hash_FollowsPointers(t *testing.T) {
+	type Config struct {
+		Value  any             `env:"VALUE"`
+		Ports  []*int          `env:"PORTS"`
+		Limits map[string]*int `env:"LIMITS"`
+		Ratio  float64         `env:"RATIO"`
+	}
+
+	build := func(port, limit int, ratio float64) Config {
+		return Config{Value: &port, Ports: []*int{&port}, Limits: map[string]*int{"api": &limit}, Ratio: ratio}
+	}
+
+	a, b := build(80, 10, 0), build(80, 10, math.Copysign(0, -1))
+	if !Equal(a, b) {
+		t.Fatal("Expected configs with distinct but equal pointees to be equal")
+	}
+
+	if Hash(a) != Hash(b) {
+		t.Errorf("Expected equal configs to hash equally, got %d and %d", Hash(a), Hash(b))
+	}
+
+	if c := build(81, 10, 0); Hash(a) == Hash(c) {
+		t.Error("Expected a different pointee to change the hash")
+	}
+}
@@ -0,0 +1,167 @@
+package envload
+
+import (
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+type (
+	// errorLine is one error of a rendered report with the section it is listed under.
+	errorLine struct {
+		section string
+		env     string
+		message string
+	}
+)
+
+const (
+	// [otherSection] lists errors that belong to no variable, such as file errors.
+	otherSection = "OTHER"
+
+	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
+	ansiRed    = "\033[31m"
+	ansiYellow = "\033[33m"
+)
+
+// FormatErrors renders err as a report for people reading a terminal, such as an
+// installer whose configuration failed to load. Errors joined with [errors.Join] are
+// listed one per line, grouped by section, the part of the variable name before the
+// first "_" (DATABASE for DATABASE_HOST, matching "[database]" in .env files), with the
+// variable names aligned. Errors without a variable are listed last under OTHER.
+// When color is true, section names and variables are highlighted with ANSI codes.
+//
+// Example output:
+//
+//	DATABASE
+//	  DATABASE_HOST  missing required field: field=Host env=DATABASE_HOST
+//	  DATABASE_PORT  invalid int for field 'Port': ...
+//	REDIS
+//	  REDIS_URL      missing required field: field=URL env=REDIS_URL
+func FormatErrors(err error, color bool) string {
+	lines := collectErrorLines(err)
+	if len(lines) == 0 {
+		return ""
+	}
+
+	width := 0
+	for _, line := range lines {
+		width = max(width, len(line.env))
+	}
+
+	var builder strings.Builder
+
+	section := ""
+
+	for i, line := range lines {
+		if i == 0 || line.section != section {
+			section = line.section
+			builder.WriteString(colorize(section, ansiBold, color) + "\n")
+		}
+
+		builder.WriteString("  ")
+
+		if width > 0 {
+			env := line.env + strings.Repeat(" ", width-len(line.env))
+			if line.env == "" {
+				builder.WriteString(env + "  ")
+			} else {
+				builder.WriteString(colorize(env, ansiRed, color) + "  ")
+			}
+		}
+
+		builder.WriteString(line.message + "\n")
+	}
+
+	return builder.String()
+}
+
+// WriteErrors writes the report of [FormatErrors] to w, colored when w is a terminal
+// and the NO_COLOR variable is not set.
+//
+// Example:
+//
+//	if err := envload.LoadAndParse(".env", &cfg); err != nil {
+//		envload.WriteErrors(os.Stderr, err)
+//		os.Exit(1)
+//	}
+func WriteErrors(w io.Writer, err error) error {
+	_, writeErr := io.WriteString(w, FormatErrors(err, isTerminal(w) && os.Getenv("NO_COLOR") == ""))
+
+	return writeErr
+}
+
+// collectErrorLines flattens joined errors into lines, ordered by section with OTHER
+// last and keeping the original order within a section.
+func collectErrorLines(err error) []errorLine {
+	var lines []errorLine
+
+	for _, leaf := range flattenErrors(err) {
+		line := errorLine{section: otherSection, message: leaf.Error()}
+
+		if fieldErr := (*FieldError)(nil); errors.As(leaf, &fieldErr) && fieldErr.Env != "" {
+			line.env = fieldErr.Env
+			line.section, _, _ = strings.Cut(fieldErr.Env, "_")
+		}
+
+		lines = append(lines, line)
+	}
+
+	slices.SortStableFunc(lines, func(a, b errorLine) int {
+		switch {
+		case a.section == b.section:
+			return 0
+		case a.section == otherSection:
+			return 1
+		case b.section == otherSection:
+			return -1
+		default:
+			return strings.Compare(a.section, b.section)
+		}
+	})
+
+	return lines
+}
+
+// flattenErrors returns the errors joined in err, descending into nested joins.
+func flattenErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+
+	var leaves []error
+	for _, inner := range joined.Unwrap() {
+		leaves = append(leaves, flattenErrors(inner)...)
+	}
+
+	return leaves
+}
+
+// colorize wraps text in the ANSI code when enabled.
+func colorize(text, code string, enabled bool) string {
+	if !enabled {
+		return text
+	}
+
+	return code + text + ansiReset
+}
+
+// isTerminal reports whether w is a character device such as a terminal.
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := file.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,58 @@
+package envload
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_FormatErrors(t *testing.T) {
+	type Config struct {
+		Host string `env:"DATABASE_HOST" required:"true"`
+		Port int    `env:"DATABASE_PORT"`
+		URL  string `env:"REDIS_URL" required:"true"`
+	}
+
+	var cfg Config
+
+	hostErr := Parse(map[string]string{}, &cfg)
+	portErr := Parse(map[string]string{"DATABASE_HOST": "db", "DATABASE_PORT": "x"}, &cfg)
+	redisErr := Parse(map[string]string{"DATABASE_HOST": "db"}, &cfg)
+	fileErr := errors.New("read app.env: permission denied")
+
+	err := errors.Join(redisErr, fileErr, errors.Join(hostErr, portErr))
+
+	want := "DATABASE\n" +
+		"  DATABASE_HOST  missing required field: field=Host env=DATABASE_HOST\n" +
+		"  DATABASE_PORT  invalid int for field 'Port': strconv.ParseInt: parsing \"x\": invalid syntax\n" +
+		"REDIS\n" +
+		"  REDIS_URL      missing required field: field=URL env=REDIS_URL\n" +
+		"OTHER\n" +
+		"                 read app.env: permission denied\n"
+
+	if got := FormatErrors(err, false); got != want {
+		t.Errorf("Unexpected report:\n%s\nwant:\n%s", got, want)
+	}
+
+	colored := FormatErrors(hostErr, true)
+	if !strings.Contains(colored, ansiBold+"DATABASE"+ansiReset) || !strings.Contains(colored, ansiRed+"DATABASE_HOST"+ansiReset) {
+		t.Errorf("Expected ANSI highlighting, got %q", colored)
+	}
+
+	if got := FormatErrors(nil, false); got != "" {
+		t.Errorf("Expected empty report for nil, got %q", got)
+	}
+}
+
+func Test_WriteErrors(t *testing.T) {
+	var buffer bytes.Buffer
+
+	if err := WriteErrors(&buffer, errors.New("boom")); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := buffer.String(); got != "OTHER\n  boom\n" {
+		t.Errorf("Expected uncolored report for non-terminal writers, got %q", got)
+	}
+}
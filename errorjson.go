@@ -0,0 +1,73 @@
+package envload
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+type (
+	// errorReport is the JSON document produced by [ErrorsJSON].
+	errorReport struct {
+		Errors []errorEntry `json:"errors"`
+	}
+
+	// errorEntry is one error of an [errorReport]. Fields that do not apply are omitted.
+	errorEntry struct {
+		Code    string `json:"code,omitempty"`
+		Field   string `json:"field,omitempty"`
+		Env     string `json:"env,omitempty"`
+		Rule    string `json:"rule,omitempty"`
+		Param   string `json:"param,omitempty"`
+		File    string `json:"file,omitempty"`
+		Line    int    `json:"line,omitempty"`
+		Column  int    `json:"column,omitempty"`
+		Message string `json:"message"`
+	}
+)
+
+const (
+	// [codeParse] marks malformed .env content in [ErrorsJSON] output.
+	codeParse = "parse"
+)
+
+// ErrorsJSON converts err into JSON for APIs that show configuration problems in a web UI.
+// Errors joined with [errors.Join] become separate entries. A [FieldError] carries its
+// code, field, variable and failed rule, a [ParseError] its file position with code
+// "parse", and other errors only their message. Secret values stay redacted.
+// A nil err yields an empty list.
+//
+// Example output:
+//
+//	{"errors":[{"code":"required","field":"Host","env":"DATABASE_HOST","message":"missing required field: field=Host env=DATABASE_HOST"}]}
+func ErrorsJSON(err error) []byte {
+	report := errorReport{Errors: []errorEntry{}}
+
+	for _, leaf := range flattenErrors(err) {
+		entry := errorEntry{Message: leaf.Error()}
+
+		var (
+			fieldErr *FieldError
+			parseErr *ParseError
+		)
+
+		switch {
+		case errors.As(leaf, &fieldErr):
+			entry.Code = fieldErr.Code
+			entry.Field = fieldErr.Field
+			entry.Env = fieldErr.Env
+			entry.Rule = fieldErr.Rule
+			entry.Param = fieldErr.Param
+		case errors.As(leaf, &parseErr):
+			entry.Code = codeParse
+			entry.File = parseErr.File
+			entry.Line = parseErr.Line
+			entry.Column = parseErr.Column
+		}
+
+		report.Errors = append(report.Errors, entry)
+	}
+
+	encoded, _ := json.Marshal(report) //nolint:errchkjson // note: only strings and ints are encoded.
+
+	return encoded
+}
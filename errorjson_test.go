@@ -0,0 +1,34 @@
+package envload
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_ErrorsJSON(t *testing.T) {
+	type Config struct {
+		Host string `env:"DATABASE_HOST" required:"true"`
+		Port int    `env:"DATABASE_PORT" validate:"min=1024"`
+	}
+
+	var cfg Config
+
+	hostErr := Parse(map[string]string{}, &cfg)
+	portErr := Parse(map[string]string{"DATABASE_HOST": "db", "DATABASE_PORT": "80"}, &cfg)
+	parseErr := &ParseError{File: "app.env", Line: 3, Column: 4, Excerpt: "BAD-KEY=2", Reason: `unexpected character "-" in variable name`}
+
+	got := string(ErrorsJSON(errors.Join(hostErr, portErr, parseErr, errors.New("boom"))))
+	want := `{"errors":[` +
+		`{"code":"required","field":"Host","env":"DATABASE_HOST","message":"missing required field: field=Host env=DATABASE_HOST"},` +
+		`{"code":"validate","field":"Port","env":"DATABASE_PORT","rule":"min","param":"1024","message":"validation failed for field 'Port': must be at least 1024, got 80"},` +
+		`{"code":"parse","file":"app.env","line":3,"column":4,"message":"app.env:3:4: unexpected character \"-\" in variable name: \"BAD-KEY=2\""},` +
+		`{"message":"boom"}]}`
+
+	if got != want {
+		t.Errorf("Unexpected JSON:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := string(ErrorsJSON(nil)); got != `{"errors":[]}` {
+		t.Errorf("Expected empty list for nil, got %s", got)
+	}
+}
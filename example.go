@@ -0,0 +1,92 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	errMissingExampleKey   = errors.New("example file does not define variable")
+	errInvalidExampleValue = errors.New("invalid example value")
+)
+
+// VerifyExample checks that the example env file at examplePath defines the variable of
+// every env-tagged field of target and that every non-empty example value converts to
+// its field type, so the example cannot drift from the struct. Empty values are accepted
+// for secrets that must not be committed. Interface fields are only checked for presence,
+// so registered constructors never run. All problems are reported together.
+// It is intended to be called from a project's own tests.
+//
+// Example:
+//
+//	func TestEnvExample(t *testing.T) {
+//		if err := envload.VerifyExample(&Config{}, "../.env.example"); err != nil {
+//			t.Error(err)
+//		}
+//	}
+func VerifyExample(target any, examplePath string) error {
+	if err := validateStruct(target); err != nil {
+		return err
+	}
+
+	envMap, err := readEnvFile(examplePath, options{})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, field := range structFields(reflect.New(reflect.TypeOf(target).Elem()).Elem()) {
+		if !isTaggedField(field.StructField) {
+			continue
+		}
+
+		envKey := field.envKey()
+
+		rawValue, ok := envMap[envKey]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: field=%s env=%s", errMissingExampleKey, field.Name, envKey))
+			continue
+		}
+
+		if rawValue == "" || field.Type.Kind() == reflect.Interface {
+			continue
+		}
+
+		if err := verifyExampleValue(field.StructField, envKey, envMap, rawValue); err != nil {
+			errs = append(errs, fmt.Errorf("%w: env=%s: %w", errInvalidExampleValue, envKey, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// verifyExampleValue converts rawValue into a scratch value of the field type,
+// resolving [Lazy] fields immediately and applying the `unique` check.
+func verifyExampleValue(field reflect.StructField, envKey string, envMap map[string]string, rawValue string) error {
+	scratch := fieldResolver{
+		field:    field,
+		value:    reflect.New(field.Type).Elem(),
+		envMap:   envMap,
+		envKey:   envKey,
+		rawValue: rawValue,
+	}
+
+	if err := scratch.setValue(); err != nil {
+		return err
+	}
+
+	if field.Type.Implements(lazyRawValueType) {
+		results := scratch.value.MethodByName("Get").Call(nil)
+		if err, _ := results[1].Interface().(error); err != nil {
+			return err
+		}
+	}
+
+	if scratch.isUnique() {
+		return scratch.checkUnique()
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package envload
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_VerifyExample(t *testing.T) {
+	type Config struct {
+		Name     string        `env:"APP_NAME"`
+		Port     int           `env:"PORT" default:"8080"`
+		Timeout  time.Duration `env:"TIMEOUT"`
+		Password string        `env:"DB_PASSWORD" secret:"true"`
+		Hosts    []string      `env:"HOSTS" unique:"true"`
+		Retries  Lazy[[]int]   `env:"RETRIES"`
+		Ignored  string        `env:"-"`
+	}
+
+	dir := writeEnvFiles(t, map[string]string{
+		"valid.env":  "APP_NAME=service\nPORT=8080\nTIMEOUT=5s\nDB_PASSWORD=\nHOSTS=a,b\nRETRIES=1,2\n",
+		"broken.env": "APP_NAME=service\nPORT=eighty\nTIMEOUT=5s\nHOSTS=a,a\nRETRIES=1,x\n",
+	})
+
+	t.Run("valid example", func(t *testing.T) {
+		if err := VerifyExample(&Config{}, filepath.Join(dir, "valid.env")); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("all problems reported", func(t *testing.T) {
+		err := VerifyExample(&Config{}, filepath.Join(dir, "broken.env"))
+
+		if !errors.Is(err, errMissingExampleKey) || !errors.Is(err, errInvalidExampleValue) {
+			t.Fatalf("Expected missing key and invalid value errors, got %v", err)
+		}
+
+		for _, expected := range []string{"env=PORT", "env=DB_PASSWORD", "env=HOSTS", "env=RETRIES"} {
+			if !strings.Contains(err.Error(), expected) {
+				t.Errorf("Expected error to mention %s, got %v", expected, err)
+			}
+		}
+	})
+
+	t.Run("unreadable example", func(t *testing.T) {
+		if err := VerifyExample(&Config{}, filepath.Join(dir, "missing.env")); err == nil {
+			t.Errorf("Expected error for missing example file")
+		}
+	})
+}
@@ -0,0 +1,193 @@
+package envload_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-fynx/envload"
+)
+
+func ExampleLoadAndParse() {
+	dir, err := os.MkdirTemp("", "envload-example")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	envFile := filepath.Join(dir, ".env")
+	if err := os.WriteFile(envFile, []byte("APP_NAME=orders\nPORT=9090\nHOSTS=a.internal,b.internal\n"), 0o600); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var cfg struct {
+		AppName string        `env:"APP_NAME" required:"true"`
+		Port    int           `env:"PORT" default:"8080"`
+		Hosts   []string      `env:"HOSTS"`
+		Timeout time.Duration `env:"TIMEOUT" default:"30s"`
+	}
+
+	if err := envload.LoadAndParse(envFile, &cfg); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(cfg.AppName, cfg.Port, cfg.Hosts, cfg.Timeout)
+	// Output: orders 9090 [a.internal b.internal] 30s
+}
+
+func ExampleParse() {
+	var cfg struct {
+		Port  int               `env:"PORT" validate:"min=1024"`
+		Debug bool              `env:"DEBUG"`
+		Tags  map[string]string `env:"TAGS"`
+	}
+
+	err := envload.Parse(map[string]string{"PORT": "8443", "DEBUG": "true", "TAGS": "team:core,tier:1"}, &cfg)
+
+	fmt.Println(err, cfg.Port, cfg.Debug, cfg.Tags)
+	// Output: <nil> 8443 true map[team:core tier:1]
+}
+
+func ExampleFieldError() {
+	var cfg struct {
+		Port int `env:"PORT" validate:"min=1024"`
+	}
+
+	err := envload.Parse(map[string]string{"PORT": "80"}, &cfg)
+
+	var fieldErr *envload.FieldError
+	if errors.As(err, &fieldErr) {
+		fmt.Println(fieldErr.Env, fieldErr.Code, fieldErr.Rule, fieldErr.Param)
+	}
+	// Output: PORT validate min 1024
+}
+
+func ExampleWithMessages() {
+	var cfg struct {
+		Token string `env:"TOKEN" required:"true"`
+	}
+
+	err := envload.Parse(map[string]string{}, &cfg, envload.WithMessages(map[string]string{
+		"required": "Bitte {{.Env}} setzen.",
+	}))
+
+	fmt.Println(err)
+	// Output: Bitte TOKEN setzen.
+}
+
+func ExampleRegisterValidator() {
+	envload.RegisterValidator("lowercase", func(value any, _ string) error {
+		if text, _ := value.(string); text != strings.ToLower(text) {
+			return fmt.Errorf("%q must be lowercase", text)
+		}
+
+		return nil
+	})
+
+	var cfg struct {
+		Topic string `env:"TOPIC" validate:"lowercase"`
+	}
+
+	fmt.Println(envload.Parse(map[string]string{"TOPIC": "Orders"}, &cfg))
+	// Output: validation failed for field 'Topic': "Orders" must be lowercase
+}
+
+func ExampleFormatErrors() {
+	var cfg struct {
+		Host string `env:"DATABASE_HOST" required:"true"`
+	}
+
+	err := envload.Parse(map[string]string{}, &cfg)
+
+	fmt.Print(envload.FormatErrors(err, false))
+	// Output:
+	// DATABASE
+	//   DATABASE_HOST  missing required field: field=Host env=DATABASE_HOST
+}
+
+func ExampleErrorsJSON() {
+	var cfg struct {
+		Host string `env:"DATABASE_HOST" required:"true"`
+	}
+
+	err := envload.Parse(map[string]string{}, &cfg)
+
+	fmt.Println(string(envload.ErrorsJSON(err)))
+	// Output: {"errors":[{"code":"required","field":"Host","env":"DATABASE_HOST","message":"missing required field: field=Host env=DATABASE_HOST"}]}
+}
+
+type reloadedConfig struct {
+	Port     int    `env:"PORT" validate:"min=1024"`
+	LogLevel string `env:"LOG_LEVEL" default:"info"`
+}
+
+func ExampleNewReloader() {
+	dir, err := os.MkdirTemp("", "envload-example")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	envFile := filepath.Join(dir, ".env")
+	if err := os.WriteFile(envFile, []byte("PORT=8080\n"), 0o600); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	reloader, err := envload.NewReloader[reloadedConfig](envFile)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	cfg := reloader.Current()
+	fmt.Println(cfg.Port, cfg.LogLevel)
+	// Output: 8080 info
+}
+
+func ExampleReloader_Reload() {
+	dir, err := os.MkdirTemp("", "envload-example")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	envFile := filepath.Join(dir, ".env")
+	if err := os.WriteFile(envFile, []byte("PORT=8080\n"), 0o600); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	reloader, err := envload.NewReloader[reloadedConfig](envFile)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// A valid edit replaces the current config.
+	if err := os.WriteFile(envFile, []byte("PORT=9090\nLOG_LEVEL=debug\n"), 0o600); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(reloader.Reload() == nil, reloader.Current().Port, reloader.Current().LogLevel)
+
+	// An invalid edit is rejected and the previous config is kept.
+	if err := os.WriteFile(envFile, []byte("PORT=80\n"), 0o600); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(reloader.Reload() == nil, reloader.Current().Port, reloader.Stats().Failed)
+	// Output:
+	// true 9090 debug
+	// false 9090 1
+}
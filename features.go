@@ -0,0 +1,25 @@
+package envload
+
+type (
+	// Features is a set of named feature flags parsed from comma-separated name:bool pairs.
+	//
+	// Example:
+	//
+	//	type Config struct {
+	//		Features envload.Features `env:"FEATURES"` // FEATURES=cache:true,debug:false
+	//	}
+	//
+	//	if cfg.Features.Enabled("cache", false) { ... }
+	Features map[string]bool
+)
+
+// Enabled reports whether the named flag is on, or def when the flag is not listed.
+// It is safe to call on a nil Features.
+func (features Features) Enabled(name string, def bool) bool {
+	enabled, ok := features[name]
+	if !ok {
+		return def
+	}
+
+	return enabled
+}
@@ -0,0 +1,33 @@
+package envload
+
+import "testing"
+
+func Test_Features_Enabled(t *testing.T) {
+	envMap := map[string]string{"FEATURES": "cache:true, debug:false"}
+
+	var config struct {
+		Features Features `env:"FEATURES"`
+		Missing  Features `env:"MISSING_FEATURES"`
+	}
+
+	if err := populateStruct(envMap, &config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := Tests[bool]{
+		{"listed and on", config.Features.Enabled("cache", false), true},
+		{"listed and off overrides default", config.Features.Enabled("debug", true), false},
+		{"not listed uses default", config.Features.Enabled("beta", true), true},
+		{"nil features uses default", config.Missing.Enabled("cache", true), true},
+	}
+
+	tests.runTests(t)
+
+	var invalid struct {
+		Features Features `env:"FEATURES"`
+	}
+
+	if err := populateStruct(map[string]string{"FEATURES": "cache:maybe"}, &invalid); err == nil {
+		t.Error("Expected error for non-bool feature value")
+	}
+}
@@ -0,0 +1,86 @@
+package envload
+
+import (
+	"errors"
+)
+
+type (
+	// FieldError reports a problem with the value of one struct field. Use errors.As to
+	// inspect it, for example to show end users which variable to fix:
+	//
+	//	var fieldErr *envload.FieldError
+	//	if errors.As(err, &fieldErr) {
+	//		fmt.Println(fieldErr.Env, fieldErr.Code)
+	//	}
+	//
+	// Its message is the one of Err unless a template from [WithMessages] replaces it.
+	FieldError struct {
+		Field string // Name of the struct field.
+		Env   string // Variable the value was read from, "" for fields without env tag.
+		Code  string // Kind of problem: "required", "invalid", "unique" or "validate".
+		Rule  string // For "validate", the failed rule of the `validate` tag, such as "min".
+		Param string // For "validate", the parameter of the failed rule, such as "1".
+		Err   error  // Underlying error, with secret values redacted.
+
+		message string // Rendered from a message template, if any.
+	}
+
+	// ruleError records which `validate` rule produced err.
+	ruleError struct {
+		rule validationRule
+		err  error
+	}
+)
+
+const (
+	codeRequired = "required"
+	codeInvalid  = "invalid"
+	codeUnique   = "unique"
+	codeValidate = "validate"
+)
+
+// Error returns the localized message, or the message of Err.
+func (fieldErr *FieldError) Error() string {
+	if fieldErr.message != "" {
+		return fieldErr.message
+	}
+
+	return fieldErr.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (fieldErr *FieldError) Unwrap() error {
+	return fieldErr.Err
+}
+
+// Error returns the message of the wrapped error.
+func (ruleErr *ruleError) Error() string {
+	return ruleErr.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (ruleErr *ruleError) Unwrap() error {
+	return ruleErr.err
+}
+
+// fieldError wraps err, a problem with the current field's value, in a [FieldError]
+// after redacting secret values from it. Definition errors are returned unchanged.
+func (resolver *fieldResolver) fieldError(code string, err error) error {
+	if err == nil || errors.Is(err, errInvalidTagDefinition) {
+		return err
+	}
+
+	fieldErr := &FieldError{
+		Field: resolver.field.Name,
+		Env:   resolver.envKey,
+		Code:  code,
+		Err:   resolver.redactError(err),
+	}
+
+	if ruleErr := (*ruleError)(nil); errors.As(err, &ruleErr) {
+		fieldErr.Rule = ruleErr.rule.name
+		fieldErr.Param = ruleErr.rule.param
+	}
+
+	return fieldErr
+}
@@ -0,0 +1,255 @@
+package envload
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/joho/godotenv"
+)
+
+type (
+	// envLine is one line of an env file together with where it came from,
+	// so errors can point at the original file after includes are inlined.
+	envLine struct {
+		file   string
+		number int
+		text   []byte
+	}
+
+	// envReader reads env content line by line, applying [section] prefixes and #if
+	// blocks as it goes, so #include directives are only followed in kept branches.
+	envReader struct {
+		maxFileSize int64
+		sectioned   bool // Whether [section] headers are read, see [WithSections].
+		sections    sectionState
+		conditions  *conditionalState
+		raw         []envLine // Every line read, to wipe once parsed.
+	}
+)
+
+const (
+	// [includeDirective] starts a line that inlines another env file: #include base.env.
+	includeDirective = "#include"
+)
+
+var (
+	errIncludeCycle   = errors.New("include cycle")
+	errIncludeFailed  = errors.New("include")
+	errOddUTF16Length = errors.New("utf-16 content has an odd number of bytes")
+
+	// [utf8BOM], [utf16LEBOM] and [utf16BEBOM] are the byte order marks recognized in env files.
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16LEBOM = []byte{0xFF, 0xFE}
+	utf16BEBOM = []byte{0xFE, 0xFF}
+)
+
+// readEnvFile reads filePath, inlines its #include directives, prefixes keys of [section] blocks,
+// applies #if blocks and parses the result,
+// enforcing the size limits configured in config.
+func readEnvFile(filePath string, config options) (map[string]string, error) {
+	reader := newEnvReader(config)
+
+	// Wipe the raw file content once parsed so secrets only live on in the returned map.
+	defer reader.wipe()
+
+	if err := reader.readFile(filePath, nil); err != nil {
+		return nil, err
+	}
+
+	return reader.parse(filePath, config)
+}
+
+// newEnvReader returns a reader enforcing the file size limit of config, reading
+// [section] headers under [WithSections].
+func newEnvReader(config options) *envReader {
+	return &envReader{maxFileSize: config.maxFileSize, sectioned: config.sections, conditions: newConditionalState()}
+}
+
+// parse parses the lines kept from the content read from name, enforcing the key and
+// value limits of config.
+func (reader *envReader) parse(name string, config options) (map[string]string, error) {
+	lines, err := reader.conditions.finish()
+	if err != nil {
+		return nil, err
+	}
+
+	content := joinLines(lines)
+	defer clear(content)
+
+	envMap, err := godotenv.UnmarshalBytes(content)
+	if err != nil {
+		return nil, locateParseError(lines, content)
+	}
+
+	if err := checkEnvLimits(envMap, config); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return envMap, nil
+}
+
+// wipe overwrites the text of every line read with zeros and forgets the keys
+// collected to evaluate conditions.
+func (reader *envReader) wipe() {
+	wipeLines(reader.raw)
+	reader.conditions.wipe()
+}
+
+// readFile reads the lines of filePath, with every "#include other.env" line in a kept
+// branch replaced by the (recursively read) lines of the referenced file.
+// Relative include paths are resolved against the directory of the including file,
+// and stack holds the files currently being read to detect cycles.
+// Because included content is inlined in place, later lines override included keys
+// and ${VAR} references work across files. Each file is limited to maxFileSize bytes when positive.
+func (reader *envReader) readFile(filePath string, stack []string) error {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(stack, absPath) {
+		return fmt.Errorf("%w: %s -> %s", errIncludeCycle, strings.Join(stack, " -> "), absPath)
+	}
+
+	content, err := readLimited(filePath, reader.maxFileSize)
+	if err != nil {
+		return err
+	}
+
+	return reader.readContent(filePath, filepath.Dir(filePath), content, append(stack, absPath))
+}
+
+// readContent reads the lines of content read from name, following its #include
+// directives with paths relative to dir. Includes within #if branches that do not
+// hold are skipped without opening the file.
+func (reader *envReader) readContent(name, dir string, content []byte, stack []string) error {
+	content, err := decodeEnvContent(content)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	var number int
+
+	for text := range bytes.Lines(content) {
+		number++
+
+		line := envLine{file: name, number: number, text: normalizeLineEnding(text)}
+		reader.raw = append(reader.raw, line)
+
+		includePath, ok := parseIncludeLine(string(text))
+		if !ok {
+			if err := reader.apply(line); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if !reader.conditions.active {
+			continue
+		}
+
+		if !filepath.IsAbs(includePath) {
+			includePath = filepath.Join(dir, includePath)
+		}
+
+		if err := reader.readFile(includePath, stack); err != nil {
+			return fmt.Errorf("%w from %s:%d: %w", errIncludeFailed, name, number, err)
+		}
+	}
+
+	return nil
+}
+
+// apply prefixes line with the current section, under [WithSections], and passes it
+// through the #if blocks.
+func (reader *envReader) apply(line envLine) error {
+	if reader.sectioned {
+		var ok bool
+		if line, ok = reader.sections.rewrite(line); !ok {
+			return nil
+		}
+	}
+
+	return reader.conditions.apply(line)
+}
+
+// decodeEnvContent strips a UTF-8 byte order mark and transcodes UTF-16 content
+// (detected by its byte order mark, as written by some Windows editors) to UTF-8.
+func decodeEnvContent(content []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(content, utf8BOM):
+		return content[len(utf8BOM):], nil
+
+	case bytes.HasPrefix(content, utf16LEBOM):
+		return decodeUTF16(content[len(utf16LEBOM):], binary.LittleEndian)
+
+	case bytes.HasPrefix(content, utf16BEBOM):
+		return decodeUTF16(content[len(utf16BEBOM):], binary.BigEndian)
+
+	default:
+		return content, nil
+	}
+}
+
+// decodeUTF16 converts UTF-16 content in the given byte order to UTF-8.
+func decodeUTF16(content []byte, order binary.ByteOrder) ([]byte, error) {
+	if len(content)%2 != 0 {
+		return nil, errOddUTF16Length
+	}
+
+	units := make([]uint16, len(content)/2)
+	for i := range units {
+		units[i] = order.Uint16(content[2*i:])
+	}
+
+	return []byte(string(utf16.Decode(units))), nil
+}
+
+// normalizeLineEnding makes text end with a single "\n", so lines from different files never merge.
+func normalizeLineEnding(text []byte) []byte {
+	text = bytes.TrimSuffix(bytes.TrimSuffix(text, []byte("\n")), []byte("\r"))
+	return append(text, '\n')
+}
+
+// joinLines concatenates lines into parsable content.
+// The result is allocated at its exact size so no outgrown copy is left unwiped.
+func joinLines(lines []envLine) []byte {
+	size := 0
+	for _, line := range lines {
+		size += len(line.text)
+	}
+
+	content := make([]byte, 0, size)
+	for _, line := range lines {
+		content = append(content, line.text...)
+	}
+
+	return content
+}
+
+// wipeLines overwrites the text of lines with zeros.
+func wipeLines(lines []envLine) {
+	for _, line := range lines {
+		clear(line.text)
+	}
+}
+
+// parseIncludeLine returns the path of an include directive line.
+// Example: `#include "shared/base.env"` -> "shared/base.env".
+func parseIncludeLine(line string) (string, bool) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(line), includeDirective)
+	if !ok || rest == "" || (rest[0] != ' ' && rest[0] != '\t') {
+		return "", false
+	}
+
+	includePath := strings.Trim(strings.TrimSpace(rest), `"'`)
+
+	return includePath, includePath != ""
+}
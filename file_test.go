@@ -0,0 +1,262 @@
+package envload
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+// writeEnvFiles writes name -> content files into a temp directory and returns it.
+func writeEnvFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	return dir
+}
+
+// envLinesOf splits content into envLines attributed to file.
+func envLinesOf(file, content string) []envLine {
+	var lines []envLine
+	for text := range strings.Lines(content) {
+		lines = append(lines, envLine{file: file, number: len(lines) + 1, text: normalizeLineEnding([]byte(text))})
+	}
+
+	return lines
+}
+
+func Test_LoadAndParse_IncludeErrors(t *testing.T) {
+	type Config struct {
+		Port int `env:"PORT" default:"1"`
+	}
+
+	dir := writeEnvFiles(t, map[string]string{
+		"missing.env": "PORT=9000\n#include absent.env\n",
+		"a.env":       "PORT=9000\n#include b.env\n",
+		"b.env":       "#include a.env\n",
+	})
+
+	tests := map[string]error{
+		"missing.env": os.ErrNotExist,
+		"a.env":       errIncludeCycle,
+	}
+
+	for name, expected := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cfg Config
+
+			err := LoadAndParse(filepath.Join(dir, name), &cfg, WithSilent())
+			if !errors.Is(err, errIncludeFailed) || !errors.Is(err, expected) {
+				t.Errorf("Expected include error wrapping %v, got %v", expected, err)
+			}
+		})
+	}
+
+	t.Run("missing top-level file falls back to defaults", func(t *testing.T) {
+		var cfg Config
+
+		if err := LoadAndParse(filepath.Join(dir, "absent.env"), &cfg, WithSilent()); err != nil || cfg.Port != 1 {
+			t.Errorf("Expected defaults without error, got Port=%d, %v", cfg.Port, err)
+		}
+	})
+}
+
+func Test_readEnvFile_Include(t *testing.T) {
+	t.Run("included keys are overridden by later lines", func(t *testing.T) {
+		dir := writeEnvFiles(t, map[string]string{
+			"shared/base.env": "HOST=base.local\nPORT=80\nTIMEOUT=5s",
+			"shared/db.env":   "#include base.env\nDB_URL=postgres://${HOST}/app\n",
+			"service.env":     "PORT=1\n#include \"shared/db.env\"\nPORT=8080\n",
+		})
+
+		envMap, err := readEnvFile(filepath.Join(dir, "service.env"), options{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		tests := Tests[string]{
+			{"nested include", envMap["TIMEOUT"], "5s"},
+			{"later line overrides include", envMap["PORT"], "8080"},
+			{"variable from included file expands", envMap["DB_URL"], "postgres://base.local/app"},
+		}
+
+		tests.runTests(t)
+	})
+
+	t.Run("cycle is detected", func(t *testing.T) {
+		dir := writeEnvFiles(t, map[string]string{
+			"a.env": "#include b.env\nA=1\n",
+			"b.env": "#include a.env\nB=1\n",
+		})
+
+		_, err := readEnvFile(filepath.Join(dir, "a.env"), options{})
+		if !errors.Is(err, errIncludeCycle) {
+			t.Errorf("Expected errIncludeCycle, got %v", err)
+		}
+	})
+
+	t.Run("missing include", func(t *testing.T) {
+		dir := writeEnvFiles(t, map[string]string{"a.env": "#include missing.env\n"})
+
+		_, err := readEnvFile(filepath.Join(dir, "a.env"), options{})
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Expected os.ErrNotExist, got %v", err)
+		}
+	})
+
+	t.Run("comments that only look like includes", func(t *testing.T) {
+		dir := writeEnvFiles(t, map[string]string{"a.env": "#included by ops\n#include\nA=1\n"})
+
+		envMap, err := readEnvFile(filepath.Join(dir, "a.env"), options{})
+		if err != nil || envMap["A"] != "1" {
+			t.Errorf("Expected A=1, got %v (err: %v)", envMap, err)
+		}
+	})
+}
+
+func Test_readEnvFile_ParseError(t *testing.T) {
+	t.Run("invalid key in included file", func(t *testing.T) {
+		dir := writeEnvFiles(t, map[string]string{
+			"base.env": "A=1\nBAD-KEY=2\n",
+			"app.env":  "X=1\n#include base.env\nY=2\n",
+		})
+
+		_, err := readEnvFile(filepath.Join(dir, "app.env"), options{})
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("Expected *ParseError, got %v", err)
+		}
+
+		if filepath.Base(parseErr.File) != "base.env" || parseErr.Line != 2 || parseErr.Column != 4 {
+			t.Errorf("Expected base.env:2:4, got %s:%d:%d", parseErr.File, parseErr.Line, parseErr.Column)
+		}
+
+		if parseErr.Excerpt != "BAD-KEY=2" || !strings.Contains(parseErr.Error(), `unexpected character "-" in variable name`) {
+			t.Errorf("Unexpected error details: %v", parseErr)
+		}
+	})
+
+	t.Run("unterminated quote", func(t *testing.T) {
+		dir := writeEnvFiles(t, map[string]string{
+			"app.env": "A=\"ok\"\r\nB=1\r\n  C=\"never closed\r\nD=4\r\n",
+		})
+
+		_, err := readEnvFile(filepath.Join(dir, "app.env"), options{})
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("Expected *ParseError, got %v", err)
+		}
+
+		if parseErr.Line != 3 || parseErr.Column != 5 || parseErr.Reason != "unterminated quoted value" {
+			t.Errorf("Expected line 3 column 5, got %d:%d (%s)", parseErr.Line, parseErr.Column, parseErr.Reason)
+		}
+	})
+}
+
+func Test_locateParseError(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		line    int
+		column  int
+		reason  string
+	}{
+		{"after multi-line value", "A=\"one\ntwo\"\nB=2\nBAD KEY!=3\nTOKEN=hunter2\n", 4, 8, `unexpected character "!" in variable name`},
+		{"exported key", "export  BAD/KEY=1\nTOKEN=hunter2\n", 1, 12, `unexpected character "/" in variable name`},
+		{"unterminated single quote", "A=1\nB = 'open\nTOKEN=hunter2\n", 2, 5, "unterminated quoted value"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var lines []envLine
+			for i, text := range strings.SplitAfter(test.content, "\n") {
+				if text != "" {
+					lines = append(lines, envLine{file: "app.env", number: i + 1, text: []byte(text)})
+				}
+			}
+
+			err := locateParseError(lines, joinLines(lines))
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Expected *ParseError, got %v", err)
+			}
+
+			if parseErr.Line != test.line || parseErr.Column != test.column || parseErr.Reason != test.reason {
+				t.Errorf("Expected %d:%d %s, got %d:%d %s", test.line, test.column, test.reason,
+					parseErr.Line, parseErr.Column, parseErr.Reason)
+			}
+
+			// The parser quotes the rest of the file; none of it may reach the error chain.
+			for unwrapped := error(parseErr); unwrapped != nil; unwrapped = errors.Unwrap(unwrapped) {
+				if strings.Contains(unwrapped.Error(), "hunter2") {
+					t.Errorf("Expected later lines to stay out of the error, got %v", unwrapped)
+				}
+			}
+		})
+	}
+}
+
+func Test_readEnvFile_Encodings(t *testing.T) {
+	content := "APP_NAME=café\r\nPORT=8080\r\n"
+
+	utf16LE := []byte{0xFF, 0xFE}
+	utf16BE := []byte{0xFE, 0xFF}
+
+	for _, unit := range utf16.Encode([]rune(content)) {
+		utf16LE = binary.LittleEndian.AppendUint16(utf16LE, unit)
+		utf16BE = binary.BigEndian.AppendUint16(utf16BE, unit)
+	}
+
+	files := map[string][]byte{
+		"utf-8 with BOM and CRLF": append([]byte{0xEF, 0xBB, 0xBF}, content...),
+		"utf-16 little endian":    utf16LE,
+		"utf-16 big endian":       utf16BE,
+	}
+
+	for name, raw := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "app.env")
+			if err := os.WriteFile(path, raw, 0o600); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+
+			envMap, err := readEnvFile(path, options{})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if envMap["APP_NAME"] != "café" || envMap["PORT"] != "8080" {
+				t.Errorf("Expected APP_NAME=café and PORT=8080, got %q", envMap)
+			}
+		})
+	}
+
+	t.Run("truncated utf-16", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "app.env")
+		if err := os.WriteFile(path, []byte{0xFF, 0xFE, 'A'}, 0o600); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+
+		if _, err := readEnvFile(path, options{}); !errors.Is(err, errOddUTF16Length) {
+			t.Errorf("Expected errOddUTF16Length, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package envload
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+const (
+	// [maxHostnameLength] is the longest hostname DNS can carry, without the trailing dot.
+	maxHostnameLength = 253
+)
+
+var (
+	// [hostnameLabelPattern] matches one RFC 1123 label: letters, digits and inner hyphens.
+	hostnameLabelPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?$`)
+
+	// [uuidPattern] matches the canonical 8-4-4-4-12 hex form, in either case.
+	uuidPattern = regexp.MustCompile(`^[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}$`)
+)
+
+// checkHostname requires an RFC 1123 hostname such as "db-1" or "db-1.internal".
+func checkHostname(value string) error {
+	if len(value) > maxHostnameLength {
+		return fmt.Errorf("hostname must be at most %d characters, got %d", maxHostnameLength, len(value))
+	}
+
+	for label := range strings.SplitSeq(value, ".") {
+		if !hostnameLabelPattern.MatchString(label) {
+			return fmt.Errorf("%q is not a valid hostname", value)
+		}
+	}
+
+	return nil
+}
+
+// checkFQDN requires a fully qualified domain name: a hostname with at least two labels
+// and a top-level label that is not numeric, optionally ending in a dot ("db.example.com.").
+func checkFQDN(value string) error {
+	name := strings.TrimSuffix(value, ".")
+
+	lastDot := strings.LastIndexByte(name, '.')
+	if lastDot < 0 || checkHostname(name) != nil {
+		return fmt.Errorf("%q is not a fully qualified domain name", value)
+	}
+
+	if strings.Trim(name[lastDot+1:], "0123456789") == "" {
+		return fmt.Errorf("%q is not a fully qualified domain name, its top-level label is numeric", value)
+	}
+
+	return nil
+}
+
+// checkEmail requires a bare RFC 5322 address such as "ops@example.com", without a display name.
+func checkEmail(value string) error {
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Name != "" || address.Address != value {
+		return fmt.Errorf("%q is not a valid email address", value)
+	}
+
+	return nil
+}
+
+// checkUUID requires a UUID in canonical form, such as "123e4567-e89b-12d3-a456-426614174000".
+func checkUUID(value string) error {
+	if !uuidPattern.MatchString(value) {
+		return fmt.Errorf("%q is not a valid UUID", value)
+	}
+
+	return nil
+}
+
+// checkBase64 requires standard, padded base64. The value itself is not echoed as it
+// usually holds key material.
+func checkBase64(value string) error {
+	if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+		return fmt.Errorf("value is not valid base64: %w", err)
+	}
+
+	return nil
+}
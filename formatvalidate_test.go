@@ -0,0 +1,72 @@
+package envload
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_ValidateFormats(t *testing.T) {
+	type Config struct {
+		Host       string   `env:"HOST" validate:"hostname"`
+		Domain     string   `env:"DOMAIN" validate:"fqdn"`
+		AlertEmail string   `env:"ALERT_EMAIL" validate:"email"`
+		InstanceID string   `env:"INSTANCE_ID" validate:"uuid"`
+		SigningKey string   `env:"SIGNING_KEY" secret:"true" validate:"base64"`
+		Peers      []string `env:"PEERS" validate:"dive,hostname"`
+	}
+
+	tests := []struct {
+		name    string
+		envMap  map[string]string
+		message string
+	}{
+		{"valid", map[string]string{
+			"HOST":        "db-1.internal",
+			"DOMAIN":      "api.example.com.",
+			"ALERT_EMAIL": "ops@example.com",
+			"INSTANCE_ID": "123E4567-e89b-12d3-a456-426614174000",
+			"SIGNING_KEY": "c2VjcmV0",
+			"PEERS":       "node-1,node-2",
+		}, ""},
+		{"hostname with underscore", map[string]string{"HOST": "db_1"}, `"db_1" is not a valid hostname`},
+		{"hostname with leading hyphen", map[string]string{"HOST": "-db"}, `"-db" is not a valid hostname`},
+		{"hostname too long", map[string]string{"HOST": strings.Repeat("a.", 127) + "a"}, "hostname must be at most 253 characters"},
+		{"fqdn single label", map[string]string{"DOMAIN": "localhost"}, `"localhost" is not a fully qualified domain name`},
+		{"fqdn numeric tld", map[string]string{"DOMAIN": "10.0.0.1"}, "top-level label is numeric"},
+		{"email with display name", map[string]string{"ALERT_EMAIL": "Ops <ops@example.com>"}, "is not a valid email address"},
+		{"email without domain", map[string]string{"ALERT_EMAIL": "ops"}, `"ops" is not a valid email address`},
+		{"uuid", map[string]string{"INSTANCE_ID": "123e4567e89b12d3a456426614174000"}, "is not a valid UUID"},
+		{"base64", map[string]string{"SIGNING_KEY": "not base64!"}, "value is not valid base64"},
+		{"element", map[string]string{"PEERS": "node-1,node 2"}, `element 1: "node 2" is not a valid hostname`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cfg Config
+
+			err := Parse(test.envMap, &cfg)
+			if test.message == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, errValidationFailed) || !strings.Contains(err.Error(), test.message) {
+				t.Errorf("Expected validation error containing %q, got %v", test.message, err)
+			}
+		})
+	}
+}
+
+func Test_ValidateFormatInvalidType(t *testing.T) {
+	target := &struct {
+		Port int `env:"PORT" validate:"uuid"`
+	}{}
+
+	if err := Parse(map[string]string{"PORT": "8080"}, target); !errors.Is(err, errInvalidTagDefinition) {
+		t.Errorf("Expected errInvalidTagDefinition, got %v", err)
+	}
+}
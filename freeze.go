@@ -0,0 +1,241 @@
+package envload
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"net/url"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"weak"
+)
+
+type (
+	// fingerprinter feeds a deterministic encoding of reflected values into a hash.
+	// visiting holds the pointers being hashed, so cyclic values terminate.
+	fingerprinter struct {
+		hash.Hash64
+		visiting map[uintptr]struct{}
+	}
+
+	// frozenKey identifies a frozen target without keeping it reachable. The type is part
+	// of the key because a struct and its first field share an address.
+	frozenKey struct {
+		target weak.Pointer[byte]
+		typ    reflect.Type
+	}
+)
+
+var (
+	// [frozenConfigs] maps the [frozenKey] of each frozen target to the fingerprints of
+	// its fields. Entries are removed once the target is garbage collected.
+	frozenConfigs sync.Map
+
+	errNotFrozen     = errors.New("config was not frozen")
+	errConfigMutated = errors.New("config mutated after load")
+)
+
+// AssertUnchanged reports an error naming every field of target that changed since
+// it was populated with [WithFreeze]. Slices, maps, pointers and nested values are
+// compared by content, so writes through a pointer field are reported; funcs and
+// channels are compared by identity.
+func AssertUnchanged(target any) error {
+	if err := validateStruct(target); err != nil {
+		return err
+	}
+
+	stored, ok := frozenConfigs.Load(frozenKeyOf(target))
+	if !ok {
+		return errNotFrozen
+	}
+
+	frozen := stored.([]uint64) //nolint:forcetypeassert // note: only freezeConfig stores values.
+	value := reflect.ValueOf(target).Elem()
+	current := fieldFingerprints(value)
+
+	var mutated []string
+	for i, fingerprint := range current {
+		if fingerprint != frozen[i] {
+			mutated = append(mutated, value.Type().Field(i).Name)
+		}
+	}
+
+	if len(mutated) > 0 {
+		return fmt.Errorf("%w: %s", errConfigMutated, strings.Join(mutated, ", "))
+	}
+
+	return nil
+}
+
+// freezeConfig records the field fingerprints of target for [AssertUnchanged].
+// The entry is dropped by a cleanup when target becomes unreachable, so configs
+// replaced by a [Reloader] do not accumulate.
+func freezeConfig(target any) {
+	key := frozenKeyOf(target)
+
+	if _, loaded := frozenConfigs.Swap(key, fieldFingerprints(reflect.ValueOf(target).Elem())); !loaded {
+		runtime.AddCleanup(targetPointer(target), func(key frozenKey) {
+			frozenConfigs.Delete(key)
+		}, key)
+	}
+}
+
+// frozenKeyOf returns the [frozenConfigs] key of target.
+func frozenKeyOf(target any) frozenKey {
+	return frozenKey{target: weak.Make(targetPointer(target)), typ: reflect.TypeOf(target)}
+}
+
+// targetPointer returns the address target points to.
+func targetPointer(target any) *byte {
+	return (*byte)(reflect.ValueOf(target).UnsafePointer())
+}
+
+// fieldFingerprints returns one fingerprint per field of the struct value.
+func fieldFingerprints(value reflect.Value) []uint64 {
+	fingerprints := make([]uint64, value.NumField())
+	for i := range fingerprints {
+		fingerprints[i] = fingerprintOf(value.Field(i))
+	}
+
+	return fingerprints
+}
+
+// fingerprintOf hashes value with [fingerprinter.write].
+func fingerprintOf(value reflect.Value) uint64 {
+	f := newFingerprinter()
+	f.write(value)
+
+	return f.Sum64()
+}
+
+// newFingerprinter returns a fingerprinter over a fresh FNV-1a hash.
+func newFingerprinter() fingerprinter {
+	return fingerprinter{Hash64: fnv.New64a(), visiting: make(map[uintptr]struct{})}
+}
+
+// write encodes value into the hash. Map entries are combined order-independently
+// so iteration order does not affect the result. URLs are encoded as text, as their
+// user info is held by pointer, and [Lazy] values by their raw value, as resolving
+// them fills in their cached result.
+func (f fingerprinter) write(value reflect.Value) {
+	if value.Type() == urlType && value.CanInterface() {
+		link := value.Interface().(url.URL) //nolint:forcetypeassert // note: checked against urlType.
+		f.write(reflect.ValueOf(link.String()))
+
+		return
+	}
+
+	if value.Kind() != reflect.Pointer && value.Type().Implements(lazyRawValueType) && value.CanInterface() {
+		f.write(reflect.ValueOf(lazyRawOf(value)))
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			f.writeUint(1)
+		} else {
+			f.writeUint(0)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f.writeUint(uint64(value.Int())) //nolint:gosec // note: bit pattern only, overflow is irrelevant.
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f.writeUint(value.Uint())
+
+	case reflect.Float32, reflect.Float64:
+		f.writeFloat(value.Float())
+
+	case reflect.Complex64, reflect.Complex128:
+		f.writeFloat(real(value.Complex()))
+		f.writeFloat(imag(value.Complex()))
+
+	case reflect.String:
+		f.writeUint(uint64(value.Len()))
+		f.Write([]byte(value.String()))
+
+	case reflect.Slice, reflect.Array:
+		f.writeUint(uint64(value.Len()))
+
+		for i := range value.Len() {
+			f.write(value.Index(i))
+		}
+
+	case reflect.Map:
+		var sum uint64
+
+		iter := value.MapRange()
+		for iter.Next() {
+			entry := fingerprinter{Hash64: fnv.New64a(), visiting: f.visiting}
+			entry.write(iter.Key())
+			entry.write(iter.Value())
+			sum += entry.Sum64()
+		}
+
+		f.writeUint(uint64(value.Len()))
+		f.writeUint(sum)
+
+	case reflect.Struct:
+		for i := range value.NumField() {
+			f.write(value.Field(i))
+		}
+
+	case reflect.Interface:
+		if value.IsNil() {
+			f.writeUint(0)
+			return
+		}
+
+		f.Write([]byte(value.Elem().Type().String()))
+		f.write(value.Elem())
+
+	case reflect.Pointer:
+		f.writePointer(value)
+
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		f.writeUint(uint64(value.Pointer()))
+
+	default:
+	}
+}
+
+// writePointer encodes what value points to, marking nil pointers and pointers
+// back into a value that is already being hashed.
+func (f fingerprinter) writePointer(value reflect.Value) {
+	if value.IsNil() {
+		f.writeUint(0)
+		return
+	}
+
+	address := value.Pointer()
+	if _, ok := f.visiting[address]; ok {
+		f.writeUint(2)
+		return
+	}
+
+	f.visiting[address] = struct{}{}
+	defer delete(f.visiting, address)
+
+	f.writeUint(1)
+	f.write(value.Elem())
+}
+
+// writeFloat writes n to the hash, with -0 encoded like 0 as the two compare equal.
+func (f fingerprinter) writeFloat(n float64) {
+	if n == 0 {
+		n = 0
+	}
+
+	f.writeUint(math.Float64bits(n))
+}
+
+// writeUint writes n to the hash in a fixed byte order.
+func (f fingerprinter) writeUint(n uint64) {
+	f.Write(binary.LittleEndian.AppendUint64(nil, n))
+}
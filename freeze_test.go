@@ -0,0 +1,183 @@
+package envload
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_AssertUnchanged(t *testing.T) {
+	type Config struct {
+		Name     string            `env:"APP_NAME"`
+		Timeout  time.Duration     `env:"TIMEOUT"`
+		Hosts    []string          `env:"HOSTS"`
+		Labels   map[string]string `env:"LABELS"`
+		Features Features          `env:"FEATURES"`
+		Token    Lazy[string]      `env:"TOKEN"`
+	}
+
+	envMap := map[string]string{
+		"APP_NAME": "service",
+		"TIMEOUT":  "5s",
+		"HOSTS":    "a,b",
+		"LABELS":   "team:core,tier:1",
+		"FEATURES": "beta:true,dark_mode:false",
+		"TOKEN":    "secret",
+	}
+
+	load := func(t *testing.T) *Config {
+		t.Helper()
+
+		var cfg Config
+		if err := Parse(envMap, &cfg, WithFreeze()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		return &cfg
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		cfg := load(t)
+
+		// Reading a lazy field must not count as a mutation.
+		if _, err := cfg.Token.Get(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if err := AssertUnchanged(cfg); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("mutations", func(t *testing.T) {
+		cfg := load(t)
+
+		cfg.Hosts[0] = "c"
+		cfg.Labels["tier"] = "2"
+		cfg.Features["beta"] = false
+
+		err := AssertUnchanged(cfg)
+		if !errors.Is(err, errConfigMutated) {
+			t.Fatalf("Expected errConfigMutated, got %v", err)
+		}
+
+		if !strings.HasSuffix(err.Error(), "Hosts, Labels, Features") {
+			t.Errorf("Expected mutated fields to be listed, got %v", err)
+		}
+	})
+
+	t.Run("refreeze on parse", func(t *testing.T) {
+		cfg := load(t)
+		cfg.Name = "other"
+
+		if err := Parse(envMap, cfg, WithFreeze()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if err := AssertUnchanged(cfg); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("not frozen", func(t *testing.T) {
+		var cfg Config
+		if err := Parse(envMap, &cfg); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if err := AssertUnchanged(&cfg); !errors.Is(err, errNotFrozen) {
+			t.Errorf("Expected errNotFrozen, got %v", err)
+		}
+	})
+	t.Run("write through pointer", func(t *testing.T) {
+		type Pointers struct {
+			Port  *int      `env:"PORT"`
+			Hosts *[]string `env:"HOSTS"`
+		}
+
+		var cfg Pointers
+		if err := Parse(map[string]string{"PORT": "80", "HOSTS": "a"}, &cfg, WithFreeze()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if err := AssertUnchanged(&cfg); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		*cfg.Port = 1
+		(*cfg.Hosts)[0] = "b"
+
+		err := AssertUnchanged(&cfg)
+		if !errors.Is(err, errConfigMutated) || !strings.HasSuffix(err.Error(), "Port, Hosts") {
+			t.Errorf("Expected Port and Hosts to be reported, got %v", err)
+		}
+	})
+}
+
+func Test_fingerprintOf_Cycle(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+
+	loop := &node{Name: "a"}
+	loop.Next = loop
+
+	other := &node{Name: "b"}
+	other.Next = other
+
+	if fingerprintOf(reflect.ValueOf(loop)) == fingerprintOf(reflect.ValueOf(other)) {
+		t.Error("Expected cyclic values with different content to hash differently")
+	}
+}
+
+func Test_freezeConfig_Collected(t *testing.T) {
+	type Config struct {
+		Name string `env:"APP_NAME"`
+	}
+
+	countFrozen := func() int {
+		count := 0
+		frozenConfigs.Range(func(_, _ any) bool {
+			count++
+			return true
+		})
+
+		return count
+	}
+
+	before := countFrozen()
+
+	for range 100 {
+		if err := Parse(map[string]string{"APP_NAME": "service"}, new(Config), WithFreeze()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	// Cleanups run asynchronously after collection, so give them a few cycles.
+	for range 50 {
+		runtime.GC()
+
+		if countFrozen() <= before {
+			return
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Errorf("Expected collected configs to be unfrozen, %d entries remain", countFrozen()-before)
+}
+
+func Test_freezeConfig_EmptyStruct(t *testing.T) {
+	var cfg struct{}
+	if err := Parse(nil, &cfg, WithFreeze()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := AssertUnchanged(&cfg); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package envload
+
+import (
+	"iter"
+	"reflect"
+
+	"github.com/go-fynx/envload/internal/envfield"
+)
+
+type (
+	// Group is one struct of a config schema with the settings it declares, as yielded
+	// by [Groups]: the target itself or a struct nested in it with an `envPrefix` tag.
+	Group struct {
+		Title  string       // "Database.Pool" for nested structs, the type name for the target.
+		Prefix string       // Prefix of the group's variables, joined from the enclosing `envPrefix` tags.
+		Fields []GroupField // Env-tagged fields in declaration order, including inlined embedded ones.
+	}
+
+	// GroupField describes one setting of a [Group].
+	GroupField struct {
+		Name     string   // Go field name within the group.
+		Env      string   // Variable the field is read from, with the group prefix.
+		Aliases  []string // Alternative variables from the `alias` tag, with the group prefix.
+		Type     string   // Go type, such as "time.Duration".
+		Default  string   // Value of the `default` tag.
+		Required bool     // Whether the field is tagged `required:"true"`.
+		Secret   bool     // Whether the field is tagged `secret:"true"`.
+		Rules    string   // Value of the `validate` tag.
+	}
+)
+
+// Groups walks the schema of target (a struct or pointer to one) group by group, for
+// documentation generators that render a section per nested struct rather than one flat
+// table. Each group lists its own settings; nested groups follow the group enclosing them,
+// depth first, and groups without settings are skipped. The target is not read.
+//
+// Example:
+//
+//	for group := range envload.Groups(&Config{}) {
+//		fmt.Printf("## %s\n\n", group.Title)
+//		for _, field := range group.Fields {
+//			fmt.Printf("- `%s` (%s) default %q\n", field.Env, field.Type, field.Default)
+//		}
+//	}
+func Groups(target any) iter.Seq[Group] {
+	return func(yield func(Group) bool) {
+		typ := reflect.TypeOf(target)
+		for typ != nil && typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+
+		if typ == nil || typ.Kind() != reflect.Struct {
+			return
+		}
+
+		walkGroups(Group{Title: typ.Name()}, "", typ, yield)
+	}
+}
+
+// walkGroups yields group filled with the fields of typ, then the groups nested in typ,
+// titled by their field path behind path. It reports false once yield asks to stop.
+func walkGroups(group Group, path string, typ reflect.Type, yield func(Group) bool) bool {
+	nested := collectGroupFields(&group, typ)
+
+	if len(group.Fields) > 0 && !yield(group) {
+		return false
+	}
+
+	for _, field := range nested {
+		// Fields of embedded structs are promoted, so their path stays the same.
+		nestedPath := path + field.Name + "."
+		if field.Anonymous {
+			nestedPath = path
+		}
+
+		nestedGroup := Group{Title: path + field.Name, Prefix: group.Prefix + field.Tag.Get("envPrefix")}
+		if !walkGroups(nestedGroup, nestedPath, field.Type, yield) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// collectGroupFields adds the env-tagged fields of typ to group, inlining embedded structs
+// without an `envPrefix` tag, and returns the struct fields that form groups of their own.
+func collectGroupFields(group *Group, typ reflect.Type) []reflect.StructField {
+	var nested []reflect.StructField
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+
+		if envfield.IsNestedStruct(field) {
+			if _, hasPrefix := field.Tag.Lookup("envPrefix"); field.Anonymous && !hasPrefix {
+				nested = append(nested, collectGroupFields(group, field.Type)...)
+			} else {
+				nested = append(nested, field)
+			}
+
+			continue
+		}
+
+		if !isTaggedField(field) {
+			continue
+		}
+
+		aliases := aliasKeys(field)
+		for j := range aliases {
+			aliases[j] = group.Prefix + aliases[j]
+		}
+
+		group.Fields = append(group.Fields, GroupField{
+			Name:     field.Name,
+			Env:      group.Prefix + field.Tag.Get("env"),
+			Aliases:  aliases,
+			Type:     field.Type.String(),
+			Default:  field.Tag.Get("default"),
+			Required: field.Tag.Get("required") == "true",
+			Secret:   isSecretField(field),
+			Rules:    field.Tag.Get("validate"),
+		})
+	}
+
+	return nested
+}
@@ -0,0 +1,58 @@
+package envload
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Groups(t *testing.T) {
+	type Worker struct {
+		Concurrency int `env:"CONCURRENCY" default:"4" validate:"min=1"`
+	}
+
+	type Config struct {
+		loggingConfig
+		Worker   `envPrefix:"WORKER_"`
+		Name     string         `env:"APP_NAME" required:"true"`
+		Database nestedDatabase `envPrefix:"DB_"`
+		Internal string
+	}
+
+	var titles []string
+	for group := range Groups(&Config{}) {
+		titles = append(titles, group.Title+"|"+group.Prefix)
+	}
+
+	expected := []string{"Config|", "Worker|WORKER_", "Database|DB_", "Database.Pool|DB_POOL_"}
+	if !reflect.DeepEqual(titles, expected) {
+		t.Errorf("Expected groups %v, got %v", expected, titles)
+	}
+
+	for group := range Groups(Config{}) {
+		expectedFields := []GroupField{
+			{Name: "Level", Env: "LOG_LEVEL", Type: "string", Default: "info"},
+			{Name: "Name", Env: "APP_NAME", Type: "string", Required: true},
+		}
+
+		if !reflect.DeepEqual(group.Fields, expectedFields) {
+			t.Errorf("Expected fields %+v, got %+v", expectedFields, group.Fields)
+		}
+
+		break
+	}
+
+	for group := range Groups(&Config{}) {
+		if group.Title != "Database" {
+			continue
+		}
+
+		password := group.Fields[2]
+		if password.Env != "DB_PASSWORD" || !reflect.DeepEqual(password.Aliases, []string{"DB_PASS"}) || !password.Secret {
+			t.Errorf("Unexpected password field: %+v", password)
+		}
+	}
+
+	for range Groups(42) {
+		t.Error("Expected no groups for a non-struct target")
+	}
+}
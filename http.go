@@ -0,0 +1,53 @@
+package envload
+
+import (
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+var (
+	// [keyCanonicalizer] replaces separators that are not valid in env names.
+	keyCanonicalizer = strings.NewReplacer("-", "_", ".", "_", " ", "_")
+)
+
+// HeaderMap converts HTTP headers into the env map form accepted by [Parse].
+// Header names are canonicalized to env style: X-Request-Limit -> X_REQUEST_LIMIT.
+// Repeated headers are joined with commas so they decode into slices.
+func HeaderMap(header http.Header) map[string]string {
+	return canonicalMap(header)
+}
+
+// ValuesMap converts URL query or form values into the env map form accepted by [Parse].
+// Keys are canonicalized to env style: page-size -> PAGE_SIZE.
+// Repeated keys are joined with commas so they decode into slices.
+func ValuesMap(values url.Values) map[string]string {
+	return canonicalMap(values)
+}
+
+// canonicalMap upper-cases keys, replaces separators with underscores and joins repeated values.
+// Keys that canonicalize to the same name are merged in the order of their source keys.
+func canonicalMap(source map[string][]string) map[string]string {
+	envMap := make(map[string]string, len(source))
+
+	for _, key := range slices.Sorted(maps.Keys(source)) {
+		values := source[key]
+
+		envKey := CanonicalKey(key)
+		if existing, ok := envMap[envKey]; ok {
+			values = append([]string{existing}, values...)
+		}
+
+		envMap[envKey] = strings.Join(values, ",")
+	}
+
+	return envMap
+}
+
+// CanonicalKey converts a header or query parameter name into env style.
+// Example: "X-Request-Limit" -> "X_REQUEST_LIMIT".
+func CanonicalKey(name string) string {
+	return strings.ToUpper(keyCanonicalizer.Replace(strings.TrimSpace(name)))
+}
@@ -0,0 +1,82 @@
+package envload
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func Test_HeaderMap(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Request-Limit", "25")
+	header.Set("X-Timeout", "3s")
+	header.Add("X-Tags", "a")
+	header.Add("X-Tags", "b")
+
+	var options struct {
+		Limit   int           `env:"X_REQUEST_LIMIT" default:"10"`
+		Timeout time.Duration `env:"X_TIMEOUT"`
+		Tags    []string      `env:"X_TAGS"`
+		Missing string        `env:"X_MISSING" default:"fallback"`
+	}
+
+	if err := Parse(HeaderMap(header), &options); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if options.Limit != 25 {
+		t.Errorf("Expected 25, got %d", options.Limit)
+	}
+
+	if options.Timeout != 3*time.Second {
+		t.Errorf("Expected 3s, got %v", options.Timeout)
+	}
+
+	if len(options.Tags) != 2 || options.Tags[0] != "a" || options.Tags[1] != "b" {
+		t.Errorf("Expected [a b], got %v", options.Tags)
+	}
+
+	if options.Missing != "fallback" {
+		t.Errorf("Expected 'fallback', got '%s'", options.Missing)
+	}
+}
+
+func Test_ValuesMap(t *testing.T) {
+	values, err := url.ParseQuery("page-size=50&sort.order=desc&id=1&id=2")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	envMap := ValuesMap(values)
+
+	tests := Tests[string]{
+		{"dash becomes underscore", envMap["PAGE_SIZE"], "50"},
+		{"dot becomes underscore", envMap["SORT_ORDER"], "desc"},
+		{"repeated values are joined", envMap["ID"], "1,2"},
+	}
+
+	tests.runTests(t)
+}
+
+func Test_ValuesMap_Collisions(t *testing.T) {
+	values := url.Values{"tag": {"c"}, "TAG": {"a"}, "Tag": {"b", "d"}}
+
+	// Map iteration order varies between runs, so repeat to catch nondeterminism.
+	for range 20 {
+		if got := ValuesMap(values)["TAG"]; got != "a,b,d,c" {
+			t.Fatalf("Expected colliding keys merged in sorted key order, got %q", got)
+		}
+	}
+}
+
+func Test_CanonicalKey(t *testing.T) {
+	tests := Tests[string]{
+		{"header name", CanonicalKey("X-Request-Id"), "X_REQUEST_ID"},
+		{"query name", CanonicalKey("page.size"), "PAGE_SIZE"},
+		{"already canonical", CanonicalKey("PORT"), "PORT"},
+		{"surrounding spaces", CanonicalKey(" debug "), "DEBUG"},
+	}
+
+	tests.runTests(t)
+}
@@ -0,0 +1,81 @@
+package envload
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+type (
+	// HTTPServerConfig is a ready-made tagged struct for HTTP server limits and timeouts.
+	//
+	// Example:
+	//
+	//	var cfg envload.HTTPServerConfig
+	//	if err := envload.LoadAndParse(".env", &cfg); err != nil { ... }
+	//	server := &http.Server{Handler: mux}
+	//	cfg.Apply(server)
+	HTTPServerConfig struct {
+		Addr              string        `env:"HTTP_ADDR"                default:":8080"`
+		ReadTimeout       time.Duration `env:"HTTP_READ_TIMEOUT"        default:"15s"`
+		ReadHeaderTimeout time.Duration `env:"HTTP_READ_HEADER_TIMEOUT" default:"5s"`
+		WriteTimeout      time.Duration `env:"HTTP_WRITE_TIMEOUT"       default:"30s"`
+		IdleTimeout       time.Duration `env:"HTTP_IDLE_TIMEOUT"        default:"60s"`
+		MaxHeaderBytes    int           `env:"HTTP_MAX_HEADER_BYTES"    default:"1048576"`
+		ShutdownTimeout   time.Duration `env:"HTTP_SHUTDOWN_TIMEOUT"    default:"10s"`
+	}
+
+	// HTTPClientConfig is a ready-made tagged struct for HTTP client timeouts and connection pooling.
+	//
+	// Example:
+	//
+	//	var cfg envload.HTTPClientConfig
+	//	if err := envload.LoadAndParse(".env", &cfg); err != nil { ... }
+	//	client := cfg.Build()
+	HTTPClientConfig struct {
+		Timeout               time.Duration `env:"HTTP_CLIENT_TIMEOUT"                 default:"30s"`
+		DialTimeout           time.Duration `env:"HTTP_CLIENT_DIAL_TIMEOUT"            default:"5s"`
+		KeepAlive             time.Duration `env:"HTTP_CLIENT_KEEP_ALIVE"              default:"30s"`
+		TLSHandshakeTimeout   time.Duration `env:"HTTP_CLIENT_TLS_HANDSHAKE_TIMEOUT"   default:"10s"`
+		ResponseHeaderTimeout time.Duration `env:"HTTP_CLIENT_RESPONSE_HEADER_TIMEOUT" default:"10s"`
+		IdleConnTimeout       time.Duration `env:"HTTP_CLIENT_IDLE_CONN_TIMEOUT"       default:"90s"`
+		MaxIdleConns          int           `env:"HTTP_CLIENT_MAX_IDLE_CONNS"          default:"100"`
+		MaxIdleConnsPerHost   int           `env:"HTTP_CLIENT_MAX_IDLE_CONNS_PER_HOST" default:"10"`
+		MaxConnsPerHost       int           `env:"HTTP_CLIENT_MAX_CONNS_PER_HOST"      default:"0"`
+	}
+)
+
+// Apply copies the address, timeouts and header limit into server.
+// ShutdownTimeout is not a server field; pass it to the context used for server.Shutdown.
+func (config HTTPServerConfig) Apply(server *http.Server) {
+	server.Addr = config.Addr
+	server.ReadTimeout = config.ReadTimeout
+	server.ReadHeaderTimeout = config.ReadHeaderTimeout
+	server.WriteTimeout = config.WriteTimeout
+	server.IdleTimeout = config.IdleTimeout
+	server.MaxHeaderBytes = config.MaxHeaderBytes
+}
+
+// Build returns an *http.Client whose transport is a clone of http.DefaultTransport
+// with the configured timeouts and pool limits applied.
+func (config HTTPClientConfig) Build() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert // note: DefaultTransport is always *http.Transport.
+
+	dialer := &net.Dialer{
+		Timeout:   config.DialTimeout,
+		KeepAlive: config.KeepAlive,
+	}
+
+	transport.DialContext = dialer.DialContext
+	transport.TLSHandshakeTimeout = config.TLSHandshakeTimeout
+	transport.ResponseHeaderTimeout = config.ResponseHeaderTimeout
+	transport.IdleConnTimeout = config.IdleConnTimeout
+	transport.MaxIdleConns = config.MaxIdleConns
+	transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	transport.MaxConnsPerHost = config.MaxConnsPerHost
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   config.Timeout,
+	}
+}
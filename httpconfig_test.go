@@ -0,0 +1,72 @@
+package envload
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_HTTPServerConfig_Apply(t *testing.T) {
+	envMap := map[string]string{
+		"HTTP_ADDR":          ":9090",
+		"HTTP_WRITE_TIMEOUT": "45s",
+	}
+
+	var config HTTPServerConfig
+	if err := Parse(envMap, &config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	server := &http.Server{} //nolint:gosec // note: Timeouts are applied below.
+	config.Apply(server)
+
+	tests := Tests[time.Duration]{
+		{"read timeout default", server.ReadTimeout, 15 * time.Second},
+		{"read header timeout default", server.ReadHeaderTimeout, 5 * time.Second},
+		{"write timeout from env", server.WriteTimeout, 45 * time.Second},
+		{"idle timeout default", server.IdleTimeout, 60 * time.Second},
+		{"shutdown timeout default", config.ShutdownTimeout, 10 * time.Second},
+	}
+
+	tests.runTests(t)
+
+	if server.Addr != ":9090" || server.MaxHeaderBytes != 1<<20 {
+		t.Errorf("Unexpected server settings: addr=%s maxHeaderBytes=%d", server.Addr, server.MaxHeaderBytes)
+	}
+}
+
+func Test_HTTPClientConfig_Build(t *testing.T) {
+	envMap := map[string]string{
+		"HTTP_CLIENT_TIMEOUT":                 "3s",
+		"HTTP_CLIENT_MAX_IDLE_CONNS_PER_HOST": "32",
+	}
+
+	var config HTTPClientConfig
+	if err := Parse(envMap, &config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	client := config.Build()
+
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected *http.Transport, got %T", client.Transport)
+	}
+
+	if transport == http.DefaultTransport {
+		t.Error("Expected a cloned transport, got http.DefaultTransport")
+	}
+
+	tests := Tests[time.Duration]{
+		{"client timeout from env", client.Timeout, 3 * time.Second},
+		{"tls handshake timeout default", transport.TLSHandshakeTimeout, 10 * time.Second},
+		{"response header timeout default", transport.ResponseHeaderTimeout, 10 * time.Second},
+		{"idle conn timeout default", transport.IdleConnTimeout, 90 * time.Second},
+	}
+
+	tests.runTests(t)
+
+	if transport.MaxIdleConns != 100 || transport.MaxIdleConnsPerHost != 32 || transport.DialContext == nil {
+		t.Errorf("Unexpected pool settings: maxIdle=%d perHost=%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	}
+}
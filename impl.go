@@ -0,0 +1,102 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type (
+	// implConstructor builds an implementation from the env map being parsed.
+	implConstructor func(envMap map[string]string) (any, error)
+)
+
+var (
+	errUnknownImpl    = errors.New("no implementation registered")
+	errImplNotAllowed = errors.New("implementation not allowed for field")
+
+	implMu       sync.RWMutex
+	implRegistry = make(map[reflect.Type]map[string]implConstructor)
+)
+
+// RegisterImpl registers a constructor for the interface type I under name.
+// Interface fields of type I are populated by calling the constructor whose name
+// matches the field's env value. The constructor receives the env map being parsed,
+// so it can read its own settings (e.g. with [Parse]); elements of slice fields get the
+// same map. Interface values inside a [Lazy] field are built by Get after loading, when
+// the map is gone, so their constructor receives a nil map.
+// Registering the same name twice for I replaces the previous constructor.
+//
+// RegisterImpl panics if I is not an interface type.
+//
+// Example:
+//
+//	envload.RegisterImpl("s3", func(envMap map[string]string) (Storage, error) {
+//		var cfg S3Config
+//		if err := envload.Parse(envMap, &cfg); err != nil {
+//			return nil, err
+//		}
+//		return NewS3Storage(cfg), nil
+//	})
+//
+//	type Config struct {
+//		Storage Storage `env:"STORAGE_BACKEND" impl:"s3|gcs|file" default:"file"`
+//	}
+func RegisterImpl[I any](name string, constructor func(envMap map[string]string) (I, error)) {
+	ifaceType := reflect.TypeFor[I]()
+	if ifaceType.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("envload: RegisterImpl: %s is not an interface type", ifaceType))
+	}
+
+	implMu.Lock()
+	defer implMu.Unlock()
+
+	if implRegistry[ifaceType] == nil {
+		implRegistry[ifaceType] = make(map[string]implConstructor)
+	}
+
+	implRegistry[ifaceType][name] = func(envMap map[string]string) (any, error) {
+		return constructor(envMap)
+	}
+}
+
+// lookupImpl returns the constructor registered for ifaceType under name.
+func lookupImpl(ifaceType reflect.Type, name string) (implConstructor, bool) {
+	implMu.RLock()
+	defer implMu.RUnlock()
+
+	constructor, ok := implRegistry[ifaceType][name]
+
+	return constructor, ok
+}
+
+// setImpl builds the implementation selected by rawValue and sets it into the interface field.
+// The optional `impl` tag restricts the accepted names: `impl:"s3|gcs|file"`.
+func (resolver *fieldResolver) setImpl() error {
+	name := strings.TrimSpace(resolver.rawValue)
+
+	if allowed := resolver.field.Tag.Get("impl"); allowed != "" && !slices.Contains(strings.Split(allowed, "|"), name) {
+		return fmt.Errorf("%w '%s': '%s' (allowed: %s)", errImplNotAllowed, resolver.field.Name, name, allowed)
+	}
+
+	constructor, ok := lookupImpl(resolver.value.Type(), name)
+	if !ok {
+		return fmt.Errorf("%w for field '%s': %s '%s'", errUnknownImpl, resolver.field.Name, resolver.value.Type(), name)
+	}
+
+	impl, err := constructor(resolver.envMap)
+	if err != nil {
+		return fmt.Errorf("invalid implementation '%s' for field '%s': %w", name, resolver.field.Name, err)
+	}
+
+	if impl == nil {
+		return nil // Constructor chose to leave the field unset.
+	}
+
+	resolver.value.Set(reflect.ValueOf(impl))
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package envload
+
+import (
+	"errors"
+	"testing"
+)
+
+type (
+	testStorage interface {
+		Name() string
+	}
+
+	testFileStorage struct {
+		Dir string `env:"STORAGE_DIR" default:"/tmp"`
+	}
+
+	testMemoryStorage struct{}
+)
+
+func (storage testFileStorage) Name() string { return "file:" + storage.Dir }
+
+func (testMemoryStorage) Name() string { return "memory" }
+
+func Test_Impl_FieldDecoding(t *testing.T) {
+	RegisterImpl("file", func(envMap map[string]string) (testStorage, error) {
+		var storage testFileStorage
+		if err := Parse(envMap, &storage); err != nil {
+			return nil, err
+		}
+
+		return storage, nil
+	})
+	RegisterImpl("memory", func(map[string]string) (testStorage, error) {
+		return testMemoryStorage{}, nil
+	})
+
+	t.Run("implementation selected by env value", func(t *testing.T) {
+		envMap := map[string]string{
+			"STORAGE_BACKEND": "file",
+			"STORAGE_DIR":     "/data",
+		}
+
+		var config struct {
+			Storage testStorage `env:"STORAGE_BACKEND" impl:"file|memory"`
+		}
+
+		if err := populateStruct(envMap, &config); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if config.Storage == nil || config.Storage.Name() != "file:/data" {
+			t.Errorf("Expected file storage in /data, got %v", config.Storage)
+		}
+	})
+
+	t.Run("default implementation", func(t *testing.T) {
+		var config struct {
+			Storage testStorage `default:"memory" env:"STORAGE_BACKEND"`
+		}
+
+		if err := populateStruct(map[string]string{}, &config); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if config.Storage == nil || config.Storage.Name() != "memory" {
+			t.Errorf("Expected memory storage, got %v", config.Storage)
+		}
+	})
+
+	t.Run("name outside impl tag", func(t *testing.T) {
+		envMap := map[string]string{"STORAGE_BACKEND": "memory"}
+
+		var config struct {
+			Storage testStorage `env:"STORAGE_BACKEND" impl:"file"`
+		}
+
+		err := populateStruct(envMap, &config)
+		if !errors.Is(err, errImplNotAllowed) {
+			t.Errorf("Expected errImplNotAllowed, got %v", err)
+		}
+	})
+
+	t.Run("slice elements receive the env map", func(t *testing.T) {
+		envMap := map[string]string{
+			"STORAGE_BACKENDS": "file,memory",
+			"STORAGE_DIR":      "/data",
+		}
+
+		var config struct {
+			Storages []testStorage `env:"STORAGE_BACKENDS"`
+		}
+
+		if err := populateStruct(envMap, &config); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(config.Storages) != 2 || config.Storages[0].Name() != "file:/data" || config.Storages[1].Name() != "memory" {
+			t.Errorf("Expected file storage in /data and memory storage, got %v", config.Storages)
+		}
+	})
+
+	t.Run("unregistered name", func(t *testing.T) {
+		envMap := map[string]string{"STORAGE_BACKEND": "s3"}
+
+		var config struct {
+			Storage testStorage `env:"STORAGE_BACKEND"`
+		}
+
+		err := populateStruct(envMap, &config)
+		if !errors.Is(err, errUnknownImpl) {
+			t.Errorf("Expected errUnknownImpl, got %v", err)
+		}
+	})
+}
+
+func Test_RegisterImplNotInterface(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for a non-interface type")
+		}
+	}()
+
+	RegisterImpl("file", func(map[string]string) (testFileStorage, error) {
+		return testFileStorage{}, nil
+	})
+}
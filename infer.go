@@ -0,0 +1,43 @@
+package envload
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// [decimalPattern] matches plain decimal numbers, so words such as "nan" or "inf"
+	// that strconv.ParseFloat also accepts stay strings.
+	decimalPattern = regexp.MustCompile(`^[+-]?(?:\d+\.?\d*|\.\d+)(?:[eE][+-]?\d+)?$`)
+)
+
+// inferValue converts rawValue to the most specific type it can be read as, for
+// map[string]any values under [WithInferredTypes]. Candidates are tried in order:
+// bool ("true"/"false" in any case), int, float64, time.Duration, then string.
+// Example: "8080" -> 8080, "0.5" -> 0.5, "30s" -> 30*time.Second, "on" -> "on".
+func inferValue(rawValue string) any {
+	switch strings.ToLower(rawValue) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	if intVal, err := strconv.Atoi(rawValue); err == nil {
+		return intVal
+	}
+
+	if decimalPattern.MatchString(rawValue) {
+		if floatVal, err := strconv.ParseFloat(rawValue, 64); err == nil {
+			return floatVal
+		}
+	}
+
+	if duration, err := time.ParseDuration(rawValue); err == nil {
+		return duration
+	}
+
+	return rawValue
+}
@@ -0,0 +1,58 @@
+package envload
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_inferValue(t *testing.T) {
+	tests := []struct {
+		rawValue string
+		expected any
+	}{
+		{"true", true},
+		{"FALSE", false},
+		{"8080", 8080},
+		{"-3", -3},
+		{"0.25", 0.25},
+		{"1e3", 1000.0},
+		{"30s", 30 * time.Second},
+		{"1h30m", 90 * time.Minute},
+		{"on", "on"},
+		{"nan", "nan"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		if got := inferValue(test.rawValue); got != test.expected {
+			t.Errorf("inferValue(%q): expected %v (%T), got %v (%T)", test.rawValue, test.expected, test.expected, got, got)
+		}
+	}
+}
+
+func Test_WithInferredTypes(t *testing.T) {
+	type Config struct {
+		SDK map[string]any `env:"SDK_OPTIONS" default:"retries:3"`
+	}
+
+	envMap := map[string]string{"SDK_OPTIONS": "retries:5,verbose:true,ratio:0.5,timeout:2s,region:eu-west-1"}
+
+	var cfg Config
+	if err := Parse(envMap, &cfg, WithInferredTypes(), WithStrictTags()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]any{"retries": 5, "verbose": true, "ratio": 0.5, "timeout": 2 * time.Second, "region": "eu-west-1"}
+	if !reflect.DeepEqual(cfg.SDK, expected) {
+		t.Errorf("Expected %v, got %v", expected, cfg.SDK)
+	}
+
+	t.Run("without option", func(t *testing.T) {
+		var cfg Config
+		if err := Parse(envMap, &cfg); !errors.Is(err, errUnsupportedMapValueType) {
+			t.Errorf("Expected errUnsupportedMapValueType, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package envload
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+const (
+	// [defaultSectionJoiner] joins section and key names when [INIMap] gets no joiner.
+	defaultSectionJoiner = "_"
+)
+
+var (
+	errInvalidINI = errors.New("invalid INI file")
+)
+
+// INIMap reads an INI file into the env map form accepted by [Parse], for legacy
+// applications that still ship INI configs. Keys are prefixed with their section,
+// joined with joiner ("_" when empty) and canonicalized with [CanonicalKey]:
+// "url" under "[database]" becomes DATABASE_URL, or DATABASE__URL with joiner "__".
+// Keys before the first section are not prefixed.
+//   - Keys and values are separated by "=" or ":" and trimmed.
+//   - Lines starting with ";" or "#" are comments, as is the rest of a line after
+//     " ;" or " #" in unquoted values.
+//   - Values in matching single or double quotes are unquoted, keeping ";" and "#".
+//   - Later keys override earlier ones.
+//
+// Lines that are neither comments, sections nor assignments are reported as a [ParseError].
+//
+// Example:
+//
+//	envMap, err := envload.INIMap("/etc/legacy/app.ini", "")
+//	if err != nil {
+//		return err
+//	}
+//	err = envload.Parse(envMap, &cfg)
+func INIMap(filePath, joiner string) (map[string]string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(content)
+
+	return parseINI(filePath, string(content), joiner)
+}
+
+// parseINI parses INI text, prefixing keys with their section.
+func parseINI(filePath, text, joiner string) (map[string]string, error) {
+	if joiner == "" {
+		joiner = defaultSectionJoiner
+	}
+
+	envMap := make(map[string]string)
+	prefix := ""
+
+	for index, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" || trimmed[0] == ';' || trimmed[0] == '#' {
+			continue
+		}
+
+		if match := sectionHeaderPattern.FindStringSubmatch(trimmed); match != nil {
+			prefix = ""
+			if section := CanonicalKey(match[1]); section != "" {
+				prefix = section + joiner
+			}
+
+			continue
+		}
+
+		separator := strings.IndexAny(trimmed, "=:")
+		key := CanonicalKey(trimmed[:max(separator, 0)])
+
+		if separator < 0 || key == "" {
+			return nil, &ParseError{
+				File:    filePath,
+				Line:    index + 1,
+				Column:  strings.Index(line, trimmed) + 1,
+				Excerpt: truncateExcerpt(redactExcerpt(strings.TrimRight(line, "\r"))),
+				Reason:  "expected a [section] or key = value",
+				Err:     errInvalidINI,
+			}
+		}
+
+		envMap[prefix+key] = iniValue(strings.TrimSpace(trimmed[separator+1:]))
+	}
+
+	return envMap, nil
+}
+
+// iniValue unquotes value or strips its inline comment. A quoted value may be followed
+// by a comment, as in `"a;b" ; note`; comment markers inside the quotes are kept.
+func iniValue(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]) + 1; end > 0 {
+			rest := strings.TrimSpace(value[end+1:])
+			if rest == "" || rest[0] == ';' || rest[0] == '#' {
+				return value[1:end]
+			}
+		}
+	}
+
+	for _, marker := range []string{" ;", " #", "\t;", "\t#"} {
+		if index := strings.Index(value, marker); index >= 0 {
+			value = value[:index]
+		}
+	}
+
+	return strings.TrimSpace(value)
+}
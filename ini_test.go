@@ -0,0 +1,115 @@
+package envload
+
+import (
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_parseINI(t *testing.T) {
+	content := "; legacy settings\n" +
+		"app_name = orders\n" +
+		"\n" +
+		"[database]\n" +
+		"url = postgres://db/app ; primary\n" +
+		"pool-size: 10\n" +
+		"password = \"p;ss#word\"\n" +
+		"user = 'a;b' ; service account\n" +
+		"\n" +
+		"[cache.redis]   # second section\n" +
+		"  host = redis.internal\n" +
+		"timeout=\r\n" +
+		"[]\n" +
+		"debug = true\n"
+
+	tests := []struct {
+		joiner   string
+		expected map[string]string
+	}{
+		{"", map[string]string{
+			"APP_NAME":            "orders",
+			"DATABASE_URL":        "postgres://db/app",
+			"DATABASE_POOL_SIZE":  "10",
+			"DATABASE_PASSWORD":   "p;ss#word",
+			"DATABASE_USER":       "a;b",
+			"CACHE_REDIS_HOST":    "redis.internal",
+			"CACHE_REDIS_TIMEOUT": "",
+			"DEBUG":               "true",
+		}},
+		{"__", map[string]string{
+			"APP_NAME":             "orders",
+			"DATABASE__URL":        "postgres://db/app",
+			"DATABASE__POOL_SIZE":  "10",
+			"DATABASE__PASSWORD":   "p;ss#word",
+			"DATABASE__USER":       "a;b",
+			"CACHE_REDIS__HOST":    "redis.internal",
+			"CACHE_REDIS__TIMEOUT": "",
+			"DEBUG":                "true",
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run("joiner "+test.joiner, func(t *testing.T) {
+			got, err := parseINI("app.ini", content, test.joiner)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !maps.Equal(got, test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func Test_iniValue(t *testing.T) {
+	tests := map[string]string{
+		`"a;b" ; note`:   "a;b",
+		`'x #y'  # note`: "x #y",
+		`"quoted"`:       "quoted",
+		`"a" b ; note`:   `"a" b`,
+		`"unterminated`:  `"unterminated`,
+		`plain ; note`:   "plain",
+	}
+
+	for value, expected := range tests {
+		if got := iniValue(value); got != expected {
+			t.Errorf("Expected iniValue(%q) to be %q, got %q", value, expected, got)
+		}
+	}
+}
+
+func Test_parseINIInvalidLine(t *testing.T) {
+	_, err := parseINI("app.ini", "[database]\n  just some text\n", "")
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || !errors.Is(err, errInvalidINI) {
+		t.Fatalf("Expected ParseError wrapping errInvalidINI, got %v", err)
+	}
+
+	if parseErr.Line != 2 || parseErr.Column != 3 {
+		t.Errorf("Expected line 2, column 3, got %d:%d", parseErr.Line, parseErr.Column)
+	}
+}
+
+func Test_INIMap(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "app.ini")
+	if err := os.WriteFile(filePath, []byte("[server]\nport = 9090\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	envMap, err := INIMap(filePath, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var cfg struct {
+		Port int `env:"SERVER_PORT"`
+	}
+
+	if err := Parse(envMap, &cfg); err != nil || cfg.Port != 9090 {
+		t.Errorf("Expected SERVER_PORT=9090, got %d (%v)", cfg.Port, err)
+	}
+}
@@ -0,0 +1,40 @@
+// Package envfield holds the struct layout rules shared by envload and envloadtest,
+// so both walk a config struct the same way.
+package envfield
+
+import (
+	"reflect"
+)
+
+// LazyValueType returns T when typ is envload.Lazy[T]. envload sets it when it is
+// initialized, so envloadtest can find the type of lazy fields without depending on
+// how Lazy stores its value.
+var LazyValueType = func(reflect.Type) (reflect.Type, bool) {
+	return nil, false
+}
+
+// HasParser reports whether a parser registered with envload.RegisterParser decodes typ,
+// so envloadtest does not generate values by kind for it. envload sets it when it is
+// initialized.
+var HasParser = func(reflect.Type) bool {
+	return false
+}
+
+// IsNestedStruct reports whether the fields of field are loaded like those of the target:
+// exported structs tagged `envPrefix`, and embedded structs without an `env` tag, which
+// are inlined unless they have an `envPrefix` tag as well.
+// Example: `envPrefix:"DB_"` on Database struct{ Host string `env:"HOST"` } -> DB_HOST.
+func IsNestedStruct(field reflect.StructField) bool {
+	if field.Type.Kind() != reflect.Struct {
+		return false
+	}
+
+	if field.Anonymous {
+		_, hasEnv := field.Tag.Lookup("env")
+		return !hasEnv
+	}
+
+	_, hasPrefix := field.Tag.Lookup("envPrefix")
+
+	return hasPrefix && field.IsExported()
+}
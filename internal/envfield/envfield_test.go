@@ -0,0 +1,39 @@
+package envfield
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_IsNestedStruct(t *testing.T) {
+	type Inner struct {
+		Host string `env:"HOST"`
+	}
+
+	type Config struct {
+		Inner
+		Database Inner `envPrefix:"DB_"`
+		Cache    Inner
+		Tagged   Inner  `env:"TAGGED"`
+		private  Inner  `envPrefix:"PRIVATE_"`
+		Name     string `envPrefix:"NAME_"`
+	}
+
+	expected := map[string]bool{
+		"Inner":    true,
+		"Database": true,
+		"Cache":    false,
+		"Tagged":   false,
+		"private":  false,
+		"Name":     false,
+	}
+
+	typ := reflect.TypeFor[Config]()
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if got := IsNestedStruct(field); got != expected[field.Name] {
+			t.Errorf("Expected IsNestedStruct(%s) to be %v, got %v", field.Name, expected[field.Name], got)
+		}
+	}
+}
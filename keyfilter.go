@@ -0,0 +1,126 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"strings"
+)
+
+var (
+	errInvalidKeyPattern = errors.New("invalid key pattern")
+)
+
+// filterKeys returns envMap without the keys rejected by the allow and deny lists.
+// envMap is returned unchanged when no lists are configured; otherwise a filtered
+// copy is returned so the caller's map is never modified.
+func (config options) filterKeys(envMap map[string]string) (map[string]string, error) {
+	if len(config.allowKeys) == 0 && len(config.denyKeys) == 0 {
+		return envMap, nil
+	}
+
+	filtered := make(map[string]string, len(envMap))
+	for key, value := range envMap {
+		allowed, err := config.isKeyAllowed(key)
+		if err != nil {
+			return nil, err
+		}
+
+		if allowed {
+			filtered[key] = value
+		}
+	}
+
+	return filtered, nil
+}
+
+// isKeyAllowed reports whether key passes the deny list and, when one is set, the allow list.
+func (config options) isKeyAllowed(key string) (bool, error) {
+	denied, err := matchesAnyKey(config.denyKeys, key)
+	if err != nil || denied {
+		return false, err
+	}
+
+	if len(config.allowKeys) == 0 {
+		return true, nil
+	}
+
+	return matchesAnyKey(config.allowKeys, key)
+}
+
+// matchesAnyKey reports whether key equals or glob-matches one of patterns.
+func matchesAnyKey(patterns []string, key string) (bool, error) {
+	for _, pattern := range patterns {
+		matched, err := path.Match(pattern, key)
+		if err != nil {
+			return false, fmt.Errorf("%w %q: %w", errInvalidKeyPattern, pattern, err)
+		}
+
+		if matched {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// applyProfile returns envMap with the keys of the [WithProfilePrefix] profile also
+// available without their prefix, taking precedence over unprefixed keys of the same
+// name. envMap is returned unchanged without a profile; otherwise a copy is returned.
+// sources maps each unprefixed name to the key it was copied from, for [originalKey].
+func (config options) applyProfile(envMap map[string]string) (map[string]string, map[string]string) {
+	if config.profilePrefix == "" {
+		return envMap, nil
+	}
+
+	profiled := maps.Clone(envMap)
+	sources := make(map[string]string)
+
+	for key, value := range envMap {
+		if name, ok := strings.CutPrefix(key, config.profilePrefix); ok && name != "" {
+			profiled[name] = value
+			sources[name] = key
+		}
+	}
+
+	return profiled, sources
+}
+
+// originalKey returns the key of the env map given to the load that key was copied
+// from by [options.applyProfile] or [options.translateKeys], or key itself.
+func originalKey(sources map[string]string, key string) string {
+	if source, ok := sources[key]; ok {
+		return source
+	}
+
+	return key
+}
+
+// translateKeys returns envMap with the values of the external names of [WithKeyTranslation]
+// also available under their struct names. Struct names set directly win.
+// envMap is returned unchanged without translations; otherwise a copy is returned.
+// sources, as returned by [options.applyProfile], is extended with the key each
+// translated name was copied from.
+func (config options) translateKeys(envMap, sources map[string]string) (map[string]string, map[string]string) {
+	if len(config.keyTranslations) == 0 {
+		return envMap, sources
+	}
+
+	translated := maps.Clone(envMap)
+	translatedSources := maps.Clone(sources)
+
+	if translatedSources == nil {
+		translatedSources = make(map[string]string)
+	}
+
+	for external, name := range config.keyTranslations {
+		value, ok := envMap[external]
+		if _, direct := envMap[name]; ok && !direct {
+			translated[name] = value
+			translatedSources[name] = originalKey(sources, external)
+		}
+	}
+
+	return translated, translatedSources
+}
@@ -0,0 +1,165 @@
+package envload
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_WithAllowDenyKeys(t *testing.T) {
+	type Config struct {
+		Name    string `env:"APP_NAME" default:"fallback"`
+		Port    int    `env:"APP_PORT"`
+		Path    string `env:"PATH"`
+		Preload string `env:"LD_PRELOAD"`
+	}
+
+	envMap := map[string]string{
+		"APP_NAME":   "service",
+		"APP_PORT":   "8080",
+		"PATH":       "/usr/bin",
+		"LD_PRELOAD": "/tmp/evil.so",
+	}
+
+	t.Run("allow list", func(t *testing.T) {
+		var cfg Config
+		if err := Parse(envMap, &cfg, WithAllowKeys("APP_*")); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if cfg.Name != "service" || cfg.Port != 8080 {
+			t.Errorf("Expected APP_* keys to load, got %+v", cfg)
+		}
+
+		if cfg.Path != "" || cfg.Preload != "" {
+			t.Errorf("Expected other keys to be unset, got %+v", cfg)
+		}
+	})
+
+	t.Run("deny list", func(t *testing.T) {
+		var cfg Config
+		if err := Parse(envMap, &cfg, WithDenyKeys("PATH", "LD_*")); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if cfg.Path != "" || cfg.Preload != "" {
+			t.Errorf("Expected denied keys to be unset, got %+v", cfg)
+		}
+
+		if cfg.Name != "service" {
+			t.Errorf("Expected Name=service, got %q", cfg.Name)
+		}
+	})
+
+	t.Run("deny wins over allow and defaults apply", func(t *testing.T) {
+		var cfg Config
+		if err := Parse(envMap, &cfg, WithAllowKeys("APP_*"), WithDenyKeys("APP_NAME")); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if cfg.Name != "fallback" || cfg.Port != 8080 {
+			t.Errorf("Expected Name=fallback and Port=8080, got %+v", cfg)
+		}
+	})
+
+	t.Run("denied required key is missing", func(t *testing.T) {
+		type Required struct {
+			Path string `env:"PATH" required:"true"`
+		}
+
+		var cfg Required
+
+		err := Parse(envMap, &cfg, WithDenyKeys("PATH"))
+		if !errors.Is(err, errMissingRequiredField) {
+			t.Errorf("Expected errMissingRequiredField, got %v", err)
+		}
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		var cfg Config
+
+		err := Parse(envMap, &cfg, WithDenyKeys("APP_["))
+		if !errors.Is(err, errInvalidKeyPattern) {
+			t.Errorf("Expected errInvalidKeyPattern, got %v", err)
+		}
+	})
+
+	t.Run("caller map untouched", func(t *testing.T) {
+		var cfg Config
+		if err := Parse(envMap, &cfg, WithDenyKeys("*")); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(envMap) != 4 {
+			t.Errorf("Expected env map to keep 4 keys, got %d", len(envMap))
+		}
+	})
+}
+
+func Test_WithProfilePrefix(t *testing.T) {
+	type Config struct {
+		DatabaseURL string `env:"DB_URL"`
+		LogLevel    string `env:"LOG_LEVEL" default:"info"`
+		Region      string `env:"REGION"`
+	}
+
+	envMap := map[string]string{
+		"PROD_DB_URL":    "postgres://prod",
+		"DEV_DB_URL":     "postgres://dev",
+		"DEV_LOG_LEVEL":  "debug",
+		"DB_URL":         "postgres://shared",
+		"REGION":         "eu-west-1",
+		"PROD_LOG_LEVEL": "warn",
+	}
+
+	var prod Config
+	if err := Parse(envMap, &prod, WithProfilePrefix("PROD_")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if prod != (Config{DatabaseURL: "postgres://prod", LogLevel: "warn", Region: "eu-west-1"}) {
+		t.Errorf("Unexpected PROD_ profile: %+v", prod)
+	}
+
+	var dev Config
+	if err := Parse(envMap, &dev, WithProfilePrefix("DEV_"), WithAllowKeys("DB_URL", "LOG_LEVEL")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if dev != (Config{DatabaseURL: "postgres://dev", LogLevel: "debug"}) {
+		t.Errorf("Expected DEV_ profile with allow list on stripped names, got %+v", dev)
+	}
+
+	if envMap["DB_URL"] != "postgres://shared" {
+		t.Errorf("Expected the caller's map to be left unchanged, got %v", envMap)
+	}
+}
+
+func Test_WithKeyTranslation(t *testing.T) {
+	type Config struct {
+		DatabaseURL string `env:"DATABASE_URL"`
+		RedisAddr   string `env:"REDIS_ADDR"`
+		Port        int    `env:"PORT"`
+	}
+
+	envMap := map[string]string{
+		"PROD_POSTGRES_URL": "postgres://prod",
+		"REDIS_HOST_PORT":   "redis:6379",
+		"HTTP_PORT":         "9090",
+		"PORT":              "8080",
+	}
+
+	var cfg Config
+
+	err := Parse(envMap, &cfg,
+		WithProfilePrefix("PROD_"),
+		WithKeyTranslation(map[string]string{"POSTGRES_URL": "DATABASE_URL", "HTTP_PORT": "PORT"}),
+		WithKeyTranslation(map[string]string{"REDIS_HOST_PORT": "REDIS_ADDR"}),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg != (Config{DatabaseURL: "postgres://prod", RedisAddr: "redis:6379", Port: 8080}) {
+		t.Errorf("Expected translated keys with direct names winning, got %+v", cfg)
+	}
+}
@@ -0,0 +1,35 @@
+package envload
+
+import (
+	"strings"
+)
+
+// LabelMap turns the OCI image labels starting with prefix into the env map form
+// accepted by [Parse], so build pipelines can bake non-secret defaults (often from
+// build args) into images. The prefix is stripped and the rest canonicalized with
+// [CanonicalKey]: with prefix "com.acme.config.", the label "com.acme.config.db-host"
+// becomes DB_HOST. Labels without the prefix are ignored.
+//
+// labels is the Labels object of the image config, as printed by
+// `docker inspect --format '{{json .Config.Labels}}'`. Copy the runtime environment
+// over the result so variables set on the container override the baked defaults:
+//
+//	envMap := envload.LabelMap(labels, "com.acme.config.")
+//	maps.Copy(envMap, runtimeEnv)
+//	err := envload.Parse(envMap, &cfg)
+func LabelMap(labels map[string]string, prefix string) map[string]string {
+	envMap := make(map[string]string)
+
+	for label, value := range labels {
+		name, ok := strings.CutPrefix(label, prefix)
+		if !ok {
+			continue
+		}
+
+		if key := CanonicalKey(name); key != "" {
+			envMap[key] = value
+		}
+	}
+
+	return envMap
+}
@@ -0,0 +1,43 @@
+package envload
+
+import (
+	"maps"
+	"testing"
+)
+
+func Test_LabelMap(t *testing.T) {
+	labels := map[string]string{
+		"com.acme.config.db-host":           "db.internal",
+		"com.acme.config.http.port":         "8080",
+		"com.acme.config.":                  "ignored",
+		"org.opencontainers.image.version":  "1.4.2",
+		"org.opencontainers.image.revision": "abc123",
+		"com.acme.config.feature flags":     "search",
+	}
+
+	got := LabelMap(labels, "com.acme.config.")
+	expected := map[string]string{
+		"DB_HOST":       "db.internal",
+		"HTTP_PORT":     "8080",
+		"FEATURE_FLAGS": "search",
+	}
+
+	if !maps.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	maps.Copy(got, map[string]string{"HTTP_PORT": "9090"})
+
+	var cfg struct {
+		Host string `env:"DB_HOST"`
+		Port int    `env:"HTTP_PORT"`
+	}
+
+	if err := Parse(got, &cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.Host != "db.internal" || cfg.Port != 9090 {
+		t.Errorf("Expected label default with runtime override, got %+v", cfg)
+	}
+}
@@ -0,0 +1,168 @@
+package envload
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/go-fynx/envload/internal/envfield"
+)
+
+type (
+	// Lazy holds a field whose raw value is captured while populating the struct
+	// but only converted to T on the first call to Get.
+	// Use it for settings that are expensive to decode and rarely needed.
+	//
+	// Example:
+	//
+	//	type Config struct {
+	//		Certificates envload.Lazy[[]string] `env:"CERTIFICATES"`
+	//	}
+	Lazy[T any] struct {
+		cell *lazyCell[T]
+	}
+
+	lazyCell[T any] struct {
+		once     sync.Once
+		field    reflect.StructField
+		rawValue string
+		value    T
+		err      error
+	}
+
+	// lazyValue is implemented by [Lazy] so populateStruct can defer conversion.
+	lazyValue interface {
+		setLazy(field reflect.StructField, rawValue string)
+	}
+
+	// lazyRawValue is implemented by [Lazy] so [Equal] and [Hash] compare the captured
+	// raw value rather than whether it has been converted yet.
+	lazyRawValue interface {
+		lazyRaw() string
+	}
+
+	// lazyZeroer is implemented by [*Lazy] so [ZeroSecrets] can drop captured secrets.
+	lazyZeroer interface {
+		zeroLazy()
+	}
+
+	// lazyElement is implemented by [Lazy] to report the type it converts to.
+	lazyElement interface {
+		lazyValueType() reflect.Type
+	}
+)
+
+var (
+	// [lazyElementType] is used to detect [Lazy] types in [lazyValueType].
+	lazyElementType = reflect.TypeFor[lazyElement]()
+)
+
+func init() {
+	envfield.LazyValueType = lazyValueType
+	envfield.HasParser = hasParser
+}
+
+// Get converts the captured raw value on first use and returns the cached result afterwards.
+// The field's `normalize`, `unique` and `validate` tags are applied to the converted value,
+// and a failing check is returned as a [*FieldError] with the zero value of T.
+// A Lazy whose variable was not set returns the zero value of T and no error.
+func (lazy Lazy[T]) Get() (T, error) {
+	if lazy.cell == nil {
+		var zero T
+		return zero, nil
+	}
+
+	lazy.cell.once.Do(lazy.cell.resolve)
+
+	return lazy.cell.value, lazy.cell.err
+}
+
+// setLazy stores the raw value for later conversion.
+func (lazy *Lazy[T]) setLazy(field reflect.StructField, rawValue string) {
+	lazy.cell = &lazyCell[T]{field: field, rawValue: rawValue}
+}
+
+// lazyRaw returns the captured raw value, or "" when the variable was not set.
+func (lazy Lazy[T]) lazyRaw() string {
+	if lazy.cell == nil {
+		return ""
+	}
+
+	return lazy.cell.rawValue
+}
+
+// lazyValueType returns T.
+func (Lazy[T]) lazyValueType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// lazyValueType returns T when typ is a Lazy[T].
+func lazyValueType(typ reflect.Type) (reflect.Type, bool) {
+	if typ.Kind() != reflect.Struct || !typ.Implements(lazyElementType) {
+		return nil, false
+	}
+
+	return reflect.Zero(typ).Interface().(lazyElement).lazyValueType(), true //nolint:forcetypeassert // note: checked with Implements.
+}
+
+// zeroLazy clears the captured raw value and any converted value, then detaches the cell.
+func (lazy *Lazy[T]) zeroLazy() {
+	if lazy.cell == nil {
+		return
+	}
+
+	var zero T
+
+	lazy.cell.rawValue = ""
+	lazy.cell.value = zero
+	lazy.cell = nil
+}
+
+// resolve converts rawValue into value using the regular field converters, then applies
+// the `normalize`, `unique` and `validate` tags the way loading does for other fields.
+func (cell *lazyCell[T]) resolve() {
+	resolver := fieldResolver{
+		field: reflect.StructField{
+			Name: cell.field.Name,
+			Type: reflect.TypeFor[T](),
+			Tag:  cell.field.Tag,
+		},
+		value:    reflect.ValueOf(&cell.value).Elem(),
+		envKey:   cell.field.Tag.Get("env"),
+		rawValue: cell.rawValue,
+	}
+
+	cell.err = resolver.convertLazy()
+	if cell.err != nil {
+		var zero T
+		cell.value = zero
+	}
+}
+
+// convertLazy sets the value of a resolver built by [lazyCell.resolve] and checks it.
+func (resolver *fieldResolver) convertLazy() error {
+	if err := resolver.setValue(); err != nil {
+		return resolver.fieldError(codeInvalid, err)
+	}
+
+	if err := resolver.normalize(); err != nil {
+		return err
+	}
+
+	if resolver.isUnique() {
+		if err := resolver.checkUnique(); err != nil {
+			return resolver.fieldError(codeUnique, err)
+		}
+	}
+
+	if err := resolver.validate(); err != nil {
+		return resolver.fieldError(codeValidate, err)
+	}
+
+	return nil
+}
+
+// isLazyField reports whether field is a [Lazy] field, converted and checked on first Get.
+func isLazyField(field reflect.StructField) bool {
+	_, ok := lazyValueType(field.Type)
+	return ok
+}
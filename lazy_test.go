@@ -0,0 +1,126 @@
+package envload
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-fynx/envload/internal/envfield"
+)
+
+func Test_Lazy_FieldDecoding(t *testing.T) {
+	t.Run("value is converted on first Get", func(t *testing.T) {
+		envMap := map[string]string{
+			"LAZY_PORTS":   "8080,9090",
+			"LAZY_TIMEOUT": "5s",
+		}
+
+		var config struct {
+			Ports   Lazy[[]int]         `env:"LAZY_PORTS"`
+			Timeout Lazy[time.Duration] `env:"LAZY_TIMEOUT"`
+			Name    Lazy[string]        `env:"LAZY_NAME" default:"fallback"`
+		}
+
+		if err := populateStruct(envMap, &config); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		ports, err := config.Ports.Get()
+		if err != nil || len(ports) != 2 || ports[0] != 8080 || ports[1] != 9090 {
+			t.Errorf("Expected [8080 9090], got %v (err: %v)", ports, err)
+		}
+
+		timeout, err := config.Timeout.Get()
+		if err != nil || timeout != 5*time.Second {
+			t.Errorf("Expected 5s, got %v (err: %v)", timeout, err)
+		}
+
+		name, err := config.Name.Get()
+		if err != nil || name != "fallback" {
+			t.Errorf("Expected 'fallback', got '%s' (err: %v)", name, err)
+		}
+	})
+
+	t.Run("conversion error is reported by Get", func(t *testing.T) {
+		envMap := map[string]string{"LAZY_INT": "abc"}
+
+		var config struct {
+			Value Lazy[int] `env:"LAZY_INT"`
+		}
+
+		if err := populateStruct(envMap, &config); err != nil {
+			t.Fatalf("Expected populateStruct to defer conversion, got: %v", err)
+		}
+
+		_, err := config.Value.Get()
+		if err == nil || !strings.Contains(err.Error(), "invalid int for field 'Value'") {
+			t.Errorf("Expected invalid int error, got %v", err)
+		}
+	})
+
+	t.Run("unset field returns zero value", func(t *testing.T) {
+		var config struct {
+			Value Lazy[int] `env:"LAZY_MISSING"`
+		}
+
+		if err := populateStruct(map[string]string{}, &config); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		value, err := config.Value.Get()
+		if err != nil || value != 0 {
+			t.Errorf("Expected 0 and no error, got %d (err: %v)", value, err)
+		}
+	})
+}
+
+func Test_Lazy_Checks(t *testing.T) {
+	envMap := map[string]string{
+		"LAZY_HOST":  "  API.Example.com ",
+		"LAZY_ID":    "not-a-uuid",
+		"LAZY_HOSTS": "a,b,a",
+	}
+
+	var config struct {
+		Host  Lazy[string]   `env:"LAZY_HOST" normalize:"trim,lower" validate:"hostname"`
+		ID    Lazy[string]   `env:"LAZY_ID" validate:"uuid"`
+		Hosts Lazy[[]string] `env:"LAZY_HOSTS" unique:"true"`
+	}
+
+	if err := populateStruct(envMap, &config); err != nil {
+		t.Fatalf("Expected populateStruct to defer checks, got: %v", err)
+	}
+
+	host, err := config.Host.Get()
+	if err != nil || host != "api.example.com" {
+		t.Errorf("Expected 'api.example.com', got '%s' (err: %v)", host, err)
+	}
+
+	var fieldErr *FieldError
+
+	id, err := config.ID.Get()
+	if !errors.As(err, &fieldErr) || fieldErr.Code != codeValidate || fieldErr.Rule != "uuid" || id != "" {
+		t.Errorf("Expected a uuid validation error and no value, got '%s' (err: %v)", id, err)
+	}
+
+	hosts, err := config.Hosts.Get()
+	if !errors.As(err, &fieldErr) || fieldErr.Code != codeUnique || hosts != nil {
+		t.Errorf("Expected a unique error and no value, got %v (err: %v)", hosts, err)
+	}
+}
+
+func Test_lazyValueType(t *testing.T) {
+	if typ, ok := lazyValueType(reflect.TypeFor[Lazy[[]int]]()); !ok || typ != reflect.TypeFor[[]int]() {
+		t.Errorf("Expected []int, got %v, %v", typ, ok)
+	}
+
+	if _, ok := lazyValueType(reflect.TypeFor[LogLevel]()); ok {
+		t.Error("Expected LogLevel not to be lazy")
+	}
+
+	if typ, ok := envfield.LazyValueType(reflect.TypeFor[Lazy[string]]()); !ok || typ != reflect.TypeFor[string]() {
+		t.Errorf("Expected the envfield hook to be set, got %v, %v", typ, ok)
+	}
+}
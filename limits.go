@@ -0,0 +1,77 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+)
+
+var (
+	errLimitExceeded = errors.New("env file limit exceeded")
+)
+
+// readLimited reads filePath, failing without reading further once the content
+// exceeds maxFileSize bytes. Zero or a negative maxFileSize reads the whole file.
+func readLimited(filePath string, maxFileSize int64) ([]byte, error) {
+	if maxFileSize <= 0 {
+		return os.ReadFile(filePath)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return readAllLimited(filePath, file, maxFileSize)
+}
+
+// readAllLimited reads reader, the content of name, failing once it exceeds maxFileSize
+// bytes. Zero or a negative maxFileSize reads everything.
+func readAllLimited(name string, reader io.Reader, maxFileSize int64) ([]byte, error) {
+	if maxFileSize <= 0 {
+		return io.ReadAll(reader)
+	}
+
+	// Read one byte past the limit so an oversized file is detected even when
+	// its size cannot be known up front (pipes, /proc files).
+	content, err := io.ReadAll(io.LimitReader(reader, maxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(content)) > maxFileSize {
+		return nil, fmt.Errorf("%w: %s is larger than %d bytes", errLimitExceeded, name, maxFileSize)
+	}
+
+	return content, nil
+}
+
+// checkEnvLimits validates the key count and value lengths of a parsed env file.
+func checkEnvLimits(envMap map[string]string, config options) error {
+	if config.maxKeys > 0 && len(envMap) > config.maxKeys {
+		return fmt.Errorf("%w: %d keys defined, at most %d allowed", errLimitExceeded, len(envMap), config.maxKeys)
+	}
+
+	if config.maxValueLength <= 0 {
+		return nil
+	}
+
+	// Sorted so the reported key is stable across runs.
+	keys := make([]string, 0, len(envMap))
+	for key := range envMap {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		if len(envMap[key]) > config.maxValueLength {
+			return fmt.Errorf("%w: value of %s is %d bytes long, at most %d allowed",
+				errLimitExceeded, key, len(envMap[key]), config.maxValueLength)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package envload
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_LoadAndParse_Limits(t *testing.T) {
+	type Config struct {
+		Name string `env:"APP_NAME" default:"fallback"`
+	}
+
+	dir := writeEnvFiles(t, map[string]string{
+		"app.env":   "APP_NAME=service\nPORT=8080\n#include big.env\n",
+		"big.env":   "BLOB=" + strings.Repeat("x", 100) + "\n",
+		"small.env": "APP_NAME=service\n",
+	})
+
+	t.Run("within limits", func(t *testing.T) {
+		var cfg Config
+
+		err := LoadAndParse(filepath.Join(dir, "app.env"), &cfg,
+			WithMaxFileSize(1024), WithMaxKeys(3), WithMaxValueLength(100))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if cfg.Name != "service" {
+			t.Errorf("Expected Name=service, got %q", cfg.Name)
+		}
+	})
+
+	tests := map[string]Option{
+		"file size":    WithMaxFileSize(64),
+		"key count":    WithMaxKeys(2),
+		"value length": WithMaxValueLength(99),
+	}
+
+	for name, opt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cfg Config
+
+			err := LoadAndParse(filepath.Join(dir, "app.env"), &cfg, opt)
+			if !errors.Is(err, errLimitExceeded) {
+				t.Errorf("Expected errLimitExceeded, got %v", err)
+			}
+		})
+	}
+
+	t.Run("missing file still degrades to defaults", func(t *testing.T) {
+		var cfg Config
+
+		err := LoadAndParse(filepath.Join(dir, "missing.env"), &cfg, WithMaxFileSize(64))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if cfg.Name != "fallback" {
+			t.Errorf("Expected Name=fallback, got %q", cfg.Name)
+		}
+	})
+
+	t.Run("limits exactly reached", func(t *testing.T) {
+		path := filepath.Join(dir, "small.env")
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("failed to stat file: %v", err)
+		}
+
+		var cfg Config
+		if err := LoadAndParse(path, &cfg, WithMaxFileSize(info.Size()), WithMaxKeys(1), WithMaxValueLength(7)); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
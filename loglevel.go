@@ -0,0 +1,101 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+type (
+	// LogLevel is a log severity parsed from "debug", "info", "warn" (or "warning") and "error",
+	// case-insensitively. Its numeric values match zapcore.Level, and [LogLevel.Slog]
+	// converts it to slog.Level.
+	//
+	// Example:
+	//
+	//	type Config struct {
+	//		LogLevel envload.LogLevel `env:"LOG_LEVEL" default:"info"`
+	//	}
+	//
+	//	slog.SetLogLoggerLevel(cfg.LogLevel.Slog())
+	//	zapLevel := zapcore.Level(cfg.LogLevel.Zap())
+	LogLevel int8
+)
+
+const (
+	// LogLevelDebug is the most verbose level.
+	LogLevelDebug LogLevel = iota - 1
+	// LogLevelInfo is the default level.
+	LogLevelInfo
+	// LogLevelWarn is for conditions worth attention.
+	LogLevelWarn
+	// LogLevelError is for failures.
+	LogLevelError
+)
+
+var (
+	errUnknownLogLevel = errors.New("unknown log level")
+
+	// [logLevelNames] maps accepted names to levels.
+	logLevelNames = map[string]LogLevel{
+		"debug":   LogLevelDebug,
+		"info":    LogLevelInfo,
+		"warn":    LogLevelWarn,
+		"warning": LogLevelWarn,
+		"error":   LogLevelError,
+	}
+)
+
+// String returns the lower-case level name.
+func (level LogLevel) String() string {
+	switch level {
+	case LogLevelDebug:
+		return "debug"
+	case LogLevelInfo:
+		return "info"
+	case LogLevelWarn:
+		return "warn"
+	case LogLevelError:
+		return "error"
+	default:
+		return fmt.Sprintf("LogLevel(%d)", int8(level))
+	}
+}
+
+// Slog converts the level to the matching slog.Level.
+func (level LogLevel) Slog() slog.Level {
+	switch level {
+	case LogLevelDebug:
+		return slog.LevelDebug
+	case LogLevelWarn:
+		return slog.LevelWarn
+	case LogLevelError:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// Zap returns the level as a zapcore.Level value; convert it with zapcore.Level(level.Zap()).
+// envload does not import zap, the numeric values are identical by design.
+func (level LogLevel) Zap() int8 {
+	return int8(level)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (level LogLevel) MarshalText() ([]byte, error) {
+	return []byte(level.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (level *LogLevel) UnmarshalText(text []byte) error {
+	parsed, ok := logLevelNames[strings.ToLower(strings.TrimSpace(string(text)))]
+	if !ok {
+		return fmt.Errorf("%w %q (expected debug, info, warn or error)", errUnknownLogLevel, text)
+	}
+
+	*level = parsed
+
+	return nil
+}
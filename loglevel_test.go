@@ -0,0 +1,77 @@
+package envload
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func Test_LogLevel_FieldDecoding(t *testing.T) {
+	t.Run("names and defaults", func(t *testing.T) {
+		envMap := map[string]string{
+			"LOG_LEVEL":    "WARNING",
+			"AUDIT_LEVELS": "debug, error",
+		}
+
+		var config struct {
+			LogLevel    LogLevel   `env:"LOG_LEVEL"`
+			AccessLevel LogLevel   `env:"ACCESS_LEVEL" default:"debug"`
+			AuditLevels []LogLevel `env:"AUDIT_LEVELS"`
+		}
+
+		if err := populateStruct(envMap, &config); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		tests := Tests[LogLevel]{
+			{"case-insensitive alias", config.LogLevel, LogLevelWarn},
+			{"default", config.AccessLevel, LogLevelDebug},
+		}
+
+		tests.runTests(t)
+
+		if len(config.AuditLevels) != 2 || config.AuditLevels[1] != LogLevelError {
+			t.Errorf("Expected [debug error], got %v", config.AuditLevels)
+		}
+	})
+
+	t.Run("unknown level", func(t *testing.T) {
+		var config struct {
+			LogLevel LogLevel `env:"LOG_LEVEL"`
+		}
+
+		err := populateStruct(map[string]string{"LOG_LEVEL": "verbose"}, &config)
+		if !errors.Is(err, errUnknownLogLevel) || !strings.Contains(err.Error(), "invalid envload.LogLevel for field 'LogLevel'") {
+			t.Errorf("Expected unknown log level error, got %v", err)
+		}
+	})
+}
+
+func Test_LogLevel_Conversions(t *testing.T) {
+	slogTests := Tests[slog.Level]{
+		{"debug", LogLevelDebug.Slog(), slog.LevelDebug},
+		{"info", LogLevelInfo.Slog(), slog.LevelInfo},
+		{"warn", LogLevelWarn.Slog(), slog.LevelWarn},
+		{"error", LogLevelError.Slog(), slog.LevelError},
+	}
+
+	slogTests.runTests(t)
+
+	// Values mirror zapcore.DebugLevel (-1) through zapcore.ErrorLevel (2).
+	zapTests := Tests[int8]{
+		{"zap debug", LogLevelDebug.Zap(), -1},
+		{"zap info", LogLevelInfo.Zap(), 0},
+		{"zap warn", LogLevelWarn.Zap(), 1},
+		{"zap error", LogLevelError.Zap(), 2},
+	}
+
+	zapTests.runTests(t)
+
+	nameTests := Tests[string]{
+		{"string", LogLevelWarn.String(), "warn"},
+		{"unknown string", LogLevel(9).String(), "LogLevel(9)"},
+	}
+
+	nameTests.runTests(t)
+}
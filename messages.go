@@ -0,0 +1,69 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+type (
+	// messageCatalog holds the parsed templates of [WithMessages] by key.
+	messageCatalog map[string]*template.Template
+)
+
+var (
+	errInvalidMessageTemplate = errors.New("invalid message template")
+)
+
+// compileMessages parses the templates of messages so broken catalogs fail every load,
+// not only the first one that hits an error.
+func compileMessages(messages map[string]string) (messageCatalog, error) {
+	if len(messages) == 0 {
+		return nil, nil
+	}
+
+	catalog := make(messageCatalog, len(messages))
+
+	for key, text := range messages {
+		tmpl, err := template.New(key).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", errInvalidMessageTemplate, key, err)
+		}
+
+		catalog[key] = tmpl
+	}
+
+	return catalog, nil
+}
+
+// localize renders the message of the [FieldError] in err from the catalog, if it
+// has a template for its code. Errors without one are returned unchanged.
+func (catalog messageCatalog) localize(err error) error {
+	if len(catalog) == 0 || err == nil {
+		return err
+	}
+
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) {
+		return err
+	}
+
+	tmpl, ok := catalog[fieldErr.Code+"."+fieldErr.Rule]
+	if !ok {
+		tmpl, ok = catalog[fieldErr.Code]
+	}
+
+	if !ok {
+		return err
+	}
+
+	var message strings.Builder
+	if execErr := tmpl.Execute(&message, fieldErr); execErr != nil {
+		return errors.Join(err, fmt.Errorf("%w: %s: %w", errInvalidMessageTemplate, tmpl.Name(), execErr))
+	}
+
+	fieldErr.message = message.String()
+
+	return err
+}
@@ -0,0 +1,114 @@
+package envload
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_FieldError(t *testing.T) {
+	type Config struct {
+		Port    int      `env:"PORT" validate:"min=1024"`
+		Hosts   []string `env:"HOSTS" unique:"true"`
+		APIKey  string   `env:"API_KEY" required:"true"`
+		Workers int      `env:"WORKERS"`
+	}
+
+	tests := []struct {
+		name   string
+		envMap map[string]string
+		want   FieldError
+	}{
+		{"required", map[string]string{}, FieldError{Field: "APIKey", Env: "API_KEY", Code: "required"}},
+		{"invalid", map[string]string{"API_KEY": "k", "WORKERS": "many"}, FieldError{Field: "Workers", Env: "WORKERS", Code: "invalid"}},
+		{"unique", map[string]string{"API_KEY": "k", "HOSTS": "a,a"}, FieldError{Field: "Hosts", Env: "HOSTS", Code: "unique"}},
+		{"validate", map[string]string{"API_KEY": "k", "PORT": "80"}, FieldError{Field: "Port", Env: "PORT", Code: "validate", Rule: "min", Param: "1024"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cfg Config
+
+			err := Parse(test.envMap, &cfg)
+
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) {
+				t.Fatalf("Expected FieldError, got %v", err)
+			}
+
+			if fieldErr.Field != test.want.Field || fieldErr.Env != test.want.Env || fieldErr.Code != test.want.Code ||
+				fieldErr.Rule != test.want.Rule || fieldErr.Param != test.want.Param {
+				t.Errorf("Expected %+v, got %+v", test.want, *fieldErr)
+			}
+
+			if err.Error() != fieldErr.Err.Error() {
+				t.Errorf("Expected default message %q, got %q", fieldErr.Err.Error(), err.Error())
+			}
+		})
+	}
+}
+
+func Test_WithMessages(t *testing.T) {
+	type Config struct {
+		MinConns int    `env:"MIN_CONNS" default:"10"`
+		MaxConns int    `env:"MAX_CONNS" validate:"max=100,gtfield=MinConns"`
+		Token    string `env:"TOKEN" required:"true"`
+	}
+
+	messages := WithMessages(map[string]string{
+		"required":         "Bitte {{.Env}} setzen.",
+		"validate":         "{{.Env}} ist ungültig.",
+		"validate.max":     "{{.Env}} darf höchstens {{.Param}} sein.",
+		"validate.gtfield": "{{.Env}} muss größer als {{.Param}} sein.",
+	})
+
+	tests := []struct {
+		name    string
+		envMap  map[string]string
+		message string
+	}{
+		{"required", map[string]string{}, "Bitte TOKEN setzen."},
+		{"rule template", map[string]string{"TOKEN": "t", "MAX_CONNS": "500"}, "MAX_CONNS darf höchstens 100 sein."},
+		{"cross-field rule", map[string]string{"TOKEN": "t", "MAX_CONNS": "5"}, "MAX_CONNS muss größer als MinConns sein."},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cfg Config
+
+			err := Parse(test.envMap, &cfg, messages)
+			if err == nil || err.Error() != test.message {
+				t.Errorf("Expected %q, got %v", test.message, err)
+			}
+
+			if !errors.Is(err, errValidationFailed) && !errors.Is(err, errMissingRequiredField) {
+				t.Errorf("Expected localized error to keep its cause, got %v", err)
+			}
+		})
+	}
+
+	var cfg Config
+
+	err := Parse(map[string]string{"TOKEN": "t", "MAX_CONNS": "x"}, &cfg, messages)
+
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || err.Error() != fieldErr.Err.Error() {
+		t.Errorf("Expected codes without a template to keep the default message, got %v", err)
+	}
+}
+
+func Test_WithMessagesInvalidTemplate(t *testing.T) {
+	var cfg struct {
+		Port int `env:"PORT"`
+	}
+
+	if err := Parse(nil, &cfg, WithMessages(map[string]string{"required": "{{.Env"})); !errors.Is(err, errInvalidMessageTemplate) {
+		t.Errorf("Expected errInvalidMessageTemplate, got %v", err)
+	}
+
+	err := Parse(map[string]string{"PORT": "x"}, &cfg, WithMessages(map[string]string{"invalid": "{{.Unknown}}"}))
+
+	var fieldErr *FieldError
+	if !errors.Is(err, errInvalidMessageTemplate) || !errors.As(err, &fieldErr) {
+		t.Errorf("Expected the FieldError joined with errInvalidMessageTemplate, got %v", err)
+	}
+}
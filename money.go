@@ -0,0 +1,129 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Money is an exact monetary amount: Amount in minor units (e.g. cents) plus an
+	// ISO 4217 currency code. It is parsed from "19.99 USD" without going through float64.
+	//
+	// Example:
+	//
+	//	type Config struct {
+	//		Price envload.Money `env:"PRICE" default:"19.99 USD"`
+	//	}
+	//
+	//	cfg.Price.Amount   // 1999
+	//	cfg.Price.Currency // "USD"
+	Money struct {
+		Amount   int64
+		Currency string
+	}
+)
+
+const (
+	// [defaultMinorUnits] is the number of decimal places for currencies not listed in currencyMinorUnits.
+	defaultMinorUnits = 2
+	// [currencyCodeLength] is the length of an ISO 4217 code.
+	currencyCodeLength = 3
+)
+
+var (
+	errInvalidMoney = errors.New("invalid money")
+
+	// [currencyMinorUnits] lists ISO 4217 currencies whose minor unit is not two decimal places.
+	currencyMinorUnits = map[string]int{
+		"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+		"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+		"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	}
+)
+
+// ParseMoney parses "<decimal> <CODE>" (e.g. "19.99 USD", "-5 EUR", "1500 JPY").
+// The amount may not have more decimal places than the currency's minor unit.
+func ParseMoney(rawValue string) (Money, error) {
+	fields := strings.Fields(rawValue)
+	if len(fields) != 2 || len(fields[1]) != currencyCodeLength {
+		return Money{}, fmt.Errorf("%w %q: expected \"<amount> <CURRENCY>\"", errInvalidMoney, rawValue)
+	}
+
+	currency := strings.ToUpper(fields[1])
+	for _, char := range currency {
+		if char < 'A' || char > 'Z' {
+			return Money{}, fmt.Errorf("%w %q: currency must be three letters", errInvalidMoney, rawValue)
+		}
+	}
+
+	amount, err := parseMinorUnits(fields[0], CurrencyMinorUnits(currency))
+	if err != nil {
+		return Money{}, fmt.Errorf("%w %q: %w", errInvalidMoney, rawValue, err)
+	}
+
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// CurrencyMinorUnits returns the number of decimal places used by the currency.
+func CurrencyMinorUnits(currency string) int {
+	if units, ok := currencyMinorUnits[strings.ToUpper(currency)]; ok {
+		return units
+	}
+
+	return defaultMinorUnits
+}
+
+// parseMinorUnits converts a decimal string into an integer count of minor units.
+func parseMinorUnits(decimal string, minorUnits int) (int64, error) {
+	whole, fraction, _ := strings.Cut(decimal, ".")
+
+	if len(fraction) > minorUnits {
+		return 0, fmt.Errorf("at most %d decimal places allowed", minorUnits)
+	}
+
+	digits := whole + fraction + strings.Repeat("0", minorUnits-len(fraction))
+	if strings.ContainsAny(digits, "+eE_") || strings.Contains(fraction, "-") {
+		return 0, fmt.Errorf("amount %q is not a plain decimal number", decimal)
+	}
+
+	amount, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return amount, nil
+}
+
+// String formats the amount with the currency's decimal places, e.g. "19.99 USD".
+func (money Money) String() string {
+	minorUnits := CurrencyMinorUnits(money.Currency)
+	if minorUnits == 0 {
+		return fmt.Sprintf("%d %s", money.Amount, money.Currency)
+	}
+
+	scale := int64(math.Pow10(minorUnits))
+	sign := ""
+	amount := money.Amount
+
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/scale, minorUnits, amount%scale, money.Currency)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (money *Money) UnmarshalText(text []byte) error {
+	parsed, err := ParseMoney(string(text))
+	if err != nil {
+		return err
+	}
+
+	*money = parsed
+
+	return nil
+}
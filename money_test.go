@@ -0,0 +1,56 @@
+package envload
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_Money_FieldDecoding(t *testing.T) {
+	envMap := map[string]string{
+		"PRICE":    "19.99 USD",
+		"DISCOUNT": "-5 eur",
+		"FEE":      "1500 JPY",
+		"TAX":      "0.125 KWD",
+	}
+
+	var config struct {
+		Price    Money `env:"PRICE"`
+		Discount Money `env:"DISCOUNT"`
+		Fee      Money `env:"FEE"`
+		Tax      Money `env:"TAX"`
+		Shipping Money `env:"SHIPPING" default:"4.5 GBP"`
+	}
+
+	if err := populateStruct(envMap, &config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := Tests[Money]{
+		{"two decimal places", config.Price, Money{Amount: 1999, Currency: "USD"}},
+		{"negative whole amount", config.Discount, Money{Amount: -500, Currency: "EUR"}},
+		{"zero decimal currency", config.Fee, Money{Amount: 1500, Currency: "JPY"}},
+		{"three decimal currency", config.Tax, Money{Amount: 125, Currency: "KWD"}},
+		{"padded fraction", config.Shipping, Money{Amount: 450, Currency: "GBP"}},
+	}
+
+	tests.runTests(t)
+
+	formats := Tests[string]{
+		{"format usd", config.Price.String(), "19.99 USD"},
+		{"format negative", config.Discount.String(), "-5.00 EUR"},
+		{"format jpy", config.Fee.String(), "1500 JPY"},
+		{"format kwd", config.Tax.String(), "0.125 KWD"},
+	}
+
+	formats.runTests(t)
+}
+
+func Test_ParseMoney_Invalid(t *testing.T) {
+	for _, rawValue := range []string{"19.99", "USD 19.99", "19.999 USD", "1.5 JPY", "1e3 USD", "19.99 US1", "abc USD", "1.-5 USD"} {
+		t.Run(rawValue, func(t *testing.T) {
+			if _, err := ParseMoney(rawValue); !errors.Is(err, errInvalidMoney) {
+				t.Errorf("Expected errInvalidMoney for %q, got %v", rawValue, err)
+			}
+		})
+	}
+}
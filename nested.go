@@ -0,0 +1,167 @@
+package envload
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-fynx/envload/internal/envfield"
+)
+
+type (
+	// structField is a field of the target struct, or of a struct nested or embedded in it.
+	// Its Name is qualified with the enclosing nested fields (Database.Host), parent is the
+	// struct that name is promoted to (the target or a nested struct, never an embedded
+	// one), and prefix joins the `envPrefix` tags above it.
+	structField struct {
+		reflect.StructField
+
+		value  reflect.Value
+		parent reflect.Value
+		prefix string
+	}
+
+	// fieldLayout locates a [structField] within its struct type: index leads to the
+	// field and parent to the struct its name is promoted to (empty for the target).
+	// Exported string fields tagged with nothing but `env` and `default` are plain: they
+	// are set from envKey or defaultValue without any conversion or check.
+	fieldLayout struct {
+		field        reflect.StructField
+		index        []int
+		parent       []int
+		prefix       string
+		plain        bool
+		envKey       string
+		defaultValue string
+	}
+)
+
+var (
+	// [fieldLayouts] caches the []fieldLayout of each struct type loaded.
+	fieldLayouts sync.Map
+)
+
+// envKey returns the variable the field is read from: its `env` tag behind the prefix
+// of the enclosing structs, or "" for fields without one.
+func (field structField) envKey() string {
+	envKey := field.Tag.Get("env")
+	if envKey == "" || envKey == "-" {
+		return envKey
+	}
+
+	return field.prefix + envKey
+}
+
+// structFields returns the fields of the struct value in declaration order, with nested
+// and embedded structs replaced by their own fields, recursively. Fields of embedded
+// structs keep their promoted name, as in Go code.
+func structFields(value reflect.Value) []structField {
+	layouts := structLayout(value.Type())
+
+	fields := make([]structField, len(layouts))
+	for i := range layouts {
+		fields[i] = layouts[i].bind(value)
+	}
+
+	return fields
+}
+
+// structLayout returns the layout of the fields of the struct type typ, as listed by
+// [structFields]. Layouts are computed once per type, so populating a struct does not
+// allocate for them.
+func structLayout(typ reflect.Type) []fieldLayout {
+	if layouts, ok := fieldLayouts.Load(typ); ok {
+		return layouts.([]fieldLayout) //nolint:forcetypeassert // note: only layouts are stored.
+	}
+
+	layouts, _ := fieldLayouts.LoadOrStore(typ, appendFieldLayouts(nil, typ, nil, nil, "", ""))
+
+	return layouts.([]fieldLayout) //nolint:forcetypeassert // note: only layouts are stored.
+}
+
+// appendFieldLayouts appends the layouts of the fields of typ, found at index and
+// promoted to the struct at parent, qualifying their names with path and their env keys
+// with prefix.
+func appendFieldLayouts(layouts []fieldLayout, typ reflect.Type, index, parent []int, path, prefix string) []fieldLayout {
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		fieldIndex := append(slices.Clip(index), i)
+
+		field.Name = path + field.Name
+
+		if envfield.IsNestedStruct(field) {
+			nestedParent, nestedPath := fieldIndex, field.Name+"."
+			if field.Anonymous {
+				nestedParent, nestedPath = parent, path
+			}
+
+			layouts = appendFieldLayouts(layouts, field.Type, fieldIndex, nestedParent, nestedPath, prefix+field.Tag.Get("envPrefix"))
+
+			continue
+		}
+
+		layout := fieldLayout{field: field, index: fieldIndex, parent: parent, prefix: prefix}
+
+		envKey := field.Tag.Get("env")
+		if field.IsExported() && field.Type == stringType && envKey != "" && envKey != "-" && onlyTags(field.Tag, "env", "default") {
+			layout.plain = true
+			layout.envKey = prefix + envKey
+			layout.defaultValue = field.Tag.Get("default")
+		}
+
+		layouts = append(layouts, layout)
+	}
+
+	return layouts
+}
+
+// onlyTags reports whether tag holds no keys other than keys.
+func onlyTags(tag reflect.StructTag, keys ...string) bool {
+	rest := string(tag)
+
+	for {
+		rest = strings.TrimLeft(rest, " ")
+		if rest == "" {
+			return true
+		}
+
+		name, value, ok := strings.Cut(rest, ":")
+		if !ok || !slices.Contains(keys, name) {
+			return false
+		}
+
+		quoted, err := strconv.QuotedPrefix(value)
+		if err != nil {
+			return false
+		}
+
+		rest = value[len(quoted):]
+	}
+}
+
+// bind returns the field of the layout within the struct value.
+func (layout *fieldLayout) bind(value reflect.Value) structField {
+	return structField{
+		StructField: layout.field,
+		value:       value.FieldByIndex(layout.index),
+		parent:      value.FieldByIndex(layout.parent),
+		prefix:      layout.prefix,
+	}
+}
+
+// nestedStructs returns the structs nested in value with an `envPrefix` tag, inner
+// structs before the structs enclosing them.
+func nestedStructs(value reflect.Value) []reflect.Value {
+	var nested []reflect.Value
+
+	for i := range value.NumField() {
+		if envfield.IsNestedStruct(value.Type().Field(i)) {
+			nested = append(nested, nestedStructs(value.Field(i))...)
+			nested = append(nested, value.Field(i))
+		}
+	}
+
+	return nested
+}
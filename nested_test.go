@@ -0,0 +1,244 @@
+package envload
+
+import (
+	"errors"
+	"maps"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type (
+	nestedPool struct {
+		MinConns int `env:"MIN_CONNS" default:"2"`
+		MaxConns int `env:"MAX_CONNS" default:"10" validate:"gtfield=MinConns"`
+	}
+
+	nestedDatabase struct {
+		Host     string        `env:"HOST" required:"true"`
+		Port     int           `env:"PORT" default:"5432"`
+		Password string        `env:"PASSWORD" alias:"PASS" secret:"true"`
+		Timeout  time.Duration `env:"TIMEOUT"`
+		Pool     nestedPool    `envPrefix:"POOL_"`
+	}
+
+	nestedConfig struct {
+		Name     string         `env:"APP_NAME"`
+		Database nestedDatabase `envPrefix:"DB_"`
+		Replica  nestedDatabase `envPrefix:"REPLICA_"`
+	}
+)
+
+func Test_NestedStructs(t *testing.T) {
+	envMap := map[string]string{
+		"APP_NAME":          "orders",
+		"DB_HOST":           "db.internal",
+		"DB_PASS":           "hunter2",
+		"DB_TIMEOUT":        "5s",
+		"DB_POOL_MAX_CONNS": "20",
+		"REPLICA_HOST":      "replica.internal",
+		"REPLICA_PORT":      "6432",
+	}
+
+	var cfg nestedConfig
+	if err := Parse(envMap, &cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := nestedConfig{
+		Name: "orders",
+		Database: nestedDatabase{
+			Host: "db.internal", Port: 5432, Password: "hunter2", Timeout: 5 * time.Second,
+			Pool: nestedPool{MinConns: 2, MaxConns: 20},
+		},
+		Replica: nestedDatabase{
+			Host: "replica.internal", Port: 6432,
+			Pool: nestedPool{MinConns: 2, MaxConns: 10},
+		},
+	}
+
+	if cfg != expected {
+		t.Errorf("Expected %+v, got %+v", expected, cfg)
+	}
+
+	redacted := Redacted(&cfg)
+	if redacted["DB_PASSWORD"] != redactedPlaceholder || redacted["REPLICA_PORT"] != 6432 {
+		t.Errorf("Expected nested fields keyed by prefixed name, got %v", redacted)
+	}
+
+	changed := cfg
+	changed.Database.Pool.MaxConns = 30
+
+	if Equal(&cfg, &changed) || Hash(&cfg) == Hash(&changed) {
+		t.Error("Expected a change in a nested struct to be detected")
+	}
+}
+
+func Test_NestedStructErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		envMap map[string]string
+		field  string
+		env    string
+		code   string
+	}{
+		{"required", map[string]string{"DB_HOST": "db"}, "Replica.Host", "REPLICA_HOST", codeRequired},
+		{"invalid", map[string]string{"DB_HOST": "db", "REPLICA_HOST": "replica", "DB_PORT": "x"}, "Database.Port", "DB_PORT", codeInvalid},
+		{"cross field", map[string]string{"DB_HOST": "db", "REPLICA_HOST": "replica", "REPLICA_POOL_MAX_CONNS": "1"}, "Replica.Pool.MaxConns", "REPLICA_POOL_MAX_CONNS", codeValidate},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cfg nestedConfig
+
+			var fieldErr *FieldError
+			if err := Parse(test.envMap, &cfg); !errors.As(err, &fieldErr) {
+				t.Fatalf("Expected *FieldError, got %v", err)
+			}
+
+			if fieldErr.Field != test.field || fieldErr.Env != test.env || fieldErr.Code != test.code {
+				t.Errorf("Expected %s %s %s, got %s %s %s", test.field, test.env, test.code, fieldErr.Field, fieldErr.Env, fieldErr.Code)
+			}
+		})
+	}
+}
+
+func Test_NestedStructStrictTags(t *testing.T) {
+	targets := []any{
+		&struct {
+			Host string `env:"HOST" envPrefix:"DB_"`
+		}{},
+		&struct {
+			database nestedPool `envPrefix:"DB_"`
+		}{},
+	}
+
+	for _, target := range targets {
+		err := Parse(map[string]string{}, target, WithStrictTags())
+		if !errors.Is(err, errInvalidTagDefinition) || !strings.Contains(err.Error(), "tag=envPrefix") {
+			t.Errorf("Expected envPrefix definition error, got %v", err)
+		}
+	}
+}
+
+func Test_NestedStructValidator(t *testing.T) {
+	type window struct {
+		Start int `env:"START"`
+		End   int `env:"END"`
+	}
+
+	RegisterStructValidator(func(w *window) error {
+		if w.End < w.Start {
+			return errors.New("end before start")
+		}
+
+		return nil
+	})
+
+	var cfg struct {
+		Maintenance window `envPrefix:"MAINTENANCE_"`
+	}
+
+	err := Parse(map[string]string{"MAINTENANCE_START": "5", "MAINTENANCE_END": "1"}, &cfg)
+	if !errors.Is(err, errValidationFailed) {
+		t.Errorf("Expected validator of nested struct to run, got %v", err)
+	}
+}
+
+type loggingConfig struct {
+	Level string `env:"LOG_LEVEL" default:"info"`
+}
+
+func Test_EmbeddedStructs(t *testing.T) {
+	type Config struct {
+		HTTPServerConfig
+		loggingConfig `envPrefix:"APP_"`
+		Name          string `env:"NAME"`
+	}
+
+	envMap := map[string]string{"HTTP_ADDR": ":9090", "APP_LOG_LEVEL": "debug", "LOG_LEVEL": "ignored", "NAME": "orders"}
+
+	var cfg Config
+	if err := Parse(envMap, &cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.Addr != ":9090" || cfg.ReadTimeout != 15*time.Second || cfg.Level != "debug" || cfg.Name != "orders" {
+		t.Errorf("Unexpected config: %+v", cfg)
+	}
+
+	var fieldErr *FieldError
+	if err := Parse(map[string]string{"HTTP_READ_TIMEOUT": "soon"}, &cfg); !errors.As(err, &fieldErr) || fieldErr.Field != "ReadTimeout" {
+		t.Errorf("Expected FieldError for promoted field ReadTimeout, got %v", err)
+	}
+
+	if _, ok := Redacted(&cfg)["APP_LOG_LEVEL"]; !ok {
+		t.Errorf("Expected embedded fields in Redacted output, got %v", Redacted(&cfg))
+	}
+}
+
+func Test_EmbeddedStructWithEnvTag(t *testing.T) {
+	type Config struct {
+		DurationRange `env:"RETRY_BACKOFF"`
+	}
+
+	var cfg Config
+	if err := Parse(map[string]string{"RETRY_BACKOFF": "1s..5s"}, &cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.Min != time.Second || cfg.Max != 5*time.Second {
+		t.Errorf("Expected embedded type with env tag to be decoded as a whole, got %+v", cfg.DurationRange)
+	}
+}
+
+func Test_EmbeddedStructCrossField(t *testing.T) {
+	type limits struct {
+		MaxConns int `env:"MAX_CONNS" validate:"gtfield=MinConns"`
+	}
+
+	type Config struct {
+		limits
+		MinConns int `env:"MIN_CONNS" default:"2"`
+	}
+
+	var cfg Config
+	if err := Parse(map[string]string{"MAX_CONNS": "1"}, &cfg); !errors.Is(err, errValidationFailed) {
+		t.Errorf("Expected promoted field to be compared with its outer sibling, got %v", err)
+	}
+}
+
+func Test_structLayout_PlainFields(t *testing.T) {
+	type Config struct {
+		Name     string `env:"APP_NAME" default:"service"`
+		Host     string `env:"HOST" validate:"hostname"`
+		Password string `env:"PASSWORD" secret:"true"`
+		Port     int    `env:"PORT"`
+		Ignored  string `env:"-"`
+		Database struct {
+			Name string `env:"NAME"`
+		} `envPrefix:"DB_"`
+	}
+
+	plain := make(map[string]string)
+	for _, layout := range structLayout(reflect.TypeFor[Config]()) {
+		if layout.plain {
+			plain[layout.field.Name] = layout.envKey + "|" + layout.defaultValue
+		}
+	}
+
+	expected := map[string]string{"Name": "APP_NAME|service", "Database.Name": "DB_NAME|"}
+	if !maps.Equal(plain, expected) {
+		t.Errorf("Expected plain fields %v, got %v", expected, plain)
+	}
+
+	var cfg Config
+	if err := Parse(map[string]string{"DB_NAME": "orders", "APP_NAME": " "}, &cfg, WithWhitespaceAsEmpty(), WithEmptyAsUnset()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.Name != "service" || cfg.Database.Name != "orders" {
+		t.Errorf("Expected plain fields to follow the lookup rules, got %q and %q", cfg.Name, cfg.Database.Name)
+	}
+}
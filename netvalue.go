@@ -0,0 +1,57 @@
+package envload
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"reflect"
+)
+
+var (
+	// [urlType] and [ipNetType] are decoded by the built-in parsers of [parsers].
+	urlType   = reflect.TypeFor[url.URL]()
+	ipNetType = reflect.TypeFor[net.IPNet]()
+
+	errURLNotAbsolute = errors.New("missing scheme")
+	errURLMissingHost = errors.New("missing host")
+)
+
+// parseURL parses an absolute URL such as https://api.internal:8443/v1.
+// Endpoints are dialed, so a scheme is required, and a host too unless the URL is
+// opaque (mailto:ops@example.com) or a file URL.
+func parseURL(rawValue string) (url.URL, error) {
+	parsed, err := url.Parse(rawValue)
+	if err != nil {
+		return url.URL{}, err
+	}
+
+	switch {
+	case parsed.Scheme == "":
+		return url.URL{}, errURLNotAbsolute
+
+	case parsed.Host == "" && parsed.Opaque == "" && parsed.Scheme != "file":
+		return url.URL{}, errURLMissingHost
+	}
+
+	return *parsed, nil
+}
+
+// parseIPNet parses a CIDR block such as 10.0.0.0/8 into its network. A bare IP address
+// is accepted as a single-host network (/32 or /128), as allowlists often mix both.
+func parseIPNet(rawValue string) (net.IPNet, error) {
+	if ip := net.ParseIP(rawValue); ip != nil {
+		bits := 8 * net.IPv6len
+		if ipv4 := ip.To4(); ipv4 != nil {
+			ip, bits = ipv4, 8*net.IPv4len
+		}
+
+		return net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, network, err := net.ParseCIDR(rawValue)
+	if err != nil {
+		return net.IPNet{}, err
+	}
+
+	return *network, nil
+}
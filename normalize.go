@@ -0,0 +1,129 @@
+package envload
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type (
+	// normalizer rewrites a string value; param is the text after "=" in the rule.
+	normalizer struct {
+		apply     func(value, param string) string
+		needParam bool
+	}
+)
+
+var (
+	// [normalizers] holds the rules of the `normalize` tag by name.
+	normalizers = map[string]normalizer{
+		"trim":         {apply: func(value, _ string) string { return strings.TrimSpace(value) }},
+		"trimprefix":   {apply: strings.TrimPrefix, needParam: true},
+		"trimsuffix":   {apply: strings.TrimSuffix, needParam: true},
+		"lower":        {apply: func(value, _ string) string { return strings.ToLower(value) }},
+		"upper":        {apply: func(value, _ string) string { return strings.ToUpper(value) }},
+		"collapse":     {apply: func(value, _ string) string { return strings.Join(strings.Fields(value), " ") }},
+		"ensureprefix": {apply: ensurePrefix, needParam: true},
+		"ensuresuffix": {apply: ensureSuffix, needParam: true},
+	}
+)
+
+// normalize rewrites the populated string value, string slice elements or string map
+// values of the field with the rules of its `normalize` tag, in order, before the value
+// is validated. The rewritten strings are recorded so [fieldResolver.redactError] masks
+// them too.
+// Example: `normalize:"trim,trimsuffix=/"` on BASE_URL=" https://api/ " -> "https://api".
+func (resolver *fieldResolver) normalize() error {
+	rules, err := resolver.normalizeRules()
+	if err != nil || rules == nil {
+		return err
+	}
+
+	value, ok := indirectValue(resolver.value)
+	if !ok {
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Slice:
+		for i := range value.Len() {
+			resolver.normalized = append(resolver.normalized, applyNormalizers(value.Index(i), rules))
+		}
+
+	case reflect.Map:
+		iter := value.MapRange()
+		for iter.Next() {
+			entry := reflect.New(value.Type().Elem()).Elem()
+			entry.Set(iter.Value())
+			resolver.normalized = append(resolver.normalized, applyNormalizers(entry, rules))
+			value.SetMapIndex(iter.Key(), entry)
+		}
+
+	default:
+		resolver.normalized = append(resolver.normalized, applyNormalizers(value, rules))
+	}
+
+	return nil
+}
+
+// normalizeRules parses the `normalize` tag, reporting unknown rules, missing parameters
+// and fields that hold no strings.
+func (resolver *fieldResolver) normalizeRules() ([]validationRule, error) {
+	tag := resolver.field.Tag.Get("normalize")
+	if tag == "" {
+		return nil, nil
+	}
+
+	rules := parseValidateTag(tag)
+	for _, rule := range rules {
+		rewrite, ok := normalizers[rule.name]
+		if !ok {
+			return nil, resolver.tagError("normalize", fmt.Sprintf("unknown rule %q", rule.name))
+		}
+
+		if rewrite.needParam && rule.param == "" {
+			return nil, resolver.tagError("normalize", rule.name+" needs a parameter")
+		}
+	}
+
+	typ := indirectType(resolver.field.Type)
+	if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.String {
+		return nil, resolver.tagError("normalize", "only string fields, slices and maps of strings can be normalized")
+	}
+
+	return rules, nil
+}
+
+// applyNormalizers rewrites the string value with rules and returns the result.
+func applyNormalizers(value reflect.Value, rules []validationRule) string {
+	text := value.String()
+	for _, rule := range rules {
+		text = normalizers[rule.name].apply(text, rule.param)
+	}
+
+	value.SetString(text)
+
+	return text
+}
+
+// ensurePrefix adds prefix to value unless it starts with it already.
+func ensurePrefix(value, prefix string) string {
+	if strings.HasPrefix(value, prefix) {
+		return value
+	}
+
+	return prefix + value
+}
+
+// ensureSuffix adds suffix to value unless it ends with it already.
+func ensureSuffix(value, suffix string) string {
+	if strings.HasSuffix(value, suffix) {
+		return value
+	}
+
+	return value + suffix
+}
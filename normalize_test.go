@@ -0,0 +1,102 @@
+package envload
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test_Normalize(t *testing.T) {
+	type Config struct {
+		BaseURL  string            `env:"BASE_URL" normalize:"trim,trimsuffix=/"`
+		Path     *string           `env:"API_PATH" normalize:"ensureprefix=/,ensuresuffix=/"`
+		Region   string            `env:"REGION" normalize:"lower" validate:"hostname"`
+		Title    string            `env:"TITLE" normalize:"collapse"`
+		Hosts    []string          `env:"HOSTS" normalize:"lower,trimprefix=www."`
+		Labels   map[string]string `env:"LABELS" normalize:"upper"`
+		Homepage string            `derive:"{{.BaseURL}}/home/" normalize:"trimsuffix=/"`
+	}
+
+	envMap := map[string]string{
+		"BASE_URL": "  https://api.example.com/  ",
+		"API_PATH": "v1",
+		"REGION":   "EU-WEST-1",
+		"TITLE":    "  Order   Service ",
+		"HOSTS":    "WWW.a.com,b.com",
+		"LABELS":   "team:payments",
+	}
+
+	var cfg Config
+	if err := Parse(envMap, &cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.BaseURL != "https://api.example.com" || *cfg.Path != "/v1/" || cfg.Region != "eu-west-1" || cfg.Title != "Order Service" {
+		t.Errorf("Unexpected strings: %q %q %q %q", cfg.BaseURL, *cfg.Path, cfg.Region, cfg.Title)
+	}
+
+	if !slices.Equal(cfg.Hosts, []string{"a.com", "b.com"}) || !maps.Equal(cfg.Labels, map[string]string{"team": "PAYMENTS"}) {
+		t.Errorf("Unexpected collections: %v %v", cfg.Hosts, cfg.Labels)
+	}
+
+	if cfg.Homepage != "https://api.example.com/home" {
+		t.Errorf("Expected derived value to be normalized, got %q", cfg.Homepage)
+	}
+}
+
+func Test_NormalizeSecretRedaction(t *testing.T) {
+	var cfg struct {
+		Host  string   `env:"SECRET_HOST" secret:"true" normalize:"trim,lower" validate:"hostname"`
+		Peers []string `env:"SECRET_PEERS" secret:"true" normalize:"lower" validate:"dive,hostname"`
+	}
+
+	tests := map[string]string{
+		"SECRET_HOST":  " Hunter2_SECRET ",
+		"SECRET_PEERS": "Valid.Host,Bad_PEER",
+	}
+
+	for key, rawValue := range tests {
+		t.Run(key, func(t *testing.T) {
+			err := Parse(map[string]string{key: rawValue}, &cfg)
+			if err == nil {
+				t.Fatal("Expected a validation error")
+			}
+
+			for _, secret := range []string{"hunter2_secret", "bad_peer"} {
+				if strings.Contains(err.Error(), secret) {
+					t.Errorf("Expected normalized secret %q to be masked, got %v", secret, err)
+				}
+			}
+		})
+	}
+}
+
+func Test_NormalizeInvalid(t *testing.T) {
+	targets := []any{
+		&struct {
+			Name string `env:"NAME" normalize:"titlecase"`
+		}{},
+		&struct {
+			Name string `env:"NAME" normalize:"trimprefix"`
+		}{},
+		&struct {
+			Port int `env:"NAME" normalize:"trim"`
+		}{},
+	}
+
+	for _, strict := range []bool{false, true} {
+		for _, target := range targets {
+			var opts []Option
+			if strict {
+				opts = append(opts, WithStrictTags())
+			}
+
+			err := Parse(map[string]string{"NAME": "1"}, target, opts...)
+			if !errors.Is(err, errInvalidTagDefinition) || !strings.Contains(err.Error(), "tag=normalize") {
+				t.Errorf("Expected normalize definition error, got %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package envload
+
+import (
+	"maps"
+	"time"
+)
+
+type (
+	// Option configures how [LoadAndParse], [Parse] and [ParseRecord] populate a struct.
+	Option func(*options)
+
+	options struct {
+		strictTags        bool
+		maxFileSize       int64
+		sections          bool
+		fileRetry         time.Duration
+		maxKeys           int
+		maxValueLength    int
+		allowKeys         []string
+		denyKeys          []string
+		freeze            bool
+		unsetSecrets      bool
+		inferTypes        bool
+		emptyAsUnset      bool
+		whitespaceAsEmpty bool
+		preflight         bool
+		preflightTimeout  time.Duration
+		messages          map[string]string
+		warnings          *[]Warning
+		silent            bool
+		color             *bool
+		deadline          time.Duration
+		deadlineAt        time.Time // Start of the load plus deadline, zero without one.
+		partialResults    bool
+		profilePrefix     string
+		keyTranslations   map[string]string
+	}
+)
+
+// WithStrictTags reports struct tag definitions that would otherwise be silently ignored
+// as errors: defaults that cannot convert to the field type, `required` values other than
+// "true" or "false", and `env`, `default` or `required` tags on fields that cannot be populated.
+func WithStrictTags() Option {
+	return func(opts *options) {
+		opts.strictTags = true
+	}
+}
+
+// WithMaxFileSize makes [LoadAndParse] reject env files (including #include'd files)
+// larger than n bytes. Zero or a negative n means no limit.
+func WithMaxFileSize(n int64) Option {
+	return func(opts *options) {
+		opts.maxFileSize = n
+	}
+}
+
+// WithSections makes [LoadAndParse] read INI-style section headers in env files:
+// after a "[database]" line, HOST=db sets DATABASE_HOST, until the next header, and "[]"
+// returns to unprefixed keys. Without it a header line is a parse error, so a stray
+// "[x]" line never renames the keys below it.
+func WithSections() Option {
+	return func(opts *options) {
+		opts.sections = true
+	}
+}
+
+// WithFileRetry makes [LoadAndParse] keep reading the env file for up to wait while it
+// (or a file it includes) does not exist yet, for Kubernetes projected volumes and
+// init containers that may still be writing it when the application starts. If the
+// file never appears, loading continues with defaults as without the option.
+// [WithDeadline] shortens the wait to the remaining budget.
+func WithFileRetry(wait time.Duration) Option {
+	return func(opts *options) {
+		opts.fileRetry = wait
+	}
+}
+
+// WithMaxKeys makes [LoadAndParse] reject env files defining more than n keys.
+// Zero or a negative n means no limit.
+func WithMaxKeys(n int) Option {
+	return func(opts *options) {
+		opts.maxKeys = n
+	}
+}
+
+// WithMaxValueLength makes [LoadAndParse] reject env files containing a value
+// longer than n bytes. Zero or a negative n means no limit.
+func WithMaxValueLength(n int) Option {
+	return func(opts *options) {
+		opts.maxValueLength = n
+	}
+}
+
+// WithAllowKeys restricts the keys visible to struct fields to those matching one of
+// patterns, either exact names or globs such as "APP_*". Other keys are treated as unset.
+// Repeated calls add to the allow list.
+func WithAllowKeys(patterns ...string) Option {
+	return func(opts *options) {
+		opts.allowKeys = append(opts.allowKeys, patterns...)
+	}
+}
+
+// WithDenyKeys hides keys matching one of patterns (exact names or globs such as "LD_*")
+// from struct fields, so they are treated as unset. Deny patterns win over [WithAllowKeys].
+// Repeated calls add to the deny list.
+func WithDenyKeys(patterns ...string) Option {
+	return func(opts *options) {
+		opts.denyKeys = append(opts.denyKeys, patterns...)
+	}
+}
+
+// WithProfilePrefix selects a profile within a shared env file: keys starting with
+// prefix are looked up without it, so with "PROD_" the field tagged `env:"DB_URL"` reads
+// PROD_DB_URL, falling back to DB_URL for settings shared by all profiles.
+// [WithAllowKeys] and [WithDenyKeys] see the stripped names.
+// Repeated calls replace the prefix.
+func WithProfilePrefix(prefix string) Option {
+	return func(opts *options) {
+		opts.profilePrefix = prefix
+	}
+}
+
+// WithKeyTranslation maps external variable names to the names used in `env` tags,
+// so a library adopted in an existing deployment reads its variables without renaming
+// them: with {"POSTGRES_URL": "DATABASE_URL"}, the field tagged `env:"DATABASE_URL"`
+// reads POSTGRES_URL when DATABASE_URL itself is unset. Translation applies after
+// [WithProfilePrefix] and before [WithAllowKeys] and [WithDenyKeys].
+// Repeated calls add to the translations.
+func WithKeyTranslation(translations map[string]string) Option {
+	return func(opts *options) {
+		if opts.keyTranslations == nil {
+			opts.keyTranslations = make(map[string]string, len(translations))
+		}
+
+		maps.Copy(opts.keyTranslations, translations)
+	}
+}
+
+// WithFreeze records a fingerprint of the struct after it is populated so that
+// [AssertUnchanged] can later detect components mutating a shared config at runtime.
+// It is meant for debug builds, tests and health checks.
+func WithFreeze() Option {
+	return func(opts *options) {
+		opts.freeze = true
+	}
+}
+
+// WithUnsetSecrets removes the variables of `secret:"true"` fields from the process
+// environment with os.Unsetenv once the struct has been populated successfully,
+// so child processes and /proc/self/environ do not expose them. Besides the field's
+// `env` name and aliases, the variable its value was read from is removed, such as
+// PROD_DB_PASSWORD under [WithProfilePrefix] or an external name of [WithKeyTranslation].
+func WithUnsetSecrets() Option {
+	return func(opts *options) {
+		opts.unsetSecrets = true
+	}
+}
+
+// WithInferredTypes populates map[string]any fields by inferring each value's type:
+// "true"/"false" become bool, integers int, decimals float64, durations such as "5s"
+// time.Duration, and anything else stays a string. Without it such fields are rejected.
+// This suits passthrough settings forwarded to third-party SDKs.
+func WithInferredTypes() Option {
+	return func(opts *options) {
+		opts.inferTypes = true
+	}
+}
+
+// WithEmptyAsUnset treats variables set to an empty value (FOO=) as unset, so fields fall
+// back to their aliases and default instead of being left empty, matching docker-compose.
+// The `emptyunset:"true"` tag enables the same behavior for a single field.
+func WithEmptyAsUnset() Option {
+	return func(opts *options) {
+		opts.emptyAsUnset = true
+	}
+}
+
+// WithWhitespaceAsEmpty treats whitespace-only values ("   ") of string fields as empty,
+// as they already are for every other field type, so string fields are left empty
+// (or fall back to their default together with [WithEmptyAsUnset]).
+func WithWhitespaceAsEmpty() Option {
+	return func(opts *options) {
+		opts.whitespaceAsEmpty = true
+	}
+}
+
+// WithPreflight runs the checks named by `preflight` tags once the struct is populated:
+// `preflight:"tcp"` dials host:port values and `preflight:"dns"` resolves hostnames.
+// Checks run concurrently within timeout (5 seconds when zero or negative), and all
+// failures are returned together so bad endpoints surface at startup.
+func WithPreflight(timeout time.Duration) Option {
+	return func(opts *options) {
+		opts.preflight = true
+		opts.preflightTimeout = timeout
+	}
+}
+
+// WithMessages replaces the messages of [FieldError]s with text/template templates, so
+// products showing config errors to end users can translate or brand them. Keys are
+// error codes ("required", "invalid", "unique", "validate") or "validate.<rule>" for one
+// rule, which wins over "validate". Templates are executed with the [FieldError]:
+//
+//	envload.WithMessages(map[string]string{
+//		"required":     "Bitte {{.Env}} setzen.",
+//		"validate.min": "{{.Env}} muss mindestens {{.Param}} sein.",
+//	})
+//
+// Codes without a template keep the default message. Repeated calls add to the catalog.
+func WithMessages(messages map[string]string) Option {
+	return func(opts *options) {
+		if opts.messages == nil {
+			opts.messages = make(map[string]string, len(messages))
+		}
+
+		maps.Copy(opts.messages, messages)
+	}
+}
+
+// WithWarnings appends the warnings of the load, in the order they occur, to *warnings,
+// so programs can decide how to surface them (e.g. fail CI on any warning). Warnings
+// are still logged as well.
+//
+//	var warnings []envload.Warning
+//	err := envload.LoadAndParse(".env", &cfg, envload.WithWarnings(&warnings))
+func WithWarnings(warnings *[]Warning) Option {
+	return func(opts *options) {
+		opts.warnings = warnings
+	}
+}
+
+// WithSilent guarantees that loading never writes to the standard logger, for tools
+// whose output must stay machine-parseable. Warnings are dropped unless collected with
+// [WithWarnings]; errors are returned as usual.
+func WithSilent() Option {
+	return func(opts *options) {
+		opts.silent = true
+	}
+}
+
+// WithColor turns the ANSI highlighting of logged warnings on or off. Without it,
+// warnings are highlighted only when the standard logger writes to a terminal and
+// NO_COLOR is not set, so escape codes stay out of log aggregation systems.
+func WithColor(enabled bool) Option {
+	return func(opts *options) {
+		opts.color = &enabled
+	}
+}
+
+// WithDeadline bounds the whole load, from reading files to preflight checks, to d, for
+// services with strict readiness budgets. When d is spent, loading stops at the next
+// phase or field with a [DeadlineError] naming the phase; preflight checks are given at
+// most the remaining time. The struct is left as it was before the load unless
+// [WithPartialResults] is set. Zero or a negative d means no deadline.
+func WithDeadline(d time.Duration) Option {
+	return func(opts *options) {
+		opts.deadline = d
+	}
+}
+
+// WithPartialResults keeps the fields populated before a [WithDeadline] budget ran out,
+// so supervisors can decide whether to proceed in degraded mode; the [DeadlineError]
+// lists the fields left unresolved. Without it, the struct is restored to its state
+// before the load.
+//
+//	err := envload.Parse(envMap, &cfg, envload.WithDeadline(time.Second), envload.WithPartialResults())
+//	if deadlineErr := (*envload.DeadlineError)(nil); errors.As(err, &deadlineErr) {
+//		log.Printf("degraded: %v unresolved", deadlineErr.Unresolved)
+//	}
+func WithPartialResults() Option {
+	return func(opts *options) {
+		opts.partialResults = true
+	}
+}
+
+// withDeadlineAt carries the [WithDeadline] budget already started by [LoadAndParse]
+// over to the population of the struct.
+func withDeadlineAt(deadlineAt time.Time) Option {
+	return func(opts *options) {
+		opts.deadlineAt = deadlineAt
+	}
+}
+
+// newOptions applies opts over the default configuration.
+// The [WithDeadline] budget starts counting here unless carried over with withDeadlineAt.
+func newOptions(opts []Option) options {
+	if len(opts) == 0 {
+		return options{} // Spares the allocation of applying options.
+	}
+
+	var config options
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if config.deadline > 0 && config.deadlineAt.IsZero() {
+		config.deadlineAt = time.Now().Add(config.deadline)
+	}
+
+	return config
+}
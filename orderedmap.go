@@ -0,0 +1,93 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type (
+	// Pair is one key:value entry of an [OrderedMap].
+	Pair struct {
+		Key   string
+		Value string
+	}
+
+	// OrderedMap is a list of key:value pairs that keeps the order they were written in,
+	// for settings where order matters such as middleware chains. Keys must be unique.
+	//
+	// Example:
+	//
+	//	type Config struct {
+	//		Middleware envload.OrderedMap `env:"MIDDLEWARE"` // MIDDLEWARE=auth:on,ratelimit:on,gzip:off
+	//	}
+	//
+	//	for _, pair := range cfg.Middleware { ... }
+	OrderedMap []Pair
+)
+
+var (
+	errInvalidOrderedMap = errors.New("invalid ordered map")
+)
+
+// ParseOrderedMap parses comma-separated key:value pairs, keeping their order.
+func ParseOrderedMap(rawValue string) (OrderedMap, error) {
+	var pairs OrderedMap
+
+	seen := make(map[string]struct{})
+
+	for entry := range strings.SplitSeq(rawValue, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue // Empty values are filtered like in other slices.
+		}
+
+		kv := strings.SplitN(entry, ":", keyValueSeparatorLimit)
+		if len(kv) != keyValueSeparatorLimit {
+			return nil, fmt.Errorf("%w: '%s' is not a key:value pair", errInvalidOrderedMap, entry)
+		}
+
+		key := strings.TrimSpace(kv[0])
+		if _, ok := seen[key]; ok {
+			return nil, fmt.Errorf("%w: duplicate key %q", errInvalidOrderedMap, key)
+		}
+
+		seen[key] = struct{}{}
+		pairs = append(pairs, Pair{Key: key, Value: strings.TrimSpace(kv[1])})
+	}
+
+	return pairs, nil
+}
+
+// Get returns the value stored under key.
+func (pairs OrderedMap) Get(key string) (string, bool) {
+	for _, pair := range pairs {
+		if pair.Key == key {
+			return pair.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// Keys returns the keys in their original order.
+func (pairs OrderedMap) Keys() []string {
+	keys := make([]string, len(pairs))
+	for i, pair := range pairs {
+		keys[i] = pair.Key
+	}
+
+	return keys
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (pairs *OrderedMap) UnmarshalText(text []byte) error {
+	parsed, err := ParseOrderedMap(string(text))
+	if err != nil {
+		return err
+	}
+
+	*pairs = parsed
+
+	return nil
+}
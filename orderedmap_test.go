@@ -0,0 +1,51 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func Test_OrderedMap_FieldDecoding(t *testing.T) {
+	envMap := map[string]string{"MIDDLEWARE": "auth:on, ratelimit:on,gzip:off,proxy:http://upstream:8080"}
+
+	var config struct {
+		Middleware OrderedMap `env:"MIDDLEWARE"`
+	}
+
+	if err := populateStruct(envMap, &config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := Tests[string]{
+		{"keys keep their order", fmt.Sprint(config.Middleware.Keys()), "[auth ratelimit gzip proxy]"},
+		{"value lookup", mustGet(config.Middleware, "gzip"), "off"},
+		{"value containing colons", mustGet(config.Middleware, "proxy"), "http://upstream:8080"},
+	}
+
+	tests.runTests(t)
+
+	if _, ok := config.Middleware.Get("missing"); ok {
+		t.Error("Expected missing key to report false")
+	}
+}
+
+func Test_ParseOrderedMap_Invalid(t *testing.T) {
+	for _, rawValue := range []string{"auth", "auth:on,auth:off"} {
+		t.Run(rawValue, func(t *testing.T) {
+			if _, err := ParseOrderedMap(rawValue); !errors.Is(err, errInvalidOrderedMap) {
+				t.Errorf("Expected errInvalidOrderedMap for %q, got %v", rawValue, err)
+			}
+		})
+	}
+}
+
+// mustGet returns the value for key, or "<missing>" when absent.
+func mustGet(pairs OrderedMap, key string) string {
+	value, ok := pairs.Get(key)
+	if !ok {
+		return "<missing>"
+	}
+
+	return value
+}
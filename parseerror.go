@@ -0,0 +1,132 @@
+package envload
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/joho/godotenv"
+)
+
+type (
+	// ParseError reports the position of malformed content in a .env file.
+	// Use errors.As to inspect it:
+	//
+	//	var parseErr *envload.ParseError
+	//	if errors.As(err, &parseErr) {
+	//		fmt.Println(parseErr.File, parseErr.Line, parseErr.Column)
+	//	}
+	ParseError struct {
+		File    string // File that contains the malformed line.
+		Line    int    // 1-based line number within File.
+		Column  int    // 1-based column (in runes) within Line.
+		Excerpt string // The offending line.
+		Reason  string // Short description of the problem.
+		Err     error  // Underlying error, without the content of the file.
+	}
+)
+
+const (
+	// [maxExcerptLength] bounds the excerpt so long values do not flood logs.
+	maxExcerptLength = 80
+)
+
+var (
+	// [errMalformedLine] is the underlying error of a [ParseError] from .env content. The
+	// parser's own message is not kept, as it quotes the rest of the file.
+	errMalformedLine = errors.New("malformed line")
+)
+
+// Error formats the position as file:line:column followed by the reason and excerpt.
+func (parseErr *ParseError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %q", parseErr.File, parseErr.Line, parseErr.Column, parseErr.Reason, parseErr.Excerpt)
+}
+
+// Unwrap returns the underlying parser error.
+func (parseErr *ParseError) Unwrap() error {
+	return parseErr.Err
+}
+
+// locateParseError returns a [ParseError] pointing at the original file and line of the
+// statement in content that the parser rejected. content is lines joined. The parser
+// reads statements in order, so the offending statement starts right after the longest
+// prefix of whole lines that still parses; prefixes ending inside a multi-line value do
+// not parse either, which is why the search runs from the end.
+func locateParseError(lines []envLine, content []byte) error {
+	ends := make([]int, len(lines))
+	end := 0
+
+	for i, line := range lines {
+		end += len(line.text)
+		ends[i] = end
+	}
+
+	failed := 0
+
+	for i := len(lines) - 1; i > 0; i-- {
+		envMap, err := godotenv.UnmarshalBytes(content[:ends[i-1]])
+		clear(envMap)
+
+		if err == nil {
+			failed = i
+			break
+		}
+	}
+
+	line := lines[failed]
+	text := string(bytes.TrimRight(line.text, "\r\n"))
+	column, reason := describeMalformedLine(text)
+
+	return &ParseError{
+		File:    line.file,
+		Line:    line.number,
+		Column:  column,
+		Excerpt: truncateExcerpt(redactExcerpt(text)),
+		Reason:  reason,
+		Err:     errMalformedLine,
+	}
+}
+
+// describeMalformedLine returns the 1-based column (in runes) and a description of the
+// problem with the statement starting on text, checking the key like the parser does.
+func describeMalformedLine(text string) (int, string) {
+	start := len(text) - len(strings.TrimLeftFunc(text, unicode.IsSpace))
+	statement := text[start:]
+
+	if rest, ok := strings.CutPrefix(statement, "export"); ok && rest != strings.TrimLeftFunc(rest, unicode.IsSpace) {
+		statement = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		start = len(text) - len(statement)
+	}
+
+	for i, char := range statement {
+		switch {
+		case char == '=' || char == ':':
+			value := strings.TrimLeftFunc(statement[i+1:], unicode.IsSpace)
+			if value != "" && (value[0] == '"' || value[0] == '\'') {
+				return utf8.RuneCountInString(text[:len(text)-len(value)]) + 1, "unterminated quoted value"
+			}
+
+			return utf8.RuneCountInString(text[:start]) + 1, "malformed line"
+
+		case unicode.IsSpace(char) || char == '_' || char == '.' || unicode.IsLetter(char) || unicode.IsNumber(char):
+			continue
+
+		default:
+			return utf8.RuneCountInString(text[:start+i]) + 1, fmt.Sprintf("unexpected character %q in variable name", string(char))
+		}
+	}
+
+	return utf8.RuneCountInString(text[:start]) + 1, "malformed line"
+}
+
+// truncateExcerpt shortens excerpt to maxExcerptLength runes.
+func truncateExcerpt(excerpt string) string {
+	if utf8.RuneCountInString(excerpt) <= maxExcerptLength {
+		return excerpt
+	}
+
+	return string([]rune(excerpt)[:maxExcerptLength]) + "..."
+}
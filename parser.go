@@ -0,0 +1,76 @@
+package envload
+
+import (
+	"reflect"
+	"sync"
+)
+
+type (
+	// fieldParser converts a raw env value into a value of the type it is registered for.
+	fieldParser func(rawValue string) (reflect.Value, error)
+)
+
+var (
+	parsersMu sync.RWMutex
+
+	// [parsers] starts out with the types decoded natively that neither parse themselves
+	// nor implement encoding.TextUnmarshaler.
+	parsers = map[reflect.Type]fieldParser{
+		urlType:   newFieldParser(parseURL),
+		ipNetType: newFieldParser(parseIPNet),
+	}
+)
+
+// RegisterParser registers parse to decode values of type T, for application types the
+// loader cannot convert on its own (e.g. a ShardID) or whose built-in conversion should
+// be replaced. It is consulted before every other conversion for fields of type T, for
+// slice elements and for the values pointer fields point to. Its error is returned naming
+// the type and field. Registering a second parser for T replaces the previous one,
+// including the built-in parsers of url.URL and net.IPNet.
+// Plain string fields are never converted, so T must not be string.
+//
+// Example:
+//
+//	envload.RegisterParser(func(rawValue string) (ShardID, error) {
+//		n, err := strconv.Atoi(strings.TrimPrefix(rawValue, "shard-"))
+//		return ShardID(n), err
+//	})
+//
+//	type Config struct {
+//		Shards []ShardID `env:"SHARDS"` // SHARDS=shard-1,shard-7
+//	}
+func RegisterParser[T any](parse func(rawValue string) (T, error)) {
+	typ := reflect.TypeFor[T]()
+	if typ == stringType {
+		panic("envload: RegisterParser: string values are not converted")
+	}
+
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+
+	parsers[typ] = newFieldParser(parse)
+}
+
+// newFieldParser adapts parse to a [fieldParser].
+func newFieldParser[T any](parse func(rawValue string) (T, error)) fieldParser {
+	return func(rawValue string) (reflect.Value, error) {
+		value, err := parse(rawValue)
+		return reflect.ValueOf(&value).Elem(), err
+	}
+}
+
+// lookupParser returns the parser registered for typ.
+func lookupParser(typ reflect.Type) (fieldParser, bool) {
+	parsersMu.RLock()
+	defer parsersMu.RUnlock()
+
+	parser, ok := parsers[typ]
+
+	return parser, ok
+}
+
+// hasParser reports whether a parser is registered for typ.
+func hasParser(typ reflect.Type) bool {
+	_, ok := lookupParser(typ)
+	return ok
+}
@@ -0,0 +1,58 @@
+package envload
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type shardID int
+
+func Test_RegisterParser(t *testing.T) {
+	RegisterParser(func(rawValue string) (shardID, error) {
+		number, found := strings.CutPrefix(rawValue, "shard-")
+		if !found {
+			return 0, errors.New("missing shard- prefix")
+		}
+
+		n, err := strconv.Atoi(number)
+
+		return shardID(n), err
+	})
+
+	var cfg struct {
+		Home    shardID   `env:"HOME_SHARD" default:"shard-1"`
+		Shards  []shardID `env:"SHARDS"`
+		Backup  *shardID  `env:"BACKUP_SHARD"`
+		Replica shardID   `env:"REPLICA_SHARD" validate:"max=10"`
+	}
+
+	envMap := map[string]string{"SHARDS": "shard-2, shard-7", "BACKUP_SHARD": "shard-9"}
+	if err := Parse(envMap, &cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.Home != 1 || len(cfg.Shards) != 2 || cfg.Shards[1] != 7 || cfg.Backup == nil || *cfg.Backup != 9 {
+		t.Errorf("Unexpected shards: %v %v %v", cfg.Home, cfg.Shards, cfg.Backup)
+	}
+
+	err := Parse(map[string]string{"REPLICA_SHARD": "3"}, &cfg)
+	if err == nil || !strings.Contains(err.Error(), "invalid envload.shardID for field 'Replica': missing shard- prefix") {
+		t.Errorf("Expected parser error, got %v", err)
+	}
+
+	if err := Parse(map[string]string{"REPLICA_SHARD": "shard-11"}, &cfg); !errors.Is(err, errValidationFailed) {
+		t.Errorf("Expected validation of the parsed value, got %v", err)
+	}
+}
+
+func Test_RegisterParserString(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for a string parser")
+		}
+	}()
+
+	RegisterParser(func(rawValue string) (string, error) { return rawValue, nil })
+}
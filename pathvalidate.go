@@ -0,0 +1,100 @@
+package envload
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"runtime"
+)
+
+const (
+	// [worldReadable] is the permission bit letting any user read a file.
+	worldReadable fs.FileMode = 0o004
+
+	// [privateRule] rejects world-readable files; implied by "file" on `secret:"true"` fields.
+	privateRule = "private"
+)
+
+// checkRegularFile requires path to exist and be a regular file.
+func checkRegularFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("file %s: %w", path, err)
+	}
+
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("%s is not a regular file", path)
+	}
+
+	return nil
+}
+
+// checkDirectory requires path to exist and be a directory.
+func checkDirectory(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("directory %s: %w", path, err)
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", path)
+	}
+
+	return nil
+}
+
+// checkReadable requires that the process can open path for reading.
+func checkReadable(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("%s is not readable: %w", path, err)
+	}
+
+	return file.Close()
+}
+
+// checkWritable requires that the process can write to path: a file is opened for
+// writing without truncation, and a directory must accept a new temporary file.
+func checkWritable(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %w", path, err)
+	}
+
+	if !info.IsDir() {
+		file, err := os.OpenFile(path, os.O_WRONLY, 0)
+		if err != nil {
+			return fmt.Errorf("%s is not writable: %w", path, err)
+		}
+
+		return file.Close()
+	}
+
+	probe, err := os.CreateTemp(path, ".envload-writable-*")
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %w", path, err)
+	}
+
+	probe.Close()
+
+	return os.Remove(probe.Name())
+}
+
+// checkPrivate rejects files any user can read, such as TLS keys left at mode 0644.
+// Windows does not expose Unix permissions, so nothing is checked there.
+func checkPrivate(path string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("file %s: %w", path, err)
+	}
+
+	if info.Mode().Perm()&worldReadable != 0 {
+		return fmt.Errorf("%s is world-readable (mode %04o), restrict it to its owner (e.g. chmod 600)", path, info.Mode().Perm())
+	}
+
+	return nil
+}
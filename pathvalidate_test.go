@@ -0,0 +1,137 @@
+package envload
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func Test_ValidatePaths(t *testing.T) {
+	type Config struct {
+		ConfigFile string   `env:"CONFIG_FILE" validate:"file,readable"`
+		DataDir    string   `env:"DATA_DIR" validate:"dir,writable"`
+		Includes   []string `env:"INCLUDES" validate:"dive,file"`
+	}
+
+	dir := t.TempDir()
+
+	file := filepath.Join(dir, "app.yaml")
+	if err := os.WriteFile(file, []byte("a: 1"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	missing := filepath.Join(dir, "missing.yaml")
+
+	tests := []struct {
+		name    string
+		envMap  map[string]string
+		message string
+	}{
+		{"valid", map[string]string{"CONFIG_FILE": file, "DATA_DIR": dir, "INCLUDES": file}, ""},
+		{"missing file", map[string]string{"CONFIG_FILE": missing}, "field 'ConfigFile': file " + missing},
+		{"directory as file", map[string]string{"CONFIG_FILE": dir}, dir + " is not a regular file"},
+		{"file as directory", map[string]string{"DATA_DIR": file}, file + " is not a directory"},
+		{"element", map[string]string{"INCLUDES": file + "," + missing}, "field 'Includes': element 1: file " + missing},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cfg Config
+
+			err := Parse(test.envMap, &cfg)
+			if test.message == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, errValidationFailed) || !strings.Contains(err.Error(), test.message) {
+				t.Errorf("Expected validation error containing %q, got %v", test.message, err)
+			}
+		})
+	}
+}
+
+func Test_ValidatePathPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("permission bits are not enforced for this user")
+	}
+
+	dir := t.TempDir()
+
+	file := filepath.Join(dir, "locked")
+	if err := os.WriteFile(file, nil, 0o200); err != nil {
+		t.Fatal(err)
+	}
+
+	readOnlyDir := filepath.Join(dir, "readonly")
+	if err := os.Mkdir(readOnlyDir, 0o500); err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg struct {
+		ConfigFile string `env:"CONFIG_FILE" validate:"readable"`
+		DataDir    string `env:"DATA_DIR" validate:"dir,writable"`
+	}
+
+	if err := Parse(map[string]string{"CONFIG_FILE": file}, &cfg); !errors.Is(err, errValidationFailed) || !strings.Contains(err.Error(), "is not readable") {
+		t.Errorf("Expected readable failure, got %v", err)
+	}
+
+	if err := Parse(map[string]string{"DATA_DIR": readOnlyDir}, &cfg); !errors.Is(err, errValidationFailed) || !strings.Contains(err.Error(), "is not writable") {
+		t.Errorf("Expected writable failure, got %v", err)
+	}
+}
+
+func Test_ValidateSecretFilePrivate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix permissions are not checked on windows")
+	}
+
+	type Config struct {
+		KeyFile  string `env:"TLS_KEY_FILE" secret:"true" validate:"file"`
+		CertFile string `env:"TLS_CERT_FILE" validate:"file"`
+	}
+
+	dir := t.TempDir()
+
+	shared := filepath.Join(dir, "shared.pem")
+	if err := os.WriteFile(shared, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	private := filepath.Join(dir, "private.pem")
+	if err := os.WriteFile(private, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg Config
+
+	if err := Parse(map[string]string{"TLS_KEY_FILE": private, "TLS_CERT_FILE": shared}, &cfg); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	err := Parse(map[string]string{"TLS_KEY_FILE": shared}, &cfg)
+	if !errors.Is(err, errValidationFailed) || !strings.Contains(err.Error(), "is world-readable (mode 0644)") {
+		t.Errorf("Expected world-readable error, got %v", err)
+	}
+}
+
+func Test_ValidatePathInvalidType(t *testing.T) {
+	target := &struct {
+		Port int `env:"PORT" validate:"file"`
+	}{}
+
+	if err := Parse(map[string]string{"PORT": "8080"}, target); !errors.Is(err, errInvalidTagDefinition) {
+		t.Errorf("Expected errInvalidTagDefinition, got %v", err)
+	}
+
+	if err := Parse(nil, target, WithStrictTags()); !errors.Is(err, errInvalidTagDefinition) {
+		t.Errorf("Expected errInvalidTagDefinition under WithStrictTags, got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package envload
+
+import (
+	"reflect"
+)
+
+// setPointer allocates the value the pointer field points to and sets rawValue into it,
+// so pointer fields stay nil unless their variable or default is present.
+// Example: PORT=0 -> *int pointing at 0, PORT unset -> nil.
+func (resolver *fieldResolver) setPointer() error {
+	pointee := reflect.New(resolver.field.Type.Elem())
+
+	target := *resolver
+	target.field.Type = pointee.Type().Elem()
+	target.value = pointee.Elem()
+
+	if err := target.setValue(); err != nil {
+		return err
+	}
+
+	resolver.value.Set(pointee)
+
+	return nil
+}
+
+// setEmptyPointer points a string pointer field at "" when its variable is present but
+// empty, so an explicitly empty value can be told apart from an unset one.
+func (resolver *fieldResolver) setEmptyPointer() {
+	if resolver.present && resolver.field.Type.Kind() == reflect.Pointer && resolver.field.Type.Elem().Kind() == reflect.String {
+		resolver.value.Set(reflect.New(resolver.field.Type.Elem()))
+	}
+}
+
+// indirectType returns the type typ points to, following every level of pointers.
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	return typ
+}
+
+// indirectValue returns the value value points to, following every level of pointers,
+// and false when one of them is nil.
+func indirectValue(value reflect.Value) (reflect.Value, bool) {
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return value, false
+		}
+
+		value = value.Elem()
+	}
+
+	return value, true
+}
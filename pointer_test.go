@@ -0,0 +1,153 @@
+package envload
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type pointerConfig struct {
+	Port    *int           `env:"PORT"`
+	Name    *string        `env:"NAME"`
+	Debug   *bool          `env:"DEBUG"`
+	Timeout *time.Duration `env:"TIMEOUT" default:"5s"`
+	Level   *LogLevel      `env:"LOG_LEVEL"`
+	Workers *int           `env:"WORKERS" validate:"min=1"`
+	Budget  *int           `env:"BUDGET" validate:"gtefield=Workers"`
+}
+
+func Test_PointerFields(t *testing.T) {
+	t.Run("unset", func(t *testing.T) {
+		var cfg pointerConfig
+		if err := Parse(map[string]string{}, &cfg); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if cfg.Port != nil || cfg.Name != nil || cfg.Debug != nil || cfg.Level != nil {
+			t.Errorf("Expected nil pointers for unset variables, got %+v", cfg)
+		}
+
+		if cfg.Timeout == nil || *cfg.Timeout != 5*time.Second {
+			t.Errorf("Expected the default to be allocated, got %v", cfg.Timeout)
+		}
+	})
+
+	t.Run("zero values", func(t *testing.T) {
+		var cfg pointerConfig
+
+		err := Parse(map[string]string{"PORT": "0", "NAME": "", "DEBUG": "false", "LOG_LEVEL": "warn"}, &cfg)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if cfg.Port == nil || *cfg.Port != 0 || cfg.Name == nil || *cfg.Name != "" || cfg.Debug == nil || *cfg.Debug {
+			t.Errorf("Expected pointers to explicit zero values, got %+v", cfg)
+		}
+
+		if cfg.Level == nil || *cfg.Level != LogLevelWarn {
+			t.Errorf("Expected warn level, got %v", cfg.Level)
+		}
+	})
+
+	t.Run("empty as unset", func(t *testing.T) {
+		var cfg pointerConfig
+		if err := Parse(map[string]string{"NAME": "", "PORT": " "}, &cfg, WithEmptyAsUnset()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if cfg.Name != nil || cfg.Port != nil {
+			t.Errorf("Expected nil pointers, got %+v", cfg)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var cfg pointerConfig
+
+		var fieldErr *FieldError
+		if err := Parse(map[string]string{"PORT": "http"}, &cfg); !errors.As(err, &fieldErr) || fieldErr.Code != codeInvalid {
+			t.Fatalf("Expected invalid FieldError, got %v", err)
+		}
+
+		if cfg.Port != nil {
+			t.Errorf("Expected nil pointer after a failed conversion, got %d", *cfg.Port)
+		}
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		tests := []struct {
+			envMap map[string]string
+			valid  bool
+		}{
+			{map[string]string{"WORKERS": "4", "BUDGET": "8"}, true},
+			{map[string]string{"BUDGET": "8"}, true},
+			{map[string]string{"WORKERS": "0"}, false},
+			{map[string]string{"WORKERS": "4", "BUDGET": "2"}, false},
+		}
+
+		for _, test := range tests {
+			var cfg pointerConfig
+
+			err := Parse(test.envMap, &cfg)
+			if test.valid != (err == nil) {
+				t.Errorf("%v: expected valid=%t, got %v", test.envMap, test.valid, err)
+			}
+		}
+	})
+}
+
+func Test_PointerFieldsStrictTags(t *testing.T) {
+	type Config struct {
+		Port  *int      `env:"PORT" validate:"min=1,max=65535"`
+		Host  *string   `env:"HOST" validate:"hostname"`
+		Peers *[]string `env:"PEERS" validate:"minlen=1,dive,hostname"`
+	}
+
+	envMap := map[string]string{"PORT": "8080", "HOST": "db.internal", "PEERS": "a.internal,b.internal"}
+
+	var cfg Config
+	if err := Parse(envMap, &cfg, WithStrictTags()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if *cfg.Port != 8080 || *cfg.Host != "db.internal" || len(*cfg.Peers) != 2 {
+		t.Errorf("Unexpected config %v, %v, %v", *cfg.Port, *cfg.Host, *cfg.Peers)
+	}
+
+	envMap["PORT"] = "0"
+	if err := Parse(envMap, &cfg, WithStrictTags()); err == nil || errors.Is(err, errInvalidTagDefinition) {
+		t.Errorf("Expected a validation error for PORT=0, got %v", err)
+	}
+
+	var invalid struct {
+		Port *int `env:"PORT" validate:"dive,min=1"`
+	}
+
+	if err := Parse(map[string]string{}, &invalid, WithStrictTags()); !errors.Is(err, errInvalidTagDefinition) {
+		t.Errorf("Expected dive on *int to be rejected, got %v", err)
+	}
+}
+
+func Test_PointerFieldsOutput(t *testing.T) {
+	var a, b pointerConfig
+	if err := Parse(map[string]string{"PORT": "8080"}, &a); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := Parse(map[string]string{"PORT": "8080"}, &b); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !Equal(&a, &b) || Hash(&a) != Hash(&b) {
+		t.Error("Expected configs with equal pointees to be equal and hash alike")
+	}
+
+	redacted := Redacted(&a)
+	if redacted["PORT"] != 8080 || redacted["NAME"] != nil || redacted["TIMEOUT"] != "5s" {
+		t.Errorf("Expected dereferenced values, got %v", redacted)
+	}
+
+	*b.Port = 0
+	if Equal(&a, &b) || Hash(&a) == Hash(&b) {
+		t.Error("Expected a changed pointee to be detected")
+	}
+}
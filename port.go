@@ -0,0 +1,104 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+)
+
+const (
+	// [autoPort] is the `auto` tag value that assigns a free port to fields left at 0.
+	autoPort = "port"
+
+	maxPort = 65535
+)
+
+var (
+	errNoFreePort = errors.New("cannot assign free port")
+)
+
+// validateFreePort requires the port held by an integer value to be free for a TCP listener.
+// Port 0 passes, since it asks for any free port (see the `auto:"port"` tag).
+func validateFreePort(value reflect.Value, _ string) error {
+	port, ok := portNumber(value)
+	if !ok {
+		return fmt.Errorf("%w: freeport only applies to integer ports, not %s", errInvalidRule, value.Type())
+	}
+
+	if port == 0 {
+		return nil
+	}
+
+	if port < 0 || port > maxPort {
+		return fmt.Errorf("port must be between 1 and %d, got %d", maxPort, port)
+	}
+
+	listener, err := net.Listen("tcp", ":"+strconv.FormatInt(port, 10))
+	if err != nil {
+		return fmt.Errorf("port %d is not free: %w", port, err)
+	}
+
+	return listener.Close()
+}
+
+// assignAutoPorts sets the `auto:"port"` fields that are still 0 to ports the
+// operating system reports free. All listeners stay open until every port is chosen, so
+// the fields never receive the same port.
+func assignAutoPorts(fields []structField) error {
+	var listeners []net.Listener
+
+	defer func() {
+		for _, listener := range listeners {
+			listener.Close()
+		}
+	}()
+
+	for _, field := range fields {
+		resolver := fieldResolver{field: field.StructField, value: field.value}
+
+		port, ok := portNumber(resolver.value)
+		if !ok {
+			return resolver.tagError("auto", "port only applies to integer fields")
+		}
+
+		if port != 0 {
+			continue
+		}
+
+		listener, err := net.Listen("tcp", ":0")
+		if err != nil {
+			return fmt.Errorf("%w for field '%s': %w", errNoFreePort, resolver.field.Name, err)
+		}
+
+		listeners = append(listeners, listener)
+
+		//nolint:forcetypeassert // note: tcp listeners always have TCP addresses.
+		assigned := listener.Addr().(*net.TCPAddr).Port
+		if resolver.value.CanInt() && resolver.value.OverflowInt(int64(assigned)) ||
+			resolver.value.CanUint() && resolver.value.OverflowUint(uint64(assigned)) {
+			return fmt.Errorf("%w for field '%s': port %d overflows %s", errNoFreePort, resolver.field.Name, assigned, resolver.field.Type)
+		}
+
+		if resolver.value.CanInt() {
+			resolver.value.SetInt(int64(assigned))
+		} else {
+			resolver.value.SetUint(uint64(assigned))
+		}
+	}
+
+	return nil
+}
+
+// portNumber returns the integer held by value, reporting false for non-integer kinds.
+func portNumber(value reflect.Value) (int64, bool) {
+	switch {
+	case value.CanInt():
+		return value.Int(), true
+	case value.CanUint():
+		return int64(min(value.Uint(), maxPort+1)), true //nolint:gosec // note: clamped above.
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,101 @@
+package envload
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func Test_ValidateFreePort(t *testing.T) {
+	type Config struct {
+		Port int `env:"PORT" validate:"freeport"`
+	}
+
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	busy := strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+
+	var cfg Config
+
+	if err := Parse(map[string]string{"PORT": "0"}, &cfg); err != nil {
+		t.Errorf("Unexpected error for port 0: %v", err)
+	}
+
+	err = Parse(map[string]string{"PORT": busy}, &cfg)
+	if !errors.Is(err, errValidationFailed) || !strings.Contains(err.Error(), "port "+busy+" is not free") {
+		t.Errorf("Expected busy port error, got %v", err)
+	}
+
+	err = Parse(map[string]string{"PORT": "70000"}, &cfg)
+	if !errors.Is(err, errValidationFailed) || !strings.Contains(err.Error(), "must be between 1 and 65535") {
+		t.Errorf("Expected range error, got %v", err)
+	}
+
+	invalid := &struct {
+		Port string `env:"PORT" validate:"freeport"`
+	}{}
+	if err := Parse(map[string]string{"PORT": "8080"}, invalid); !errors.Is(err, errInvalidTagDefinition) {
+		t.Errorf("Expected errInvalidTagDefinition, got %v", err)
+	}
+}
+
+func Test_AutoPort(t *testing.T) {
+	type Config struct {
+		HTTPPort    int    `env:"HTTP_PORT" auto:"port"`
+		MetricsPort uint16 `env:"METRICS_PORT" default:"0" auto:"port"`
+		AdminPort   int    `env:"ADMIN_PORT" auto:"port"`
+	}
+
+	var cfg Config
+
+	if err := Parse(map[string]string{"ADMIN_PORT": "9000"}, &cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.HTTPPort == 0 || cfg.MetricsPort == 0 {
+		t.Errorf("Expected assigned ports, got HTTPPort=%d MetricsPort=%d", cfg.HTTPPort, cfg.MetricsPort)
+	}
+
+	if cfg.HTTPPort == int(cfg.MetricsPort) {
+		t.Errorf("Expected distinct ports, both got %d", cfg.HTTPPort)
+	}
+
+	if cfg.AdminPort != 9000 {
+		t.Errorf("Expected explicit AdminPort to be kept, got %d", cfg.AdminPort)
+	}
+}
+
+func Test_AutoPortInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		target any
+	}{
+		{"not an integer", &struct {
+			Addr string `env:"ADDR" auto:"port"`
+		}{}},
+		{"overflow", &struct {
+			Port int8 `env:"PORT" auto:"port"`
+		}{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := Parse(nil, test.target); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+
+	unknown := &struct {
+		Port int `env:"PORT" auto:"random"`
+	}{}
+	if err := Parse(nil, unknown, WithStrictTags()); !errors.Is(err, errInvalidTagDefinition) {
+		t.Errorf("Expected errInvalidTagDefinition under WithStrictTags, got %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package envload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// preflightCheck verifies that address is usable before the service relies on it.
+	preflightCheck func(ctx context.Context, address string) error
+)
+
+const (
+	// [defaultPreflightTimeout] bounds preflight checks when [WithPreflight] gets no timeout.
+	defaultPreflightTimeout = 5 * time.Second
+)
+
+var (
+	errPreflightFailed = errors.New("preflight check failed")
+
+	// [preflightChecks] maps values of the `preflight` tag to their checks.
+	preflightChecks = map[string]preflightCheck{
+		"tcp": func(ctx context.Context, address string) error {
+			var dialer net.Dialer
+
+			conn, err := dialer.DialContext(ctx, "tcp", address)
+			if err != nil {
+				return err
+			}
+
+			return conn.Close()
+		},
+		"dns": func(ctx context.Context, address string) error {
+			host := address
+			if splitHost, _, err := net.SplitHostPort(address); err == nil {
+				host = splitHost
+			}
+
+			_, err := net.DefaultResolver.LookupHost(ctx, host)
+
+			return err
+		},
+	}
+)
+
+// runPreflight runs the checks named by the `preflight` tags of the populated struct value
+// concurrently, all bounded by timeout, and joins the failures. String fields are checked
+// as one address and string slices element by element; unset fields are skipped.
+func runPreflight(value reflect.Value, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = defaultPreflightTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	for _, field := range structFields(value) {
+		name := field.Tag.Get("preflight")
+		if name == "" || !field.IsExported() {
+			continue
+		}
+
+		check, ok := preflightChecks[name]
+		if !ok {
+			return (&fieldResolver{field: field.StructField}).tagError("preflight", fmt.Sprintf("unknown check %q", name))
+		}
+
+		addresses, ok := preflightAddresses(field.value)
+		if !ok {
+			return (&fieldResolver{field: field.StructField}).tagError("preflight", "only string and []string fields can be checked")
+		}
+
+		for _, address := range addresses {
+			wg.Go(func() {
+				if err := check(ctx, address); err != nil {
+					mu.Lock()
+					defer mu.Unlock()
+
+					errs = append(errs, fmt.Errorf("%w for field '%s': %s %s: %w", errPreflightFailed, field.Name, name, address, err))
+				}
+			})
+		}
+	}
+
+	wg.Wait()
+
+	// Checks finish in any order; sort so the joined error is stable.
+	slices.SortFunc(errs, func(a, b error) int {
+		return strings.Compare(a.Error(), b.Error())
+	})
+
+	return errors.Join(errs...)
+}
+
+// preflightAddresses returns the non-empty addresses held by a string or []string field.
+func preflightAddresses(value reflect.Value) ([]string, bool) {
+	switch {
+	case value.Kind() == reflect.String:
+		if value.String() == "" {
+			return nil, true
+		}
+
+		return []string{value.String()}, true
+
+	case value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.String:
+		addresses := make([]string, 0, value.Len())
+		for i := range value.Len() {
+			if address := value.Index(i).String(); address != "" {
+				addresses = append(addresses, address)
+			}
+		}
+
+		return addresses, true
+
+	default:
+		return nil, false
+	}
+}
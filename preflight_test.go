@@ -0,0 +1,86 @@
+package envload
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_WithPreflight(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	closedAddress := closed.Addr().String()
+	closed.Close()
+
+	type Config struct {
+		Database string   `env:"DATABASE_ADDR" preflight:"tcp"`
+		Brokers  []string `env:"BROKERS" preflight:"tcp"`
+		Host     string   `env:"HOST" preflight:"dns"`
+		Optional string   `env:"OPTIONAL" preflight:"tcp"`
+	}
+
+	t.Run("reachable", func(t *testing.T) {
+		envMap := map[string]string{
+			"DATABASE_ADDR": listener.Addr().String(),
+			"BROKERS":       listener.Addr().String(),
+			"HOST":          "localhost",
+		}
+
+		var cfg Config
+		if err := Parse(envMap, &cfg, WithPreflight(time.Second)); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		envMap := map[string]string{
+			"DATABASE_ADDR": closedAddress,
+			"BROKERS":       listener.Addr().String() + "," + closedAddress,
+		}
+
+		var cfg Config
+
+		err := Parse(envMap, &cfg, WithPreflight(time.Second))
+		if !errors.Is(err, errPreflightFailed) {
+			t.Fatalf("Expected errPreflightFailed, got %v", err)
+		}
+
+		for _, field := range []string{"field 'Database'", "field 'Brokers'"} {
+			if !strings.Contains(err.Error(), field) {
+				t.Errorf("Expected error to report %s, got %v", field, err)
+			}
+		}
+	})
+
+	t.Run("not run without option", func(t *testing.T) {
+		var cfg Config
+		if err := Parse(map[string]string{"DATABASE_ADDR": closedAddress}, &cfg); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("unknown check", func(t *testing.T) {
+		var cfg struct {
+			Host string `env:"HOST" preflight:"http"`
+		}
+
+		if err := Parse(nil, &cfg, WithPreflight(time.Second)); !errors.Is(err, errInvalidTagDefinition) {
+			t.Errorf("Expected errInvalidTagDefinition, got %v", err)
+		}
+
+		if err := Parse(nil, &cfg, WithStrictTags()); !errors.Is(err, errInvalidTagDefinition) {
+			t.Errorf("Expected errInvalidTagDefinition under WithStrictTags, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,61 @@
+//go:build linux
+
+package envload
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// ProcessEnvMap reads the environment of the running process pid from
+// /proc/<pid>/environ into the env map form accepted by [Parse], to audit the
+// configuration a service actually started with. It reflects the environment at
+// exec time; changes the process made later with setenv are not visible.
+// Reading another user's process needs root or CAP_SYS_PTRACE, and failures
+// are reported wrapping [os.ErrPermission] or [os.ErrNotExist].
+//
+// Example:
+//
+//	envMap, err := envload.ProcessEnvMap(pid)
+//	if err != nil {
+//		return err
+//	}
+//	err = envload.Parse(envMap, &cfg)
+func ProcessEnvMap(pid int) (map[string]string, error) {
+	if pid <= 0 {
+		return nil, fmt.Errorf("read environment of process %d: %w", pid, os.ErrNotExist)
+	}
+
+	content, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/environ")
+	if errors.Is(err, os.ErrPermission) {
+		return nil, fmt.Errorf("read environment of process %d: %w (it belongs to another user; "+
+			"run as that user, as root or with CAP_SYS_PTRACE)", pid, err)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read environment of process %d: %w", pid, err)
+	}
+	defer clear(content)
+
+	return parseEnviron(content), nil
+}
+
+// parseEnviron splits NUL-separated KEY=VALUE entries. Entries without "=" are
+// skipped and the last of duplicate keys wins.
+func parseEnviron(content []byte) map[string]string {
+	envMap := make(map[string]string)
+
+	for entry := range bytes.SplitSeq(content, []byte{0}) {
+		key, value, ok := bytes.Cut(entry, []byte("="))
+		if !ok || len(key) == 0 {
+			continue
+		}
+
+		envMap[string(key)] = string(value)
+	}
+
+	return envMap
+}
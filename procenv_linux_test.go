@@ -0,0 +1,34 @@
+//go:build linux
+
+package envload
+
+import (
+	"errors"
+	"maps"
+	"os"
+	"testing"
+)
+
+func Test_ProcessEnvMap(t *testing.T) {
+	envMap, err := ProcessEnvMap(os.Getpid())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if path, ok := os.LookupEnv("PATH"); ok && envMap["PATH"] != path {
+		t.Errorf("Expected PATH %q, got %q", path, envMap["PATH"])
+	}
+
+	if _, err := ProcessEnvMap(0); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected os.ErrNotExist for pid 0, got %v", err)
+	}
+}
+
+func Test_parseEnviron(t *testing.T) {
+	got := parseEnviron([]byte("PORT=8080\x00EMPTY=\x00NOEQUALS\x00=bad\x00OPTS=a=b\x00PORT=9090\x00"))
+	expected := map[string]string{"PORT": "9090", "EMPTY": "", "OPTS": "a=b"}
+
+	if !maps.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
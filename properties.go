@@ -0,0 +1,208 @@
+package envload
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+const (
+	malformedUnicodeEscape = `malformed \uxxxx encoding`
+)
+
+var (
+	errInvalidProperties = errors.New("invalid properties file")
+)
+
+// PropertiesMap reads a Java .properties file into the env map form accepted by
+// [Parse], easing the migration of JVM services. Keys are canonicalized with
+// [CanonicalKey] (db.url -> DB_URL) and the java.util.Properties rules apply:
+//   - Lines starting with "#" or "!" are comments.
+//   - The key ends at the first unescaped "=", ":" or whitespace.
+//   - A backslash at the end of a line continues the value on the next one, whose
+//     leading whitespace is dropped.
+//   - \t, \n, \r, \f and \uXXXX escapes are decoded; other escaped characters stand
+//     for themselves.
+//
+// Files that are not valid UTF-8 are read as ISO-8859-1, the encoding of
+// Properties.load. Malformed \u escapes are reported as a [ParseError].
+//
+// Example:
+//
+//	envMap, err := envload.PropertiesMap("application.properties")
+//	if err != nil {
+//		return err
+//	}
+//	err = envload.Parse(envMap, &cfg)
+func PropertiesMap(filePath string) (map[string]string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(content)
+
+	return parseProperties(filePath, decodeProperties(content))
+}
+
+// decodeProperties returns content as text, decoding ISO-8859-1 when it is not UTF-8.
+func decodeProperties(content []byte) string {
+	if utf8.Valid(content) {
+		return string(content)
+	}
+
+	runes := make([]rune, len(content))
+	for i, b := range content {
+		runes[i] = rune(b)
+	}
+
+	return string(runes)
+}
+
+// parseProperties parses the logical lines of text.
+func parseProperties(filePath, text string) (map[string]string, error) {
+	envMap := make(map[string]string)
+
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	for number := 0; number < len(lines); number++ {
+		start := number
+		line := strings.TrimLeft(lines[number], " \t\f")
+
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		// Join continuation lines, dropping the leading whitespace of each.
+		for endsWithContinuation(line) && number+1 < len(lines) {
+			number++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[number], " \t\f")
+		}
+
+		line = strings.TrimSuffix(line, "\\") // A continuation on the last line has nothing to join.
+
+		rawKey, rawValue := splitProperty(line)
+
+		key, err := unescapeProperty(rawKey)
+		if err == nil {
+			rawValue, err = unescapeProperty(rawValue)
+		}
+
+		if err != nil {
+			return nil, &ParseError{
+				File:    filePath,
+				Line:    start + 1,
+				Column:  1,
+				Excerpt: truncateExcerpt(redactExcerpt(lines[start])),
+				Reason:  err.Error(),
+				Err:     errInvalidProperties,
+			}
+		}
+
+		if key = CanonicalKey(key); key != "" {
+			envMap[key] = rawValue
+		}
+	}
+
+	return envMap, nil
+}
+
+// endsWithContinuation reports whether line ends with an odd number of backslashes.
+func endsWithContinuation(line string) bool {
+	count := len(line) - len(strings.TrimRight(line, "\\"))
+
+	return count%2 == 1
+}
+
+// splitProperty splits a logical line into its raw key and value at the first
+// unescaped separator: "=", ":" or whitespace, followed by optional whitespace and
+// at most one "=" or ":".
+func splitProperty(line string) (string, string) {
+	end := len(line)
+
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+
+		if strings.IndexByte("=: \t\f", line[i]) >= 0 {
+			end = i
+			break
+		}
+	}
+
+	rest := strings.TrimLeft(line[end:], " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') && (end == len(line) || line[end] == ' ' || line[end] == '\t' || line[end] == '\f') {
+		rest = rest[1:]
+	} else if end < len(line) && (line[end] == '=' || line[end] == ':') {
+		rest = line[end+1:]
+	}
+
+	return line[:end], strings.TrimLeft(rest, " \t\f")
+}
+
+// parseUnicodeEscape parses the four hex digits at the start of text.
+func parseUnicodeEscape(text string) (rune, bool) {
+	if len(text) < 4 {
+		return 0, false
+	}
+
+	code, err := strconv.ParseUint(text[:4], 16, 16)
+
+	return rune(code), err == nil
+}
+
+// unescapeProperty decodes the backslash escapes of a key or value.
+func unescapeProperty(raw string) (string, error) {
+	if !strings.Contains(raw, "\\") {
+		return raw, nil
+	}
+
+	var builder strings.Builder
+
+	for i := 0; i < len(raw); i++ {
+		if raw[i] != '\\' || i+1 == len(raw) {
+			builder.WriteByte(raw[i])
+			continue
+		}
+
+		i++
+
+		switch raw[i] {
+		case 't':
+			builder.WriteByte('\t')
+		case 'n':
+			builder.WriteByte('\n')
+		case 'r':
+			builder.WriteByte('\r')
+		case 'f':
+			builder.WriteByte('\f')
+		case 'u':
+			char, ok := parseUnicodeEscape(raw[i+1:])
+			if !ok {
+				return "", errors.New(malformedUnicodeEscape)
+			}
+
+			i += 4
+
+			// Characters outside the BMP are written as a UTF-16 surrogate pair.
+			if next, ok := strings.CutPrefix(raw[i+1:], `\u`); ok && utf16.IsSurrogate(char) {
+				if low, ok := parseUnicodeEscape(next); ok {
+					if pair := utf16.DecodeRune(char, low); pair != utf8.RuneError {
+						char = pair
+						i += 6
+					}
+				}
+			}
+
+			builder.WriteRune(char)
+		default:
+			builder.WriteByte(raw[i])
+		}
+	}
+
+	return builder.String(), nil
+}
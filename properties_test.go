@@ -0,0 +1,82 @@
+package envload
+
+import (
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_parseProperties(t *testing.T) {
+	content := "# JVM settings\n" +
+		"! also a comment\n" +
+		"db.url=jdbc:postgresql://db/app\n" +
+		"server.port : 8080\n" +
+		"app.name  Orders Service\n" +
+		"  indented.key=value\n" +
+		"multi.line=first, \\\n" +
+		"    second\n" +
+		"escaped\\ key=a\\=b\\:c\n" +
+		"tabs=col1\\tcol2\n" +
+		"greeting=caf\\u00e9 \\uD83D\\uDE00\n" +
+		"empty=\n" +
+		"bare.key\n" +
+		"windows=crlf\r\n" +
+		"last.line=trailing\\"
+
+	got, err := parseProperties("app.properties", content)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"DB_URL":       "jdbc:postgresql://db/app",
+		"SERVER_PORT":  "8080",
+		"APP_NAME":     "Orders Service",
+		"INDENTED_KEY": "value",
+		"MULTI_LINE":   "first, second",
+		"ESCAPED_KEY":  "a=b:c",
+		"TABS":         "col1\tcol2",
+		"GREETING":     "café 😀",
+		"EMPTY":        "",
+		"BARE_KEY":     "",
+		"WINDOWS":      "crlf",
+		"LAST_LINE":    "trailing",
+	}
+
+	if !maps.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func Test_parsePropertiesMalformedEscape(t *testing.T) {
+	_, err := parseProperties("app.properties", "ok=1\nbad=\\u12G4\n")
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || !errors.Is(err, errInvalidProperties) {
+		t.Fatalf("Expected ParseError wrapping errInvalidProperties, got %v", err)
+	}
+
+	if parseErr.Line != 2 || parseErr.Reason != malformedUnicodeEscape {
+		t.Errorf("Expected line 2 with %q, got %d %q", malformedUnicodeEscape, parseErr.Line, parseErr.Reason)
+	}
+}
+
+func Test_PropertiesMap(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "app.properties")
+
+	// ISO-8859-1 encoded "name=café".
+	if err := os.WriteFile(filePath, []byte("name=caf\xe9\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	envMap, err := PropertiesMap(filePath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if envMap["NAME"] != "café" {
+		t.Errorf("Expected ISO-8859-1 content to be decoded, got %q", envMap["NAME"])
+	}
+}
@@ -0,0 +1,135 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+type (
+	// Quantity is a Kubernetes-style resource quantity such as "500m" (CPU millicores),
+	// "1.5" or "256Mi" (memory). Values are stored exactly in thousandths, rounding
+	// sub-milli fractions up the way Kubernetes does, so the largest quantity is
+	// math.MaxInt64 thousandths: just over 9.2P, or a little under 8Pi.
+	//
+	// Example:
+	//
+	//	type Config struct {
+	//		CPU    envload.Quantity `env:"CPU_LIMIT"    default:"500m"`
+	//		Memory envload.Quantity `env:"MEMORY_LIMIT" default:"256Mi"`
+	//	}
+	//
+	//	cfg.CPU.MilliValue()  // 500
+	//	cfg.Memory.Value()    // 268435456
+	Quantity struct {
+		milli int64
+		raw   string
+	}
+)
+
+const (
+	// [milliPerUnit] is the number of thousandths in one unit.
+	milliPerUnit = 1000
+)
+
+var (
+	errInvalidQuantity = errors.New("invalid quantity")
+
+	// [quantitySuffixes] maps suffixes to their multiplier, longest suffixes first so "Mi" wins over "M".
+	quantitySuffixes = []struct {
+		suffix     string
+		multiplier *big.Rat
+	}{
+		{"Ki", new(big.Rat).SetInt64(1 << 10)},
+		{"Mi", new(big.Rat).SetInt64(1 << 20)},
+		{"Gi", new(big.Rat).SetInt64(1 << 30)},
+		{"Ti", new(big.Rat).SetInt64(1 << 40)},
+		{"Pi", new(big.Rat).SetInt64(1 << 50)},
+		{"m", big.NewRat(1, 1000)},
+		{"k", new(big.Rat).SetInt64(1e3)},
+		{"M", new(big.Rat).SetInt64(1e6)},
+		{"G", new(big.Rat).SetInt64(1e9)},
+		{"T", new(big.Rat).SetInt64(1e12)},
+		{"P", new(big.Rat).SetInt64(1e15)},
+	}
+)
+
+// ParseQuantity parses a decimal number with an optional decimal (m, k, M, G, T, P)
+// or binary (Ki, Mi, Gi, Ti, Pi) suffix. Exa suffixes are not supported, as no
+// quantity that large fits in int64 thousandths.
+func ParseQuantity(rawValue string) (Quantity, error) {
+	trimmed := strings.TrimSpace(rawValue)
+	number := trimmed
+	multiplier := big.NewRat(1, 1)
+
+	for _, candidate := range quantitySuffixes {
+		if strings.HasSuffix(trimmed, candidate.suffix) {
+			number = strings.TrimSuffix(trimmed, candidate.suffix)
+			multiplier = candidate.multiplier
+
+			break
+		}
+	}
+
+	value, ok := new(big.Rat).SetString(number)
+	if !ok || number == "" || strings.ContainsAny(number, "/eE") {
+		return Quantity{}, fmt.Errorf("%w %q", errInvalidQuantity, rawValue)
+	}
+
+	value.Mul(value, multiplier)
+	value.Mul(value, big.NewRat(milliPerUnit, 1))
+
+	milli := ceilRat(value)
+	if !milli.IsInt64() {
+		return Quantity{}, fmt.Errorf("%w %q: out of range", errInvalidQuantity, rawValue)
+	}
+
+	return Quantity{milli: milli.Int64(), raw: trimmed}, nil
+}
+
+// ceilRat rounds value up to the nearest integer.
+func ceilRat(value *big.Rat) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	if remainder.Sign() > 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+
+	return quotient
+}
+
+// MilliValue returns the quantity in thousandths (e.g. CPU millicores).
+func (quantity Quantity) MilliValue() int64 {
+	return quantity.milli
+}
+
+// Value returns the quantity in whole units, rounded up (e.g. bytes or cores).
+func (quantity Quantity) Value() int64 {
+	value := quantity.milli / milliPerUnit
+	if quantity.milli%milliPerUnit > 0 {
+		value++
+	}
+
+	return value
+}
+
+// String returns the quantity as it was written, or in milli units when built in code.
+func (quantity Quantity) String() string {
+	if quantity.raw != "" {
+		return quantity.raw
+	}
+
+	return fmt.Sprintf("%dm", quantity.milli)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (quantity *Quantity) UnmarshalText(text []byte) error {
+	parsed, err := ParseQuantity(string(text))
+	if err != nil {
+		return err
+	}
+
+	*quantity = parsed
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package envload
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_Quantity_FieldDecoding(t *testing.T) {
+	envMap := map[string]string{
+		"CPU_REQUEST":  "500m",
+		"CPU_LIMIT":    "1.5",
+		"MEMORY_LIMIT": "256Mi",
+		"DISK":         "10G",
+		"TINY":         "0.1m",
+	}
+
+	var config struct {
+		CPURequest  Quantity `env:"CPU_REQUEST"`
+		CPULimit    Quantity `env:"CPU_LIMIT"`
+		MemoryLimit Quantity `env:"MEMORY_LIMIT"`
+		Disk        Quantity `env:"DISK"`
+		Tiny        Quantity `env:"TINY"`
+		Default     Quantity `env:"DEFAULT_QUANTITY" default:"1Ki"`
+	}
+
+	if err := populateStruct(envMap, &config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := Tests[int64]{
+		{"millicores", config.CPURequest.MilliValue(), 500},
+		{"millicores rounded up to a core", config.CPURequest.Value(), 1},
+		{"fractional cores", config.CPULimit.MilliValue(), 1500},
+		{"binary suffix", config.MemoryLimit.Value(), 256 << 20},
+		{"decimal suffix", config.Disk.Value(), 10_000_000_000},
+		{"sub-milli rounds up", config.Tiny.MilliValue(), 1},
+		{"default", config.Default.Value(), 1024},
+	}
+
+	tests.runTests(t)
+
+	if config.MemoryLimit.String() != "256Mi" {
+		t.Errorf("Expected '256Mi', got '%s'", config.MemoryLimit.String())
+	}
+}
+
+func Test_ParseQuantity_Invalid(t *testing.T) {
+	for _, rawValue := range []string{"", "Mi", "abc", "1/2", "1e3", "10Ei", "1E", "1Ei", "9Pi", "9.3P"} {
+		t.Run(rawValue, func(t *testing.T) {
+			if _, err := ParseQuantity(rawValue); !errors.Is(err, errInvalidQuantity) {
+				t.Errorf("Expected errInvalidQuantity for %q, got %v", rawValue, err)
+			}
+		})
+	}
+}
+
+func Test_ParseQuantity_Maximum(t *testing.T) {
+	for rawValue, expected := range map[string]int64{
+		"7Pi":  7 << 50,
+		"9.2P": 9_200_000_000_000_000,
+	} {
+		quantity, err := ParseQuantity(rawValue)
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", rawValue, err)
+		}
+
+		if quantity.Value() != expected {
+			t.Errorf("Expected %d for %q, got %d", expected, rawValue, quantity.Value())
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// RateLimit is a rate expression such as "100/s", "5000/m" or "10/500ms":
+	// Count events allowed Per interval.
+	//
+	// Example:
+	//
+	//	type Config struct {
+	//		RateLimit envload.RateLimit `env:"RATE_LIMIT" default:"100/s"`
+	//	}
+	//
+	//	limiter := rate.NewLimiter(rate.Limit(cfg.RateLimit.PerSecond()), cfg.RateLimit.Count)
+	RateLimit struct {
+		Count int
+		Per   time.Duration
+	}
+)
+
+const (
+	// [rateLimitSeparatorLimit] is the number of parts in a count/interval expression.
+	rateLimitSeparatorLimit = 2
+)
+
+var (
+	errInvalidRateLimit = errors.New("invalid rate limit")
+
+	// [rateLimitUnits] maps bare interval units to their duration.
+	rateLimitUnits = map[string]time.Duration{
+		"ms":     time.Millisecond,
+		"s":      time.Second,
+		"sec":    time.Second,
+		"second": time.Second,
+		"m":      time.Minute,
+		"min":    time.Minute,
+		"minute": time.Minute,
+		"h":      time.Hour,
+		"hour":   time.Hour,
+		"d":      24 * time.Hour,
+		"day":    24 * time.Hour,
+	}
+)
+
+// ParseRateLimit parses "count/interval" where interval is a unit (s, m, h, d, ...)
+// or any time.ParseDuration string (e.g. "500ms", "15m").
+func ParseRateLimit(rawValue string) (RateLimit, error) {
+	parts := strings.SplitN(strings.TrimSpace(rawValue), "/", rateLimitSeparatorLimit)
+	if len(parts) != rateLimitSeparatorLimit {
+		return RateLimit{}, fmt.Errorf("%w %q: expected count/interval", errInvalidRateLimit, rawValue)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || count <= 0 {
+		return RateLimit{}, fmt.Errorf("%w %q: count must be a positive integer", errInvalidRateLimit, rawValue)
+	}
+
+	interval := strings.ToLower(strings.TrimSpace(parts[1]))
+
+	per, ok := rateLimitUnits[interval]
+	if !ok {
+		per, err = time.ParseDuration(interval)
+		if err != nil {
+			return RateLimit{}, fmt.Errorf("%w %q: %w", errInvalidRateLimit, rawValue, err)
+		}
+	}
+
+	if per <= 0 {
+		return RateLimit{}, fmt.Errorf("%w %q: interval must be positive", errInvalidRateLimit, rawValue)
+	}
+
+	return RateLimit{Count: count, Per: per}, nil
+}
+
+// PerSecond returns the rate as events per second.
+func (limit RateLimit) PerSecond() float64 {
+	if limit.Per <= 0 {
+		return 0
+	}
+
+	return float64(limit.Count) / limit.Per.Seconds()
+}
+
+// Every returns the average interval between two events.
+func (limit RateLimit) Every() time.Duration {
+	if limit.Count <= 0 {
+		return 0
+	}
+
+	return limit.Per / time.Duration(limit.Count)
+}
+
+// String formats the limit as "count/interval".
+func (limit RateLimit) String() string {
+	return fmt.Sprintf("%d/%s", limit.Count, limit.Per)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (limit *RateLimit) UnmarshalText(text []byte) error {
+	parsed, err := ParseRateLimit(string(text))
+	if err != nil {
+		return err
+	}
+
+	*limit = parsed
+
+	return nil
+}
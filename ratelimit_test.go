@@ -0,0 +1,53 @@
+package envload
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_RateLimit_FieldDecoding(t *testing.T) {
+	envMap := map[string]string{
+		"API_LIMIT":   "100/s",
+		"LOGIN_LIMIT": "5000/m",
+		"BURST_LIMIT": "10/500ms",
+	}
+
+	var config struct {
+		API     RateLimit `env:"API_LIMIT"`
+		Login   RateLimit `env:"LOGIN_LIMIT"`
+		Burst   RateLimit `env:"BURST_LIMIT"`
+		Default RateLimit `env:"DEFAULT_LIMIT" default:"2/hour"`
+	}
+
+	if err := populateStruct(envMap, &config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := Tests[RateLimit]{
+		{"per second", config.API, RateLimit{Count: 100, Per: time.Second}},
+		{"per minute", config.Login, RateLimit{Count: 5000, Per: time.Minute}},
+		{"duration interval", config.Burst, RateLimit{Count: 10, Per: 500 * time.Millisecond}},
+		{"default with long unit", config.Default, RateLimit{Count: 2, Per: time.Hour}},
+	}
+
+	tests.runTests(t)
+
+	if config.API.PerSecond() != 100 || config.API.Every() != 10*time.Millisecond {
+		t.Errorf("Unexpected helpers: perSecond=%v every=%v", config.API.PerSecond(), config.API.Every())
+	}
+
+	if config.Burst.String() != "10/500ms" {
+		t.Errorf("Expected '10/500ms', got '%s'", config.Burst.String())
+	}
+}
+
+func Test_ParseRateLimit_Invalid(t *testing.T) {
+	for _, rawValue := range []string{"100", "abc/s", "0/s", "-5/s", "10/fortnight", "10/0s"} {
+		t.Run(rawValue, func(t *testing.T) {
+			if _, err := ParseRateLimit(rawValue); !errors.Is(err, errInvalidRateLimit) {
+				t.Errorf("Expected errInvalidRateLimit for %q, got %v", rawValue, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	errRecordLengthMismatch = errors.New("record length does not match header length")
+)
+
+// ParseRecord maps one tabular row (for example a CSV record) to a struct.
+// Column names from header are matched against `env` tags; the row must have
+// exactly one value per header column. Empty cells fall back to `default` tags.
+//
+// Example:
+//
+//	header, _ := reader.Read()
+//	for {
+//		row, err := reader.Read()
+//		...
+//		var customer CustomerConfig
+//		if err := envload.ParseRecord(header, row, &customer); err != nil { ... }
+//	}
+func ParseRecord(header []string, row []string, target any, opts ...Option) error {
+	if len(header) != len(row) {
+		return fmt.Errorf("%w: header=%d row=%d", errRecordLengthMismatch, len(header), len(row))
+	}
+
+	envMap := make(map[string]string, len(header))
+
+	for i, column := range header {
+		if row[i] == "" {
+			continue // Empty cells behave like unset variables so defaults apply.
+		}
+
+		envMap[strings.TrimSpace(column)] = row[i]
+	}
+
+	return populateStruct(envMap, target, opts...)
+}
@@ -0,0 +1,82 @@
+package envload
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func Test_ParseRecord(t *testing.T) {
+	type customer struct {
+		ID      int      `env:"ID"      required:"true"`
+		Name    string   `env:"NAME"`
+		Plan    string   `env:"PLAN"    default:"free"`
+		Regions []string `env:"REGIONS"`
+	}
+
+	t.Run("rows map to structs", func(t *testing.T) {
+		reader := csv.NewReader(strings.NewReader("ID, NAME,PLAN,REGIONS\n1,acme,pro,\"eu,us\"\n2,globex,,apac\n"))
+
+		header, err := reader.Read()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var customers []customer
+
+		for {
+			row, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			var item customer
+			if err := ParseRecord(header, row, &item); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			customers = append(customers, item)
+		}
+
+		if len(customers) != 2 {
+			t.Fatalf("Expected 2 customers, got %d", len(customers))
+		}
+
+		tests := Tests[string]{
+			{"first name", customers[0].Name, "acme"},
+			{"first plan", customers[0].Plan, "pro"},
+			{"second name from trimmed header", customers[1].Name, "globex"},
+			{"empty cell falls back to default", customers[1].Plan, "free"},
+		}
+
+		tests.runTests(t)
+
+		if customers[0].ID != 1 || len(customers[0].Regions) != 2 {
+			t.Errorf("Unexpected first customer: %+v", customers[0])
+		}
+	})
+
+	t.Run("length mismatch", func(t *testing.T) {
+		var item customer
+
+		err := ParseRecord([]string{"ID", "NAME"}, []string{"1"}, &item)
+		if !errors.Is(err, errRecordLengthMismatch) {
+			t.Errorf("Expected errRecordLengthMismatch, got %v", err)
+		}
+	})
+
+	t.Run("empty required cell", func(t *testing.T) {
+		var item customer
+
+		err := ParseRecord([]string{"ID", "NAME"}, []string{"", "acme"}, &item)
+		if !errors.Is(err, errMissingRequiredField) {
+			t.Errorf("Expected errMissingRequiredField, got %v", err)
+		}
+	})
+}
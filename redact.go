@@ -0,0 +1,99 @@
+package envload
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+)
+
+const (
+	// [redactedPlaceholder] replaces the value of set `secret:"true"` fields in [Redacted].
+	redactedPlaceholder = "[REDACTED]"
+)
+
+var (
+	// [stringerType] is used to render values such as time.Duration in their text form.
+	stringerType = reflect.TypeFor[fmt.Stringer]()
+)
+
+// Redacted returns the resolved values of the env-tagged fields of target (a struct or
+// pointer to one, including nested `envPrefix` structs), keyed by env variable, with
+// the values of set `secret:"true"` fields and values flagged by a [SecretScanner]
+// replaced by "[REDACTED]". Values implementing fmt.Stringer, and slices of them, are
+// rendered as text and [Lazy] fields as their raw value, so the result is suitable for
+// logging or JSON encoding.
+//
+// Example:
+//
+//	log.Printf("config: %v", envload.Redacted(&cfg))
+func Redacted(target any) map[string]any {
+	value, ok := structValue(target)
+	if !ok {
+		return nil
+	}
+
+	redacted := make(map[string]any)
+
+	for _, field := range structFields(value) {
+		if !isTaggedField(field.StructField) {
+			continue
+		}
+
+		fieldValue := field.value
+		envKey := field.envKey()
+
+		var display any
+		if field.Type.Implements(lazyRawValueType) {
+			display = lazyRawOf(fieldValue)
+		} else {
+			display = displayValue(fieldValue)
+		}
+
+		if !fieldValue.IsZero() && (isSecretField(field.StructField) || looksSecret(envKey, fmt.Sprint(display))) {
+			display = redactedPlaceholder
+		}
+
+		redacted[envKey] = display
+	}
+
+	return redacted
+}
+
+// isSecretField reports whether field is tagged `secret:"true"`.
+func isSecretField(field reflect.StructField) bool {
+	return field.Tag.Get("secret") == "true"
+}
+
+// displayValue returns value as text when it implements fmt.Stringer, as a slice of
+// text when its elements do, dereferenced when it is a pointer, and unchanged otherwise.
+// URLs are rendered with their password masked.
+func displayValue(value reflect.Value) any {
+	switch {
+	case (value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface) && value.IsNil():
+		return nil
+
+	case value.Type() == urlType:
+		link := value.Interface().(url.URL) //nolint:forcetypeassert // note: checked against urlType.
+		return link.Redacted()
+
+	case value.Kind() == reflect.Pointer && value.Type().Elem() == urlType:
+		return displayValue(value.Elem())
+
+	case value.Type().Implements(stringerType):
+		return value.Interface().(fmt.Stringer).String() //nolint:forcetypeassert // note: checked with Implements.
+
+	case value.Kind() == reflect.Pointer:
+		return displayValue(value.Elem())
+
+	case value.Kind() == reflect.Slice && value.Type().Elem().Implements(stringerType) && !value.IsNil():
+		texts := make([]string, value.Len())
+		for i := range texts {
+			texts[i] = fmt.Sprint(value.Index(i).Interface())
+		}
+
+		return texts
+
+	default:
+		return value.Interface()
+	}
+}
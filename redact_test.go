@@ -0,0 +1,55 @@
+package envload
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_Redacted(t *testing.T) {
+	type Config struct {
+		Name     string          `env:"APP_NAME"`
+		Password string          `env:"DB_PASSWORD" secret:"true"`
+		APIKey   string          `env:"API_KEY" secret:"true"`
+		Token    Lazy[string]    `env:"TOKEN" secret:"true"`
+		Timeout  time.Duration   `env:"TIMEOUT"`
+		Backoff  []time.Duration `env:"BACKOFF"`
+		Level    LogLevel        `env:"LOG_LEVEL"`
+		Features Features        `env:"FEATURES"`
+		Ignored  string          `env:"-"`
+	}
+
+	envMap := map[string]string{
+		"APP_NAME":    "service",
+		"DB_PASSWORD": "hunter2",
+		"TOKEN":       "secret",
+		"TIMEOUT":     "5s",
+		"BACKOFF":     "1s,2s",
+		"LOG_LEVEL":   "warn",
+		"FEATURES":    "beta:true",
+	}
+
+	var cfg Config
+	if err := Parse(envMap, &cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]any{
+		"APP_NAME":    "service",
+		"DB_PASSWORD": "[REDACTED]",
+		"API_KEY":     "",
+		"TOKEN":       "[REDACTED]",
+		"TIMEOUT":     "5s",
+		"BACKOFF":     []string{"1s", "2s"},
+		"LOG_LEVEL":   "warn",
+		"FEATURES":    Features{"beta": true},
+	}
+
+	if got := Redacted(&cfg); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if got := Redacted("not a struct"); got != nil {
+		t.Errorf("Expected nil for non-struct target, got %v", got)
+	}
+}
@@ -0,0 +1,202 @@
+//go:build windows
+
+package envload
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall"
+	"unicode/utf16"
+	"unsafe"
+)
+
+var (
+	errInvalidRegistryKey = errors.New("invalid registry key")
+
+	// [errNoMoreItems] is ERROR_NO_MORE_ITEMS, which the syscall package does not define.
+	errNoMoreItems = syscall.Errno(259)
+
+	// [registryRoots] maps the accepted root key names to their predefined handles.
+	registryRoots = map[string]syscall.Handle{
+		"HKLM":                syscall.HKEY_LOCAL_MACHINE,
+		"HKEY_LOCAL_MACHINE":  syscall.HKEY_LOCAL_MACHINE,
+		"HKCU":                syscall.HKEY_CURRENT_USER,
+		"HKEY_CURRENT_USER":   syscall.HKEY_CURRENT_USER,
+		"HKU":                 syscall.HKEY_USERS,
+		"HKEY_USERS":          syscall.HKEY_USERS,
+		"HKCR":                syscall.HKEY_CLASSES_ROOT,
+		"HKEY_CLASSES_ROOT":   syscall.HKEY_CLASSES_ROOT,
+		"HKCC":                syscall.HKEY_CURRENT_CONFIG,
+		"HKEY_CURRENT_CONFIG": syscall.HKEY_CURRENT_CONFIG,
+	}
+
+	// The syscall package does not wrap value enumeration or expansion.
+	advapi32                     = syscall.NewLazyDLL("advapi32.dll")
+	kernel32                     = syscall.NewLazyDLL("kernel32.dll")
+	procRegEnumValueW            = advapi32.NewProc("RegEnumValueW")
+	procExpandEnvironmentStrings = kernel32.NewProc("ExpandEnvironmentStringsW")
+)
+
+// RegistryMap reads the values stored directly under a Windows registry key into the
+// env map form accepted by [Parse], for services configured through the registry.
+// The key is a path such as `HKLM\SOFTWARE\Acme\Service`; the root may be abbreviated
+// (HKLM, HKCU, HKU, HKCR, HKCC) or spelled out (HKEY_LOCAL_MACHINE).
+// Value names are canonicalized with [CanonicalKey]. REG_SZ values are used as-is,
+// REG_EXPAND_SZ values have %VAR% references expanded, REG_MULTI_SZ strings are joined
+// with commas so they decode into slices and REG_DWORD/REG_QWORD values are formatted
+// as decimal numbers. Subkeys and values of other types are ignored.
+//
+// Example:
+//
+//	envMap, err := envload.RegistryMap(`HKLM\SOFTWARE\Acme\Service`)
+//	if err != nil {
+//		return err
+//	}
+//	err = envload.Parse(envMap, &cfg)
+func RegistryMap(key string) (map[string]string, error) {
+	handle, err := openRegistryKey(key)
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.RegCloseKey(handle)
+
+	var valueCount, maxNameLen, maxDataLen uint32
+	if err := syscall.RegQueryInfoKey(handle, nil, nil, nil, nil, nil, nil, &valueCount, &maxNameLen, &maxDataLen, nil, nil); err != nil {
+		return nil, fmt.Errorf("query registry key %s: %w", key, err)
+	}
+
+	envMap := make(map[string]string, valueCount)
+	name := make([]uint16, maxNameLen+1) // Lengths exclude the terminating NUL.
+	data := make([]byte, maxDataLen+2)
+
+	for index := uint32(0); index < valueCount; index++ {
+		nameLen := uint32(len(name))
+		dataLen := uint32(len(data))
+
+		var valueType uint32
+
+		errno, _, _ := procRegEnumValueW.Call(
+			uintptr(handle),
+			uintptr(index),
+			uintptr(unsafe.Pointer(&name[0])),
+			uintptr(unsafe.Pointer(&nameLen)),
+			0,
+			uintptr(unsafe.Pointer(&valueType)),
+			uintptr(unsafe.Pointer(&data[0])),
+			uintptr(unsafe.Pointer(&dataLen)),
+		)
+		if syscall.Errno(errno) == errNoMoreItems {
+			break // Values were deleted since the key was queried.
+		}
+
+		if errno != 0 {
+			return nil, fmt.Errorf("read registry key %s: %w", key, syscall.Errno(errno))
+		}
+
+		value, ok := registryValueString(valueType, data[:dataLen])
+		if !ok {
+			continue
+		}
+
+		envMap[CanonicalKey(syscall.UTF16ToString(name[:nameLen]))] = value
+	}
+
+	return envMap, nil
+}
+
+// openRegistryKey opens path, which starts with a root key name, for reading.
+func openRegistryKey(path string) (syscall.Handle, error) {
+	rootName, subkey, _ := strings.Cut(path, `\`)
+
+	root, ok := registryRoots[strings.ToUpper(rootName)]
+	if !ok {
+		return 0, fmt.Errorf("%w: unknown root %q in %s", errInvalidRegistryKey, rootName, path)
+	}
+
+	subkeyPtr, err := syscall.UTF16PtrFromString(subkey)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %w", errInvalidRegistryKey, path, err)
+	}
+
+	var handle syscall.Handle
+	if err := syscall.RegOpenKeyEx(root, subkeyPtr, 0, syscall.KEY_READ, &handle); err != nil {
+		return 0, fmt.Errorf("open registry key %s: %w", path, err)
+	}
+
+	return handle, nil
+}
+
+// registryValueString converts registry value data to its env representation.
+// It reports false for value types that have none.
+func registryValueString(valueType uint32, data []byte) (string, bool) {
+	switch valueType {
+	case syscall.REG_SZ:
+		return utf16BytesToString(data), true
+	case syscall.REG_EXPAND_SZ:
+		return expandEnvironmentStrings(utf16BytesToString(data)), true
+	case syscall.REG_MULTI_SZ:
+		var values []string
+		for value := range strings.SplitSeq(utf16BytesToString(data), "\x00") {
+			if value != "" {
+				values = append(values, value)
+			}
+		}
+
+		return strings.Join(values, ","), true
+	case syscall.REG_DWORD:
+		if len(data) < 4 {
+			return "", false
+		}
+
+		return strconv.FormatUint(uint64(binary.LittleEndian.Uint32(data)), 10), true
+	case syscall.REG_QWORD:
+		if len(data) < 8 {
+			return "", false
+		}
+
+		return strconv.FormatUint(binary.LittleEndian.Uint64(data), 10), true
+	default:
+		return "", false
+	}
+}
+
+// utf16BytesToString decodes little-endian UTF-16 registry data, keeping embedded NULs
+// (REG_MULTI_SZ separators) but dropping the trailing terminators.
+func utf16BytesToString(data []byte) string {
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(data[2*i:])
+	}
+
+	return strings.TrimRight(string(utf16.Decode(units)), "\x00")
+}
+
+// expandEnvironmentStrings replaces %VAR% references in value with the process environment.
+// It returns value unchanged when expansion fails.
+func expandEnvironmentStrings(value string) string {
+	source, err := syscall.UTF16PtrFromString(value)
+	if err != nil {
+		return value
+	}
+
+	buffer := make([]uint16, len(value)+1)
+	for {
+		size, _, _ := procExpandEnvironmentStrings.Call(
+			uintptr(unsafe.Pointer(source)),
+			uintptr(unsafe.Pointer(&buffer[0])),
+			uintptr(len(buffer)),
+		)
+		if size == 0 {
+			return value
+		}
+
+		if int(size) <= len(buffer) {
+			return syscall.UTF16ToString(buffer[:size])
+		}
+
+		buffer = make([]uint16, size)
+	}
+}
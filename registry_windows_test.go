@@ -0,0 +1,80 @@
+//go:build windows
+
+package envload
+
+import (
+	"encoding/binary"
+	"errors"
+	"syscall"
+	"testing"
+	"unicode/utf16"
+)
+
+func Test_RegistryMap(t *testing.T) {
+	envMap, err := RegistryMap(`HKLM\SOFTWARE\Microsoft\Windows NT\CurrentVersion`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var cfg struct {
+		ProductName  string `env:"PRODUCTNAME" required:"true"`
+		MajorVersion int    `env:"CURRENTMAJORVERSIONNUMBER"`
+	}
+
+	if err := Parse(envMap, &cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.ProductName == "" {
+		t.Error("Expected ProductName to be read from the registry")
+	}
+}
+
+func Test_RegistryMap_InvalidKey(t *testing.T) {
+	if _, err := RegistryMap(`HKXX\SOFTWARE`); !errors.Is(err, errInvalidRegistryKey) {
+		t.Errorf("Expected errInvalidRegistryKey, got %v", err)
+	}
+
+	if _, err := RegistryMap(`HKCU\SOFTWARE\envload-test-missing`); err == nil {
+		t.Error("Expected error for missing key")
+	}
+}
+
+func Test_registryValueString(t *testing.T) {
+	utf16Data := func(value string) []byte {
+		units := utf16.Encode([]rune(value))
+		data := make([]byte, 2*len(units))
+		for i, unit := range units {
+			binary.LittleEndian.PutUint16(data[2*i:], unit)
+		}
+
+		return data
+	}
+
+	dword := binary.LittleEndian.AppendUint32(nil, 8080)
+	qword := binary.LittleEndian.AppendUint64(nil, 1<<40)
+
+	tests := []struct {
+		name      string
+		valueType uint32
+		data      []byte
+		want      string
+		wantOK    bool
+	}{
+		{"string", syscall.REG_SZ, utf16Data("localhost\x00"), "localhost", true},
+		{"multi string", syscall.REG_MULTI_SZ, utf16Data("a\x00b\x00c\x00\x00"), "a,b,c", true},
+		{"dword", syscall.REG_DWORD, dword, "8080", true},
+		{"qword", syscall.REG_QWORD, qword, "1099511627776", true},
+		{"short dword", syscall.REG_DWORD, dword[:2], "", false},
+		{"binary", syscall.REG_BINARY, []byte{1, 2}, "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := registryValueString(test.valueType, test.data)
+			if got != test.want || ok != test.wantOK {
+				t.Errorf("Expected (%q, %v), got (%q, %v)", test.want, test.wantOK, got, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package envload
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// Reloader holds the config loaded from an env file and reloads it transactionally:
+	// each reload parses and validates into a fresh T, and the current config is only
+	// replaced when that succeeds, so a bad edit never reaches a running service.
+	// Its methods are safe for concurrent use.
+	Reloader[T any] struct {
+		filePath string
+		opts     []Option
+		current  atomic.Pointer[T]
+
+		mu      sync.Mutex // Serializes reloads and guards the fields below.
+		stats   ReloadStats
+		onError func(err error)
+	}
+
+	// ReloadStats counts the reloads of a [Reloader], for metrics and health checks.
+	ReloadStats struct {
+		Succeeded   uint64    // Reloads that replaced the config.
+		Failed      uint64    // Reloads that kept the previous config.
+		LastSuccess time.Time // Time of the last successful load, including the initial one.
+		LastFailure time.Time // Time of the last failed reload, zero without one.
+		LastError   error     // Error of the last failed reload, nil once a reload succeeds.
+	}
+)
+
+// NewReloader loads filePath into a new T with [LoadAndParse] and returns a [Reloader]
+// holding it; opts apply to the initial load and to every reload. Like LoadAndParse, the
+// initial load falls back to defaults when the file cannot be read.
+//
+// Example:
+//
+//	reloader, err := envload.NewReloader[Config](".env")
+//	if err != nil {
+//		return err
+//	}
+//
+//	reloader.OnReloadError(func(err error) { log.Printf("keeping previous config: %v", err) })
+//	signal.Notify(hup, syscall.SIGHUP)
+//	for range hup {
+//		_ = reloader.Reload()
+//	}
+func NewReloader[T any](filePath string, opts ...Option) (*Reloader[T], error) {
+	cfg := new(T)
+	if err := LoadAndParse(filePath, cfg, opts...); err != nil {
+		return nil, err
+	}
+
+	reloader := &Reloader[T]{filePath: filePath, opts: slices.Clip(opts)}
+	reloader.current.Store(cfg)
+	reloader.stats.LastSuccess = time.Now()
+
+	return reloader, nil
+}
+
+// Current returns the last config loaded successfully. The returned struct is never
+// modified by later reloads, so it can be read while a reload is in progress; callers
+// must not modify it either.
+func (reloader *Reloader[T]) Current() *T {
+	return reloader.current.Load()
+}
+
+// Reload loads the env file again into a fresh T and, once it is populated and
+// validated, replaces the current config. On failure the current config is kept, the
+// [ReloadStats] record the error and the callback set with [Reloader.OnReloadError] is
+// called before the error is returned. Unlike the initial load, a file that cannot be
+// read is a failure rather than a fall back to defaults, since a deleted or half-written
+// file must not reset a running service.
+func (reloader *Reloader[T]) Reload() error {
+	reloader.mu.Lock()
+	defer reloader.mu.Unlock()
+
+	cfg := new(T)
+	if err := loadFileStrict(reloader.filePath, cfg, reloader.opts); err != nil {
+		err = fmt.Errorf("reload %s: %w", reloader.filePath, err)
+
+		reloader.stats.Failed++
+		reloader.stats.LastFailure = time.Now()
+		reloader.stats.LastError = err
+
+		if reloader.onError != nil {
+			reloader.onError(err)
+		}
+
+		return err
+	}
+
+	reloader.current.Store(cfg)
+
+	reloader.stats.Succeeded++
+	reloader.stats.LastSuccess = time.Now()
+	reloader.stats.LastError = nil
+
+	return nil
+}
+
+// OnReloadError sets fn to be called with the error of every failed [Reloader.Reload],
+// e.g. to log it or increment a metric. Calls are serialized with reloads.
+func (reloader *Reloader[T]) OnReloadError(fn func(err error)) {
+	reloader.mu.Lock()
+	defer reloader.mu.Unlock()
+
+	reloader.onError = fn
+}
+
+// Stats returns the reload counters.
+func (reloader *Reloader[T]) Stats() ReloadStats {
+	reloader.mu.Lock()
+	defer reloader.mu.Unlock()
+
+	return reloader.stats
+}
+
+// loadFileStrict loads filePath into target like [LoadAndParse], but returns the error
+// of reading the file instead of falling back to defaults.
+func loadFileStrict(filePath string, target any, opts []Option) error {
+	return load(target, opts, filePath, false, func(config options) (map[string]string, error) {
+		return readEnvFileWithRetry(filePath, config)
+	})
+}
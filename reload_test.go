@@ -0,0 +1,113 @@
+package envload
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_Reloader(t *testing.T) {
+	type Config struct {
+		Name string `env:"APP_NAME" required:"true"`
+		Port int    `env:"PORT" default:"8080" validate:"min=1"`
+	}
+
+	filePath := filepath.Join(t.TempDir(), "app.env")
+	writeFile := func(content string) {
+		t.Helper()
+
+		if err := os.WriteFile(filePath, []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write env file: %v", err)
+		}
+	}
+
+	writeFile("APP_NAME=service\n")
+
+	reloader, err := NewReloader[Config](filePath, WithSilent())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var reported []error
+	reloader.OnReloadError(func(err error) { reported = append(reported, err) })
+
+	initial := reloader.Current()
+	if initial.Name != "service" || initial.Port != 8080 {
+		t.Fatalf("Unexpected initial config %+v", *initial)
+	}
+
+	t.Run("successful reload swaps", func(t *testing.T) {
+		writeFile("APP_NAME=service\nPORT=9090\n")
+
+		if err := reloader.Reload(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if got := reloader.Current(); got.Port != 9090 || got == initial {
+			t.Errorf("Expected a fresh config with Port=9090, got %+v", *got)
+		}
+
+		if initial.Port != 8080 {
+			t.Errorf("Expected the previous config to stay untouched, got %+v", *initial)
+		}
+	})
+
+	good := reloader.Current()
+
+	failures := map[string]func(){
+		"invalid value":  func() { writeFile("APP_NAME=service\nPORT=0\n") },
+		"missing field":  func() { writeFile("PORT=7070\n") },
+		"malformed file": func() { writeFile("APP_NAME='unterminated\n") },
+		"deleted file":   func() { _ = os.Remove(filePath) },
+	}
+
+	for name, breakFile := range failures {
+		t.Run(name, func(t *testing.T) {
+			breakFile()
+
+			err := reloader.Reload()
+			if err == nil || !strings.Contains(err.Error(), "reload "+filePath) {
+				t.Fatalf("Expected a reload error, got %v", err)
+			}
+
+			if reloader.Current() != good {
+				t.Errorf("Expected the last good config to be kept, got %+v", *reloader.Current())
+			}
+
+			if len(reported) == 0 || !errors.Is(reported[len(reported)-1], err) {
+				t.Errorf("Expected the callback to receive %v, got %v", err, reported)
+			}
+
+			if stats := reloader.Stats(); !errors.Is(stats.LastError, err) || stats.LastFailure.IsZero() {
+				t.Errorf("Expected the stats to record %v, got %+v", err, stats)
+			}
+		})
+	}
+
+	writeFile("APP_NAME=recovered\n")
+
+	if err := reloader.Reload(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	stats := reloader.Stats()
+	if stats.Succeeded != 2 || stats.Failed != uint64(len(failures)) || stats.LastError != nil {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+	if reloader.Current().Name != "recovered" {
+		t.Errorf("Expected the recovered config, got %+v", *reloader.Current())
+	}
+}
+
+func Test_NewReloader_InitialFailure(t *testing.T) {
+	type Config struct {
+		Name string `env:"APP_NAME" required:"true"`
+	}
+
+	if _, err := NewReloader[Config](filepath.Join(t.TempDir(), "missing.env"), WithSilent()); err == nil {
+		t.Error("Expected an error for a missing required field")
+	}
+}
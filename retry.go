@@ -0,0 +1,34 @@
+package envload
+
+import (
+	"errors"
+	"io/fs"
+	"time"
+)
+
+// fileRetryInterval is how often [WithFileRetry] checks again for a missing env file.
+var fileRetryInterval = 100 * time.Millisecond
+
+// readEnvFileWithRetry reads filePath like readEnvFile, reading again while the file
+// (or a file it includes) does not exist yet, until the [WithFileRetry] wait or the
+// [WithDeadline] budget runs out. The last error is returned when the file never appears.
+func readEnvFileWithRetry(filePath string, config options) (map[string]string, error) {
+	waitUntil := time.Now().Add(config.fileRetry)
+	if !config.deadlineAt.IsZero() && config.deadlineAt.Before(waitUntil) {
+		waitUntil = config.deadlineAt
+	}
+
+	for {
+		envMap, err := readEnvFile(filePath, config)
+		if !errors.Is(err, fs.ErrNotExist) {
+			return envMap, err
+		}
+
+		remaining := time.Until(waitUntil)
+		if remaining <= 0 {
+			return nil, err
+		}
+
+		time.Sleep(min(fileRetryInterval, remaining))
+	}
+}
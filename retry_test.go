@@ -0,0 +1,68 @@
+package envload
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_WithFileRetry(t *testing.T) {
+	type Config struct {
+		Name string `env:"APP_NAME" default:"fallback"`
+	}
+
+	t.Run("file appears while waiting", func(t *testing.T) {
+		filePath := filepath.Join(t.TempDir(), "app.env")
+
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			_ = os.WriteFile(filePath, []byte("APP_NAME=mounted\n"), 0o600)
+		}()
+
+		var cfg Config
+		if err := LoadAndParse(filePath, &cfg, WithFileRetry(5*time.Second), WithSilent()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if cfg.Name != "mounted" {
+			t.Errorf("Expected Name=mounted, got %q", cfg.Name)
+		}
+	})
+
+	t.Run("file never appears", func(t *testing.T) {
+		var (
+			cfg      Config
+			warnings []Warning
+		)
+
+		start := time.Now()
+
+		err := LoadAndParse(filepath.Join(t.TempDir(), "missing.env"), &cfg,
+			WithFileRetry(150*time.Millisecond), WithWarnings(&warnings), WithSilent())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+			t.Errorf("Expected to wait for the file, returned after %s", elapsed)
+		}
+
+		if cfg.Name != "fallback" || len(warnings) != 1 || warnings[0].Code != warningMissingFile {
+			t.Errorf("Expected defaults and a missing file warning, got %q and %v", cfg.Name, warnings)
+		}
+	})
+
+	t.Run("deadline shortens the wait", func(t *testing.T) {
+		var cfg Config
+
+		start := time.Now()
+
+		_ = LoadAndParse(filepath.Join(t.TempDir(), "missing.env"), &cfg,
+			WithFileRetry(time.Minute), WithDeadline(100*time.Millisecond), WithSilent())
+
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("Expected the deadline to bound the wait, took %s", elapsed)
+		}
+	})
+}
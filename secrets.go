@@ -0,0 +1,134 @@
+package envload
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type (
+	// SecretScanner reports whether value, read from the variable key, looks like a secret.
+	// Key may be empty when the variable name is not known.
+	SecretScanner func(key, value string) bool
+
+	// redactedError masks secret values in the message of the error it wraps.
+	// errors.Is and errors.As still see the wrapped error.
+	redactedError struct {
+		err     error
+		secrets []string
+	}
+)
+
+var (
+	// [secretScanners] holds the scanners added with [RegisterSecretScanner].
+	secretScanners   []SecretScanner
+	secretScannersMu sync.RWMutex
+)
+
+// RegisterSecretScanner adds a scanner consulted before a value is included in errors,
+// parse error excerpts or [Redacted] output. A value any scanner flags is redacted even
+// when its field is not tagged `secret:"true"`, letting security teams plug in entropy
+// or pattern based secret detection. Scanners must be safe for concurrent use.
+//
+// Example:
+//
+//	envload.RegisterSecretScanner(func(key, value string) bool {
+//		return strings.HasPrefix(value, "AKIA") || strings.Contains(key, "TOKEN")
+//	})
+func RegisterSecretScanner(scanner SecretScanner) {
+	secretScannersMu.Lock()
+	defer secretScannersMu.Unlock()
+
+	secretScanners = append(secretScanners, scanner)
+}
+
+// looksSecret reports whether any registered scanner flags value.
+func looksSecret(key, value string) bool {
+	if value == "" {
+		return false
+	}
+
+	secretScannersMu.RLock()
+	defer secretScannersMu.RUnlock()
+
+	for _, scanner := range secretScanners {
+		if scanner(key, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactError masks the raw value in err, and the values the `normalize` tag made of it,
+// when the field is tagged secret or a scanner flags it.
+func (resolver *fieldResolver) redactError(err error) error {
+	if err == nil || resolver.rawValue == "" {
+		return err
+	}
+
+	if !isSecretField(resolver.field) && !looksSecret(resolver.envKey, resolver.rawValue) {
+		return err
+	}
+
+	// Slice and map errors quote single elements, so mask those as well, longest first.
+	secrets := []string{resolver.rawValue}
+	for element := range strings.SplitSeq(resolver.rawValue, ",") {
+		if element = strings.TrimSpace(element); element != "" && element != resolver.rawValue {
+			secrets = append(secrets, element)
+		}
+	}
+
+	for _, normalized := range resolver.normalized {
+		if normalized != "" {
+			secrets = append(secrets, normalized)
+		}
+	}
+
+	slices.SortFunc(secrets, func(a, b string) int { return len(b) - len(a) })
+
+	return &redactedError{err: err, secrets: secrets}
+}
+
+// unsetSecretEnv removes keys from the process environment. Used by [WithUnsetSecrets].
+func unsetSecretEnv(keys []string) error {
+	for _, key := range keys {
+		if _, ok := os.LookupEnv(key); !ok {
+			continue
+		}
+
+		if err := os.Unsetenv(key); err != nil {
+			return fmt.Errorf("unset secret variable %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// redactExcerpt masks the value of a "KEY=value" excerpt flagged by a scanner.
+func redactExcerpt(excerpt string) string {
+	key, value, found := strings.Cut(excerpt, "=")
+	key = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(key), "export "))
+	if !found || !looksSecret(key, strings.Trim(strings.TrimSpace(value), `"'`)) {
+		return excerpt
+	}
+
+	return key + "=" + redactedPlaceholder
+}
+
+// Error returns the wrapped message with every occurrence of the secrets masked.
+func (redacted *redactedError) Error() string {
+	message := redacted.err.Error()
+	for _, secret := range redacted.secrets {
+		message = strings.ReplaceAll(message, secret, redactedPlaceholder)
+	}
+
+	return message
+}
+
+// Unwrap returns the wrapped error.
+func (redacted *redactedError) Unwrap() error {
+	return redacted.err
+}
@@ -0,0 +1,185 @@
+package envload
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// withSecretScanner registers scanner for the duration of the test.
+func withSecretScanner(t *testing.T, scanner SecretScanner) {
+	t.Helper()
+
+	secretScannersMu.Lock()
+	previous := secretScanners
+	secretScannersMu.Unlock()
+
+	RegisterSecretScanner(scanner)
+
+	t.Cleanup(func() {
+		secretScannersMu.Lock()
+		defer secretScannersMu.Unlock()
+
+		secretScanners = previous
+	})
+}
+
+func Test_SecretScanner(t *testing.T) {
+	withSecretScanner(t, func(_, value string) bool {
+		return strings.HasPrefix(value, "AKIA")
+	})
+
+	t.Run("conversion errors", func(t *testing.T) {
+		var config struct {
+			Retries int `env:"RETRIES"`
+		}
+
+		err := populateStruct(map[string]string{"RETRIES": "AKIA1234"}, &config)
+		if !errors.Is(err, strconv.ErrSyntax) {
+			t.Fatalf("Expected strconv.ErrSyntax, got %v", err)
+		}
+
+		if strings.Contains(err.Error(), "AKIA1234") || !strings.Contains(err.Error(), "[REDACTED]") {
+			t.Errorf("Expected value to be redacted, got %v", err)
+		}
+	})
+
+	t.Run("redacted output", func(t *testing.T) {
+		var config struct {
+			Name string `env:"APP_NAME"`
+			Key  string `env:"AWS_KEY"`
+		}
+
+		if err := populateStruct(map[string]string{"APP_NAME": "service", "AWS_KEY": "AKIA1234"}, &config); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		redacted := Redacted(&config)
+		if redacted["AWS_KEY"] != "[REDACTED]" || redacted["APP_NAME"] != "service" {
+			t.Errorf("Expected only AWS_KEY to be redacted, got %v", redacted)
+		}
+	})
+
+	t.Run("parse error excerpts", func(t *testing.T) {
+		dir := writeEnvFiles(t, map[string]string{"app.env": "AWS_KEY=\"AKIA1234\n"})
+
+		_, err := readEnvFile(filepath.Join(dir, "app.env"), options{})
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("Expected *ParseError, got %v", err)
+		}
+
+		if parseErr.Excerpt != "AWS_KEY=[REDACTED]" {
+			t.Errorf("Expected redacted excerpt, got %q", parseErr.Excerpt)
+		}
+	})
+}
+
+func Test_SecretTag_RedactsErrors(t *testing.T) {
+	var config struct {
+		Pin   int         `env:"PIN" secret:"true"`
+		Token Lazy[[]int] `env:"TOKEN" secret:"true"`
+		Hosts []string    `env:"HOSTS" secret:"true" unique:"true"`
+	}
+
+	err := populateStruct(map[string]string{"PIN": "12ab"}, &config)
+	if err == nil || strings.Contains(err.Error(), "12ab") {
+		t.Errorf("Expected secret value to be redacted, got %v", err)
+	}
+
+	err = populateStruct(map[string]string{"HOSTS": "db-secret,db-secret"}, &config)
+	if !errors.Is(err, errDuplicateElement) || strings.Contains(err.Error(), "db-secret") {
+		t.Errorf("Expected redacted duplicate element error, got %v", err)
+	}
+
+	if err := populateStruct(map[string]string{"TOKEN": "1,two"}, &config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := config.Token.Get(); err == nil || strings.Contains(err.Error(), "1,two") {
+		t.Errorf("Expected lazy secret value to be redacted, got %v", err)
+	}
+}
+
+func Test_WithUnsetSecrets(t *testing.T) {
+	type Config struct {
+		Name     string `env:"TEST_UNSET_NAME"`
+		Password string `env:"TEST_UNSET_PASSWORD" secret:"true"`
+		Port     int    `env:"TEST_UNSET_PORT" secret:"true"`
+	}
+
+	t.Setenv("TEST_UNSET_NAME", "service")
+	t.Setenv("TEST_UNSET_PASSWORD", "hunter2")
+	t.Setenv("TEST_UNSET_PORT", "eighty")
+
+	environ := map[string]string{
+		"TEST_UNSET_NAME":     os.Getenv("TEST_UNSET_NAME"),
+		"TEST_UNSET_PASSWORD": os.Getenv("TEST_UNSET_PASSWORD"),
+		"TEST_UNSET_PORT":     os.Getenv("TEST_UNSET_PORT"),
+	}
+
+	var cfg Config
+	if err := Parse(environ, &cfg, WithUnsetSecrets()); err == nil {
+		t.Fatalf("Expected conversion error for TEST_UNSET_PORT")
+	}
+
+	if _, ok := os.LookupEnv("TEST_UNSET_PASSWORD"); !ok {
+		t.Errorf("Expected secrets to be kept when parsing fails")
+	}
+
+	environ["TEST_UNSET_PORT"] = "8080"
+
+	if err := Parse(environ, &cfg, WithUnsetSecrets()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.Password != "hunter2" || cfg.Port != 8080 {
+		t.Errorf("Expected secrets to be loaded, got %+v", cfg)
+	}
+
+	for _, key := range []string{"TEST_UNSET_PASSWORD", "TEST_UNSET_PORT"} {
+		if _, ok := os.LookupEnv(key); ok {
+			t.Errorf("Expected %s to be unset", key)
+		}
+	}
+
+	if os.Getenv("TEST_UNSET_NAME") != "service" {
+		t.Errorf("Expected non-secret TEST_UNSET_NAME to be kept")
+	}
+
+	t.Run("translated key", func(t *testing.T) {
+		t.Setenv("EXT_TEST_UNSET_PASSWORD", "ext-secret")
+
+		var translated Config
+
+		err := Parse(map[string]string{"EXT_TEST_UNSET_PASSWORD": os.Getenv("EXT_TEST_UNSET_PASSWORD")}, &translated,
+			WithKeyTranslation(map[string]string{"EXT_TEST_UNSET_PASSWORD": "TEST_UNSET_PASSWORD"}), WithUnsetSecrets())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if _, ok := os.LookupEnv("EXT_TEST_UNSET_PASSWORD"); ok || translated.Password != "ext-secret" {
+			t.Errorf("Expected EXT_TEST_UNSET_PASSWORD to be read and unset, got %q", translated.Password)
+		}
+	})
+
+	t.Run("profile key", func(t *testing.T) {
+		t.Setenv("PROD_TEST_UNSET_PASSWORD", "prod-secret")
+
+		var profiled Config
+
+		err := Parse(map[string]string{"PROD_TEST_UNSET_PASSWORD": os.Getenv("PROD_TEST_UNSET_PASSWORD")}, &profiled,
+			WithProfilePrefix("PROD_"), WithUnsetSecrets())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if _, ok := os.LookupEnv("PROD_TEST_UNSET_PASSWORD"); ok || profiled.Password != "prod-secret" {
+			t.Errorf("Expected PROD_TEST_UNSET_PASSWORD to be read and unset, got %q", profiled.Password)
+		}
+	})
+}
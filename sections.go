@@ -0,0 +1,93 @@
+package envload
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// [sectionHeaderPattern] matches INI-style section headers: [database], [redis.cache], [].
+	sectionHeaderPattern = regexp.MustCompile(`^\[([A-Za-z0-9_.\- ]*)\]\s*(?:#.*)?$`)
+
+	// [assignmentPattern] captures the indentation, optional export keyword and key of an assignment line.
+	assignmentPattern = regexp.MustCompile(`^(\s*(?:export\s+)?)([A-Za-z_][A-Za-z0-9_.]*)(\s*[=:])(.*)$`)
+)
+
+type (
+	// sectionState rewrites INI-style sectioned content into flat keys, line by line: after
+	// a "[database]" header, "HOST=db" becomes "DATABASE_HOST=db", until the next header.
+	// Section names are canonicalized like [CanonicalKey], and an empty header "[]" returns
+	// to unprefixed keys. It carries the current prefix, and the quote of a multi-line value
+	// being read, from one line to the next.
+	sectionState struct {
+		prefix    string
+		openQuote byte
+	}
+)
+
+// rewrite prefixes the key of line with the current section, or returns false for a
+// section header, which is dropped. Continuation lines of multi-line quoted values are
+// kept as-is.
+func (state *sectionState) rewrite(line envLine) (envLine, bool) {
+	text := strings.TrimRight(string(line.text), "\r\n")
+
+	if state.openQuote != 0 {
+		// Inside a multi-line quoted value: look for its closing quote.
+		if closesQuote(text, state.openQuote) {
+			state.openQuote = 0
+		}
+
+		return line, true
+	}
+
+	if match := sectionHeaderPattern.FindStringSubmatch(strings.TrimSpace(text)); match != nil {
+		state.prefix = ""
+		if name := CanonicalKey(match[1]); name != "" {
+			state.prefix = name + "_"
+		}
+
+		return line, false
+	}
+
+	match := assignmentPattern.FindStringSubmatch(text)
+	if match == nil {
+		return line, true
+	}
+
+	state.openQuote = opensMultilineQuote(match[4])
+
+	if state.prefix != "" {
+		line.text = []byte(match[1] + state.prefix + match[2] + match[3] + match[4] + "\n")
+	}
+
+	return line, true
+}
+
+// opensMultilineQuote returns the quote character of a value that starts a quoted
+// string not closed on the same line, or 0.
+func opensMultilineQuote(value string) byte {
+	value = strings.TrimSpace(value)
+	if value == "" || (value[0] != '"' && value[0] != '\'') {
+		return 0
+	}
+
+	if closesQuote(value[1:], value[0]) {
+		return 0
+	}
+
+	return value[0]
+}
+
+// closesQuote reports whether text contains an unescaped quote character.
+func closesQuote(text string, quote byte) bool {
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case quote:
+			return true
+		}
+	}
+
+	return false
+}
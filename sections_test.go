@@ -0,0 +1,91 @@
+package envload
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_readEnvFile_Sections(t *testing.T) {
+	t.Run("keys are prefixed by section", func(t *testing.T) {
+		dir := writeEnvFiles(t, map[string]string{
+			"app.env": `APP_NAME=service
+
+[database]
+HOST=db.internal
+export PORT=5432
+
+[redis.cache] # shared cache
+HOST=cache.internal
+CERT="-----BEGIN-----
+HOST=not-a-key
+-----END-----"
+
+#if DATABASE_HOST=db.internal
+TLS=true
+#endif
+
+[]
+DEBUG=false
+`,
+		})
+
+		envMap, err := readEnvFile(filepath.Join(dir, "app.env"), options{sections: true})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := map[string]string{
+			"APP_NAME":         "service",
+			"DATABASE_HOST":    "db.internal",
+			"DATABASE_PORT":    "5432",
+			"REDIS_CACHE_HOST": "cache.internal",
+			"REDIS_CACHE_CERT": "-----BEGIN-----\nHOST=not-a-key\n-----END-----",
+			"REDIS_CACHE_TLS":  "true",
+			"DEBUG":            "false",
+		}
+
+		if !reflect.DeepEqual(envMap, expected) {
+			t.Errorf("Expected %v, got %v", expected, envMap)
+		}
+	})
+
+	t.Run("sections apply to included files", func(t *testing.T) {
+		dir := writeEnvFiles(t, map[string]string{
+			"app.env":      "[database]\n#include database.env\n",
+			"database.env": "HOST=db.internal\n",
+		})
+
+		envMap, err := readEnvFile(filepath.Join(dir, "app.env"), options{sections: true})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if envMap["DATABASE_HOST"] != "db.internal" {
+			t.Errorf("Expected DATABASE_HOST=db.internal, got %v", envMap)
+		}
+	})
+
+	t.Run("errors keep original positions", func(t *testing.T) {
+		dir := writeEnvFiles(t, map[string]string{"app.env": "[database]\nHOST=db\nBAD-KEY=1\n"})
+
+		_, err := readEnvFile(filepath.Join(dir, "app.env"), options{sections: true})
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || parseErr.Line != 3 {
+			t.Errorf("Expected a *ParseError on line 3, got %v", err)
+		}
+	})
+
+	t.Run("headers are parse errors without the option", func(t *testing.T) {
+		dir := writeEnvFiles(t, map[string]string{"app.env": "APP_NAME=service\n[database]\nHOST=db\n"})
+
+		_, err := readEnvFile(filepath.Join(dir, "app.env"), options{})
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || parseErr.Line != 2 {
+			t.Errorf("Expected a *ParseError on line 2, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package envload
+
+import (
+	"fmt"
+	"os"
+)
+
+const (
+	// [stdinName] stands for standard input in errors and parse positions.
+	stdinName = "<stdin>"
+)
+
+// LoadFromStdin reads .env content from standard input and maps its values to a struct,
+// so orchestration scripts can pipe rendered configuration straight into the process
+// (`render-config | app --config -`) instead of writing secrets to a temporary file.
+// The content is parsed like a file given to [LoadAndParse]; #include paths are relative
+// to the working directory, and [ParseError] positions name "<stdin>". Unlike LoadAndParse,
+// a failure to read standard input is returned rather than logged, since falling back to
+// defaults would hide a broken pipeline. Standard input is read to the end.
+//
+// Example:
+//
+//	if configPath == "-" {
+//		err = envload.LoadFromStdin(&cfg, envload.WithMaxFileSize(1<<20))
+//	} else {
+//		err = envload.LoadAndParse(configPath, &cfg)
+//	}
+func LoadFromStdin(target any, opts ...Option) error {
+	return load(target, opts, stdinName, false, readStdin)
+}
+
+// readStdin reads and parses the env content piped to standard input.
+func readStdin(config options) (map[string]string, error) {
+	content, err := readAllLimited(stdinName, os.Stdin, config.maxFileSize)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", stdinName, err)
+	}
+
+	// Wipe the piped content on every path, including errors before parsing.
+	defer clear(content)
+
+	reader := newEnvReader(config)
+	defer reader.wipe()
+
+	if err := reader.readContent(stdinName, ".", content, nil); err != nil {
+		return nil, err
+	}
+
+	return reader.parse(stdinName, config)
+}
@@ -0,0 +1,90 @@
+package envload
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// pipeStdin replaces standard input with a pipe holding content for the rest of the test.
+func pipeStdin(t *testing.T, content string) {
+	t.Helper()
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := writer.WriteString(content); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	writer.Close()
+
+	original := os.Stdin
+	os.Stdin = reader
+
+	t.Cleanup(func() {
+		os.Stdin = original
+		reader.Close()
+	})
+}
+
+func Test_LoadFromStdin(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	if err := os.WriteFile(filepath.Join(tempDir, "base.env"), []byte("LOG_LEVEL=warn\n"), 0o600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	pipeStdin(t, "#include base.env\nDATABASE_URL=postgres://db/app\n[cache]\nTTL=5s\n")
+
+	var cfg struct {
+		DatabaseURL string `env:"DATABASE_URL" required:"true"`
+		LogLevel    string `env:"LOG_LEVEL"`
+		CacheTTL    string `env:"CACHE_TTL"`
+		Port        int    `env:"PORT" default:"8080"`
+	}
+
+	if err := LoadFromStdin(&cfg, WithSections()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.DatabaseURL != "postgres://db/app" || cfg.LogLevel != "warn" || cfg.CacheTTL != "5s" || cfg.Port != 8080 {
+		t.Errorf("Unexpected config: %+v", cfg)
+	}
+}
+
+func Test_LoadFromStdin_Errors(t *testing.T) {
+	var cfg struct {
+		Name string `env:"NAME"`
+	}
+
+	t.Run("parse error", func(t *testing.T) {
+		pipeStdin(t, "NAME=ok\nBROKEN='unterminated\n")
+
+		var parseErr *ParseError
+		if err := LoadFromStdin(&cfg); !errors.As(err, &parseErr) || parseErr.File != stdinName || parseErr.Line != 2 {
+			t.Errorf("Expected ParseError at %s:2, got %v", stdinName, err)
+		}
+	})
+
+	t.Run("size limit", func(t *testing.T) {
+		pipeStdin(t, "NAME=a-rather-long-value\n")
+
+		if err := LoadFromStdin(&cfg, WithMaxFileSize(8)); !errors.Is(err, errLimitExceeded) {
+			t.Errorf("Expected errLimitExceeded, got %v", err)
+		}
+	})
+
+	t.Run("closed stdin", func(t *testing.T) {
+		pipeStdin(t, "")
+		os.Stdin.Close()
+
+		if err := LoadFromStdin(&cfg); err == nil {
+			t.Error("Expected read error for closed standard input")
+		}
+	})
+}
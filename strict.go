@@ -0,0 +1,155 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+var (
+	errInvalidTagDefinition = errors.New("invalid tag definition")
+)
+
+// validateTags checks the struct tags of the current field for definitions that have no effect.
+// Used by [WithStrictTags].
+func (resolver *fieldResolver) validateTags() error {
+	tag := resolver.field.Tag
+	envKey, hasEnv := tag.Lookup("env")
+	defaultValue, hasDefault := tag.Lookup("default")
+	required, hasRequired := tag.Lookup("required")
+
+	if hasRequired && required != "true" && required != "false" {
+		return resolver.tagError("required", fmt.Sprintf("value %q must be \"true\" or \"false\"", required))
+	}
+
+	if secret, hasSecret := tag.Lookup("secret"); hasSecret && secret != "true" && secret != "false" {
+		return resolver.tagError("secret", fmt.Sprintf("value %q must be \"true\" or \"false\"", secret))
+	}
+
+	if emptyUnset, hasEmptyUnset := tag.Lookup("emptyunset"); hasEmptyUnset && emptyUnset != "true" && emptyUnset != "false" {
+		return resolver.tagError("emptyunset", fmt.Sprintf("value %q must be \"true\" or \"false\"", emptyUnset))
+	}
+
+	if _, hasPrefix := tag.Lookup("envPrefix"); hasPrefix {
+		// Nested structs never get here, so the tag is misplaced.
+		if resolver.field.Type.Kind() != reflect.Struct {
+			return resolver.tagError("envPrefix", "only struct fields can be nested")
+		}
+
+		if resolver.field.Anonymous {
+			return resolver.tagError("envPrefix", "embedded struct with env tag is not nested")
+		}
+
+		return resolver.tagError("envPrefix", "field is unexported and cannot be set")
+	}
+
+	if preflight, hasPreflight := tag.Lookup("preflight"); hasPreflight {
+		if _, ok := preflightChecks[preflight]; !ok {
+			return resolver.tagError("preflight", fmt.Sprintf("unknown check %q", preflight))
+		}
+	}
+
+	if auto, hasAuto := tag.Lookup("auto"); hasAuto {
+		if auto != autoPort {
+			return resolver.tagError("auto", fmt.Sprintf("unknown mode %q", auto))
+		}
+
+		if _, ok := portNumber(reflect.New(resolver.field.Type).Elem()); !ok {
+			return resolver.tagError("auto", "port only applies to integer fields")
+		}
+	}
+
+	// Lazy[T] fields are checked against T, the type Get converts to.
+	checked := *resolver
+	if valueType, ok := lazyValueType(resolver.field.Type); ok {
+		checked.field.Type = valueType
+	}
+
+	if lazy, hasLazy := tag.Lookup("lazy"); hasLazy {
+		if lazy != "true" && lazy != "false" {
+			return resolver.tagError("lazy", fmt.Sprintf("value %q must be \"true\" or \"false\"", lazy))
+		}
+
+		if lazy == "true" && !isLazyField(resolver.field) {
+			return resolver.tagError("lazy", "only envload.Lazy fields are converted on first use")
+		}
+	}
+
+	if unique, hasUnique := tag.Lookup("unique"); hasUnique {
+		if unique != "true" && unique != "false" {
+			return resolver.tagError("unique", fmt.Sprintf("value %q must be \"true\" or \"false\"", unique))
+		}
+
+		if checked.field.Type.Kind() != reflect.Slice {
+			return resolver.tagError("unique", "only slice fields can be unique")
+		}
+	}
+
+	_, hasAlias := tag.Lookup("alias")
+
+	if _, hasDerive := tag.Lookup("derive"); hasDerive && (hasEnv || hasDefault || hasRequired || hasAlias) {
+		return resolver.tagError("derive", "derived fields cannot have env, default, required or alias tags")
+	}
+
+	if !hasEnv || envKey == "" {
+		if hasDefault || hasRequired || hasAlias {
+			return resolver.tagError("env", "default, required or alias tag without env tag")
+		}
+
+		return nil
+	}
+
+	if resolver.isUnexportedWithEnv() {
+		return resolver.tagError("env", "field is unexported and cannot be set")
+	}
+
+	if hasAlias && slices.Contains(aliasKeys(resolver.field), "") {
+		return resolver.tagError("alias", "empty variable name")
+	}
+
+	if err := checked.validateRules(); err != nil {
+		return err
+	}
+
+	if _, err := checked.normalizeRules(); err != nil {
+		return err
+	}
+
+	if hasDefault && defaultValue != "" {
+		return checked.validateDefault(defaultValue)
+	}
+
+	return nil
+}
+
+// validateDefault converts defaultValue into a scratch value of the field type.
+// Interface fields are skipped so registered constructors never run during validation.
+func (resolver *fieldResolver) validateDefault(defaultValue string) error {
+	if resolver.field.Type.Kind() == reflect.Interface {
+		return nil
+	}
+
+	scratch := fieldResolver{
+		field:      resolver.field,
+		value:      reflect.New(resolver.field.Type).Elem(),
+		rawValue:   defaultValue,
+		inferTypes: resolver.inferTypes,
+	}
+
+	if err := scratch.setValue(); err != nil {
+		return resolver.tagError("default", err.Error())
+	}
+
+	return nil
+}
+
+// isUnexportedWithEnv reports whether the field carries an env tag it can never be populated from.
+func (resolver *fieldResolver) isUnexportedWithEnv() bool {
+	return !resolver.field.IsExported() && resolver.field.Tag.Get("env") != ""
+}
+
+// tagError builds a definition error for the current field.
+func (resolver *fieldResolver) tagError(tagName, reason string) error {
+	return fmt.Errorf("%w: field=%s tag=%s: %s", errInvalidTagDefinition, resolver.field.Name, tagName, reason)
+}
@@ -0,0 +1,161 @@
+package envload
+
+import (
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_WithStrictTags(t *testing.T) {
+	t.Run("valid definitions pass", func(t *testing.T) {
+		var config struct {
+			Port     int           `default:"8080" env:"PORT"`
+			Timeout  time.Duration `default:"5s"   env:"TIMEOUT"   required:"true"`
+			Hosts    []string      `default:"a,b"  env:"HOSTS"`
+			Optional string        `               env:"OPTIONAL"  required:"false"`
+			Token    Lazy[string]  `               env:"TOKEN"     lazy:"true"     validate:"uuid" normalize:"lower"`
+			Skipped  string
+		}
+
+		if err := populateStruct(map[string]string{}, &config, WithStrictTags()); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		target   any
+		expected string
+	}{
+		{
+			name: "default that cannot convert",
+			target: &struct {
+				Port int `default:"eighty" env:"PORT"`
+			}{},
+			expected: "field=Port tag=default",
+		},
+		{
+			name: "required typo",
+			target: &struct {
+				URL string `env:"URL" required:"yes"`
+			}{},
+			expected: "field=URL tag=required",
+		},
+		{
+			name: "secret typo",
+			target: &struct {
+				Password string `env:"PASSWORD" secret:"yes"`
+			}{},
+			expected: "field=Password tag=secret",
+		},
+		{
+			name: "env on unexported field",
+			target: &struct {
+				privateField string `env:"PRIVATE"`
+			}{},
+			expected: "field=privateField tag=env",
+		},
+		{
+			name: "default without env",
+			target: &struct {
+				Port int `default:"8080"`
+			}{},
+			expected: "field=Port tag=env",
+		},
+		{
+			name: "lazy tag on a plain field",
+			target: &struct {
+				Port int `env:"PORT" lazy:"true"`
+			}{},
+			expected: "field=Port tag=lazy",
+		},
+		{
+			name: "lazy rule for another type",
+			target: &struct {
+				Port Lazy[int] `env:"PORT" validate:"uuid"`
+			}{},
+			expected: "field=Port tag=validate",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := populateStruct(map[string]string{"PORT": "80", "URL": "x"}, tc.target, WithStrictTags())
+			if !errors.Is(err, errInvalidTagDefinition) {
+				t.Fatalf("Expected errInvalidTagDefinition, got %v", err)
+			}
+
+			if !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("Expected error to contain '%s', got '%s'", tc.expected, err.Error())
+			}
+		})
+	}
+
+	t.Run("definitions are ignored without the option", func(t *testing.T) {
+		var config struct {
+			URL string `env:"URL" required:"yes"`
+		}
+
+		if err := populateStruct(map[string]string{}, &config); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
+
+func Test_UnexportedFieldWarning(t *testing.T) {
+	loggedWarnings.Clear()
+
+	var output strings.Builder
+
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	var config struct {
+		PublicField  string `env:"PUBLIC"`
+		privateField string `env:"PRIVATE"`
+		internal     string
+	}
+
+	if err := populateStruct(map[string]string{"PUBLIC": "value"}, &config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(output.String(), `Field [privateField] has env tag "PRIVATE" but is unexported`) {
+		t.Errorf("Expected unexported field warning, got %q", output.String())
+	}
+
+	if strings.Contains(output.String(), "internal") {
+		t.Errorf("Expected no warning for untagged unexported field, got %q", output.String())
+	}
+
+	t.Run("logged once per type", func(t *testing.T) {
+		output.Reset()
+
+		var warnings []Warning
+		if err := populateStruct(map[string]string{"PUBLIC": "value"}, &config, WithWarnings(&warnings)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if output.Len() != 0 {
+			t.Errorf("Expected the warning to be logged once, got %q", output.String())
+		}
+
+		if len(warnings) != 1 || warnings[0].Code != warningUnexportedField {
+			t.Errorf("Expected the warning to still be collected, got %+v", warnings)
+		}
+	})
+}
+
+func Test_WithStrictTags_Unique(t *testing.T) {
+	var config struct {
+		Host string `env:"HOST" unique:"true"`
+	}
+
+	err := populateStruct(map[string]string{}, &config, WithStrictTags())
+	if !errors.Is(err, errInvalidTagDefinition) || !strings.Contains(err.Error(), "tag=unique") {
+		t.Errorf("Expected unique tag definition error, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package envload
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type (
+	// structValidator checks a populated config struct, given as a pointer.
+	structValidator func(target any) error
+)
+
+var (
+	structValidatorsMu sync.RWMutex
+	structValidators   = make(map[reflect.Type]structValidator)
+)
+
+// RegisterStructValidator registers fn to check every config of type T once it is
+// populated, for rules spanning several fields or types whose code you do not own.
+// Structs nested with an `envPrefix` tag are checked too, before the struct enclosing them.
+// fn runs after the field rules of the `validate` tag and before preflight checks; its
+// error is returned wrapping errValidationFailed with the type name.
+// Registering a second validator for T replaces the previous one.
+//
+// Example:
+//
+//	envload.RegisterStructValidator(func(cfg *thirdparty.Config) error {
+//		if cfg.TLS && cfg.CertFile == "" {
+//			return errors.New("CertFile is required when TLS is enabled")
+//		}
+//		return nil
+//	})
+func RegisterStructValidator[T any](fn func(cfg *T) error) {
+	structValidatorsMu.Lock()
+	defer structValidatorsMu.Unlock()
+
+	structValidators[reflect.TypeFor[T]()] = func(target any) error {
+		return fn(target.(*T)) //nolint:forcetypeassert // note: keyed by type.
+	}
+}
+
+// validateStructValue runs the validator registered for the type of the struct value, if any.
+func validateStructValue(value reflect.Value) error {
+	structValidatorsMu.RLock()
+	validator, ok := structValidators[value.Type()]
+	structValidatorsMu.RUnlock()
+
+	if !ok {
+		return nil
+	}
+
+	target := value.Addr()
+	if !target.CanInterface() {
+		// An embedded struct of an unexported type: the validator was registered by the
+		// package owning the type, so hand it a usable pointer to the same struct.
+		target = reflect.NewAt(value.Type(), target.UnsafePointer())
+	}
+
+	if err := validator(target.Interface()); err != nil {
+		return fmt.Errorf("%w for %s: %w", errValidationFailed, value.Type(), err)
+	}
+
+	return nil
+}
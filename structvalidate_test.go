@@ -0,0 +1,84 @@
+package envload
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type structValidatorConfig struct {
+	TLS      bool   `env:"TLS"`
+	CertFile string `env:"CERT_FILE"`
+}
+
+func Test_RegisterStructValidator(t *testing.T) {
+	errCertRequired := errors.New("CertFile is required when TLS is enabled")
+
+	RegisterStructValidator(func(cfg *structValidatorConfig) error {
+		if cfg.TLS && cfg.CertFile == "" {
+			return errCertRequired
+		}
+
+		return nil
+	})
+
+	var cfg structValidatorConfig
+
+	if err := Parse(map[string]string{"TLS": "true", "CERT_FILE": "cert.pem"}, &cfg); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	cfg = structValidatorConfig{}
+
+	err := Parse(map[string]string{"TLS": "true"}, &cfg)
+	if !errors.Is(err, errValidationFailed) || !errors.Is(err, errCertRequired) {
+		t.Fatalf("Expected wrapped struct validator error, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "envload.structValidatorConfig") {
+		t.Errorf("Expected error to name the type, got %v", err)
+	}
+
+	var other struct {
+		TLS bool `env:"TLS"`
+	}
+
+	if err := Parse(map[string]string{"TLS": "true"}, &other); err != nil {
+		t.Errorf("Expected validator to apply only to its type, got %v", err)
+	}
+}
+
+type structValidatorLimits struct {
+	MaxConns int `env:"MAX_CONNS"`
+}
+
+func Test_RegisterStructValidator_UnexportedEmbedded(t *testing.T) {
+	type Config struct {
+		structValidatorLimits
+		Name string `env:"APP_NAME"`
+	}
+
+	RegisterStructValidator(func(limits *structValidatorLimits) error {
+		if limits.MaxConns < 1 {
+			return errors.New("MaxConns must be positive")
+		}
+
+		return nil
+	})
+	t.Cleanup(func() {
+		structValidatorsMu.Lock()
+		defer structValidatorsMu.Unlock()
+
+		delete(structValidators, reflect.TypeFor[structValidatorLimits]())
+	})
+
+	var cfg Config
+	if err := Parse(map[string]string{"MAX_CONNS": "4"}, &cfg); err != nil || cfg.MaxConns != 4 {
+		t.Fatalf("Expected the embedded struct to validate, got MaxConns=%d, %v", cfg.MaxConns, err)
+	}
+
+	if err := Parse(map[string]string{"MAX_CONNS": "0"}, &cfg); !errors.Is(err, errValidationFailed) {
+		t.Errorf("Expected the embedded struct validator to fail, got %v", err)
+	}
+}
@@ -0,0 +1,312 @@
+package envload
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+type (
+	// environmentFileState is a state of the systemd EnvironmentFile= parser.
+	environmentFileState int
+
+	// environmentFileParser mirrors systemd's env file parser so values match what the
+	// service itself receives through EnvironmentFile=.
+	environmentFileParser struct {
+		config   options
+		filePath string
+		envMap   map[string]string
+		state    environmentFileState
+		line     int // Current line number.
+		keyLine  int // Line the current assignment started on.
+		key      []byte
+		value    []byte
+		// keyEnd and valueEnd mark where trailing unquoted whitespace starts, or -1.
+		keyEnd   int
+		valueEnd int
+	}
+)
+
+const (
+	// [credentialsDirectoryEnv] names the directory systemd passes credentials in.
+	credentialsDirectoryEnv = "CREDENTIALS_DIRECTORY"
+
+	// [shellEscapable] lists the characters a backslash escapes inside double quotes.
+	shellEscapable = "\"\\`$"
+)
+
+const (
+	statePreKey environmentFileState = iota
+	stateKey
+	statePreValue
+	stateValue
+	stateValueEscape
+	stateSingleQuote
+	stateDoubleQuote
+	stateDoubleQuoteEscape
+	stateComment
+	stateCommentEscape
+)
+
+var (
+	// [systemdEnvNamePattern] matches the variable names systemd accepts.
+	systemdEnvNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// CredentialsMap reads the credentials systemd passes to a service (LoadCredential=,
+// SetCredential=) from $CREDENTIALS_DIRECTORY into the env map form accepted by [Parse].
+// Each file becomes one key, its name canonicalized with [CanonicalKey] (db.password ->
+// DB_PASSWORD) and its content, minus one trailing newline, the value.
+// When the service has no credentials, the variable is unset and an empty map is returned.
+//
+// Example:
+//
+//	creds, err := envload.CredentialsMap()
+//	if err != nil {
+//		return err
+//	}
+//	err = envload.Parse(creds, &cfg)
+func CredentialsMap() (map[string]string, error) {
+	envMap := make(map[string]string)
+
+	dir := os.Getenv(credentialsDirectoryEnv)
+	if dir == "" {
+		return envMap, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read credentials: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read credential %s: %w", entry.Name(), err)
+		}
+
+		content = bytes.TrimSuffix(bytes.TrimSuffix(content, []byte("\n")), []byte("\r"))
+		envMap[CanonicalKey(entry.Name())] = string(content)
+
+		clear(content)
+	}
+
+	return envMap, nil
+}
+
+// EnvironmentFileMap reads filePath with the rules systemd applies to EnvironmentFile=,
+// which differ from the .env syntax of [LoadAndParse]:
+//   - Only lines starting with "#" or ";" are comments; "#" inside a value is kept.
+//   - There is no "export" keyword and no ${VAR} expansion.
+//   - Unquoted values are trimmed, a backslash escapes the next character and a
+//     backslash at the end of a line continues the value on the next one.
+//   - Single quotes are literal; inside double quotes a backslash only escapes
+//     `"`, `\`, "`" and "$" and joins lines. Adjacent quoted parts are concatenated.
+//   - Lines without "=" are skipped. Invalid variable names and values that are not
+//     valid UTF-8 are skipped with a warning, as systemd does. [WithWarnings] collects
+//     these warnings and [WithSilent] stops them from being logged; other options are ignored.
+//
+// Example:
+//
+//	envMap, err := envload.EnvironmentFileMap("/etc/default/myservice")
+//	if err != nil {
+//		return err
+//	}
+//	err = envload.Parse(envMap, &cfg)
+func EnvironmentFileMap(filePath string, opts ...Option) (map[string]string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(content)
+
+	return parseEnvironmentFile(filePath, content, newOptions(opts)), nil
+}
+
+// parseEnvironmentFile parses content with systemd's EnvironmentFile= rules.
+func parseEnvironmentFile(filePath string, content []byte, config options) map[string]string {
+	parser := &environmentFileParser{
+		config:   config,
+		filePath: filePath,
+		envMap:   make(map[string]string),
+		line:     1,
+		keyEnd:   -1,
+		valueEnd: -1,
+	}
+
+	for _, char := range content {
+		parser.next(char)
+
+		if char == '\n' {
+			parser.line++
+		}
+	}
+
+	switch parser.state {
+	case statePreValue, stateValue, stateValueEscape, stateSingleQuote, stateDoubleQuote, stateDoubleQuoteEscape:
+		// An unterminated value at the end of the file is still assigned.
+		parser.push()
+	}
+
+	return parser.envMap
+}
+
+// next advances the parser by one byte of content.
+func (parser *environmentFileParser) next(char byte) {
+	switch parser.state {
+	case statePreKey:
+		switch {
+		case char == '#' || char == ';':
+			parser.state = stateComment
+		case !isEnvironmentFileSpace(char):
+			parser.state = stateKey
+			parser.keyLine = parser.line
+			parser.key = append(parser.key, char)
+		}
+
+	case stateKey:
+		switch {
+		case char == '\n':
+			// Lines without "=" are ignored.
+			parser.state = statePreKey
+			parser.key = parser.key[:0]
+			parser.keyEnd = -1
+		case char == '=':
+			parser.state = statePreValue
+		default:
+			trackTrailingSpace(&parser.keyEnd, len(parser.key), char)
+			parser.key = append(parser.key, char)
+		}
+
+	case statePreValue:
+		switch {
+		case char == '\n':
+			parser.push()
+		case char == '\'':
+			parser.state = stateSingleQuote
+		case char == '"':
+			parser.state = stateDoubleQuote
+		case char == '\\':
+			parser.state = stateValueEscape
+		case !isEnvironmentFileSpace(char):
+			parser.state = stateValue
+			parser.value = append(parser.value, char)
+		}
+
+	case stateValue:
+		switch char {
+		case '\n':
+			parser.push()
+		case '\\':
+			parser.state = stateValueEscape
+		default:
+			trackTrailingSpace(&parser.valueEnd, len(parser.value), char)
+			parser.value = append(parser.value, char)
+		}
+
+	case stateValueEscape:
+		parser.state = stateValue
+		if char != '\n' {
+			parser.valueEnd = -1
+			parser.value = append(parser.value, char)
+		}
+
+	case stateSingleQuote:
+		if char == '\'' {
+			parser.state = statePreValue
+		} else {
+			parser.value = append(parser.value, char)
+		}
+
+	case stateDoubleQuote:
+		switch char {
+		case '"':
+			parser.state = statePreValue
+		case '\\':
+			parser.state = stateDoubleQuoteEscape
+		default:
+			parser.value = append(parser.value, char)
+		}
+
+	case stateDoubleQuoteEscape:
+		parser.state = stateDoubleQuote
+
+		switch {
+		case strings.IndexByte(shellEscapable, char) >= 0:
+			parser.value = append(parser.value, char)
+		case char != '\n':
+			parser.value = append(parser.value, '\\', char)
+		}
+
+	case stateComment:
+		switch char {
+		case '\\':
+			parser.state = stateCommentEscape
+		case '\n':
+			parser.state = statePreKey
+		}
+
+	case stateCommentEscape:
+		// An escaped newline continues the comment on the next line.
+		parser.state = stateComment
+	}
+}
+
+// push assigns the collected value to the collected key and resets the parser for the next line.
+func (parser *environmentFileParser) push() {
+	key := parser.key
+	if parser.keyEnd >= 0 {
+		key = key[:parser.keyEnd]
+	}
+
+	value := parser.value
+	if parser.valueEnd >= 0 {
+		value = value[:parser.valueEnd]
+	}
+
+	switch {
+	case !systemdEnvNamePattern.Match(key):
+		parser.warn(fmt.Sprintf("%s:%d: invalid variable name %q, ignoring assignment.", parser.filePath, parser.keyLine, key))
+	case !utf8.Valid(value):
+		parser.warn(fmt.Sprintf("%s:%d: value of %s is not valid UTF-8, ignoring assignment.", parser.filePath, parser.keyLine, key))
+	default:
+		parser.envMap[string(key)] = string(value)
+	}
+
+	clear(parser.value)
+
+	parser.state = statePreKey
+	parser.key = parser.key[:0]
+	parser.value = parser.value[:0]
+	parser.keyEnd = -1
+	parser.valueEnd = -1
+}
+
+// warn reports an assignment the parser skips.
+func (parser *environmentFileParser) warn(message string) {
+	parser.config.warn(Warning{Code: warningInvalidAssign, File: parser.filePath, Message: message})
+}
+
+// trackTrailingSpace updates end, the start of a run of trailing whitespace,
+// as char is appended at position length.
+func trackTrailingSpace(end *int, length int, char byte) {
+	switch {
+	case !isEnvironmentFileSpace(char):
+		*end = -1
+	case *end < 0:
+		*end = length
+	}
+}
+
+// isEnvironmentFileSpace reports whether char is whitespace to systemd.
+func isEnvironmentFileSpace(char byte) bool {
+	return char == ' ' || char == '\t' || char == '\r' || char == '\n'
+}
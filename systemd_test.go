@@ -0,0 +1,119 @@
+package envload
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_parseEnvironmentFile(t *testing.T) {
+	content := "# comment \\\n" +
+		"STILL_COMMENT=1\n" +
+		"; another comment\n" +
+		"  PLAIN = value with spaces   \n" +
+		"HASH=a#b\n" +
+		"SINGLE='${HOME} \\n'\n" +
+		"DOUBLE=\"say \\\"hi\\\" \\$x \\n\"\n" +
+		"JOINED='a'\"b\"c\n" +
+		"CONTINUED=first\\\n" +
+		"second\n" +
+		"EMPTY=\n" +
+		"NO_SEPARATOR\n" +
+		"export EXPORTED=1\n" +
+		"1BAD=x\n" +
+		"CRLF=windows\r\n" +
+		"LAST=\"unterminated"
+
+	var warnings []Warning
+
+	got := parseEnvironmentFile("app.env", []byte(content), newOptions([]Option{WithWarnings(&warnings), WithSilent()}))
+	expected := map[string]string{
+		"PLAIN":     "value with spaces",
+		"HASH":      "a#b",
+		"SINGLE":    `${HOME} \n`,
+		"DOUBLE":    `say "hi" $x \n`,
+		"JOINED":    "abc",
+		"CONTINUED": "firstsecond",
+		"EMPTY":     "",
+		"CRLF":      "windows",
+		"LAST":      "unterminated",
+	}
+
+	if !maps.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if len(warnings) != 2 || warnings[0].Code != warningInvalidAssign || warnings[1].Message != `app.env:14: invalid variable name "1BAD", ignoring assignment.` {
+		t.Errorf("Expected warnings for the two invalid names, got %v", warnings)
+	}
+}
+
+func Test_EnvironmentFileMap(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "myservice")
+	if err := os.WriteFile(filePath, []byte("PORT=9090\nOPTS=\"-v --color\"\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	envMap, err := EnvironmentFileMap(filePath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var cfg struct {
+		Port int    `env:"PORT"`
+		Opts string `env:"OPTS"`
+	}
+
+	if err := Parse(envMap, &cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.Port != 9090 || cfg.Opts != "-v --color" {
+		t.Errorf("Unexpected config: %+v", cfg)
+	}
+
+	if _, err := EnvironmentFileMap(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func Test_CredentialsMap(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "db.password"), []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write credential: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "api-token"), []byte("tok"), 0o600); err != nil {
+		t.Fatalf("Failed to write credential: %v", err)
+	}
+
+	if err := os.Mkdir(filepath.Join(dir, "nested"), 0o700); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	t.Setenv(credentialsDirectoryEnv, dir)
+
+	envMap, err := CredentialsMap()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]string{"DB_PASSWORD": "s3cret", "API_TOKEN": "tok"}
+	if !maps.Equal(envMap, expected) {
+		t.Errorf("Expected %v, got %v", expected, envMap)
+	}
+}
+
+func Test_CredentialsMap_NoDirectory(t *testing.T) {
+	t.Setenv(credentialsDirectoryEnv, "")
+
+	envMap, err := CredentialsMap()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(envMap) != 0 {
+		t.Errorf("Expected empty map, got %v", envMap)
+	}
+}
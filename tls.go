@@ -0,0 +1,170 @@
+package envload
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type (
+	// TLSConfig is a ready-made tagged struct for TLS settings.
+	// Certificates and keys can be given as file paths or inline PEM, not both.
+	//
+	// Example:
+	//
+	//	var cfg envload.TLSConfig
+	//	if err := envload.LoadAndParse(".env", &cfg); err != nil { ... }
+	//	tlsConfig, err := cfg.Build()
+	TLSConfig struct {
+		CertFile           string `env:"TLS_CERT_FILE"`
+		KeyFile            string `env:"TLS_KEY_FILE"`
+		CertPEM            string `env:"TLS_CERT_PEM"`
+		KeyPEM             string `env:"TLS_KEY_PEM"              secret:"true"`
+		CAFile             string `env:"TLS_CA_FILE"`
+		CAPEM              string `env:"TLS_CA_PEM"`
+		ServerName         string `env:"TLS_SERVER_NAME"`
+		MinVersion         string `env:"TLS_MIN_VERSION"          default:"1.2"`
+		ClientAuth         string `env:"TLS_CLIENT_AUTH"          default:"none"`
+		InsecureSkipVerify bool   `env:"TLS_INSECURE_SKIP_VERIFY" default:"false"`
+	}
+)
+
+var (
+	errInvalidTLSConfig = errors.New("invalid tls config")
+
+	// [tlsVersions] maps accepted TLS_MIN_VERSION values to tls constants.
+	tlsVersions = map[string]uint16{
+		"1.0": tls.VersionTLS10,
+		"1.1": tls.VersionTLS11,
+		"1.2": tls.VersionTLS12,
+		"1.3": tls.VersionTLS13,
+	}
+
+	// [tlsClientAuthTypes] maps accepted TLS_CLIENT_AUTH values to tls constants.
+	tlsClientAuthTypes = map[string]tls.ClientAuthType{
+		"none":               tls.NoClientCert,
+		"request":            tls.RequestClientCert,
+		"require":            tls.RequireAnyClientCert,
+		"verify-if-given":    tls.VerifyClientCertIfGiven,
+		"require-and-verify": tls.RequireAndVerifyClientCert,
+	}
+)
+
+// Build validates the settings and returns the matching *tls.Config.
+// The CA bundle, when given, is used both as RootCAs (verifying servers)
+// and ClientCAs (verifying clients).
+func (config TLSConfig) Build() (*tls.Config, error) {
+	minVersion, ok := tlsVersions[strings.TrimSpace(config.MinVersion)]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported min version %q", errInvalidTLSConfig, config.MinVersion)
+	}
+
+	clientAuth, ok := tlsClientAuthTypes[strings.ToLower(strings.TrimSpace(config.ClientAuth))]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported client auth %q", errInvalidTLSConfig, config.ClientAuth)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         minVersion,
+		ClientAuth:         clientAuth,
+		ServerName:         config.ServerName,
+		InsecureSkipVerify: config.InsecureSkipVerify, //nolint:gosec // note: Explicitly opted into via TLS_INSECURE_SKIP_VERIFY.
+	}
+
+	certificate, err := config.loadCertificate()
+	if err != nil {
+		return nil, err
+	}
+
+	if certificate != nil {
+		tlsConfig.Certificates = []tls.Certificate{*certificate}
+	}
+
+	pool, err := config.loadCAPool()
+	if err != nil {
+		return nil, err
+	}
+
+	if pool != nil {
+		tlsConfig.RootCAs = pool
+		tlsConfig.ClientCAs = pool
+	}
+
+	if clientAuth >= tls.VerifyClientCertIfGiven && pool == nil {
+		return nil, fmt.Errorf("%w: client auth %q requires a CA", errInvalidTLSConfig, config.ClientAuth)
+	}
+
+	return tlsConfig, nil
+}
+
+// loadCertificate loads the key pair from files or inline PEM.
+// It returns nil when no certificate is configured.
+func (config TLSConfig) loadCertificate() (*tls.Certificate, error) {
+	hasFiles := config.CertFile != "" || config.KeyFile != ""
+	hasPEM := config.CertPEM != "" || config.KeyPEM != ""
+
+	switch {
+	case hasFiles && hasPEM:
+		return nil, fmt.Errorf("%w: set either cert/key files or cert/key PEM, not both", errInvalidTLSConfig)
+
+	case hasFiles:
+		if config.CertFile == "" || config.KeyFile == "" {
+			return nil, fmt.Errorf("%w: cert file and key file must be set together", errInvalidTLSConfig)
+		}
+
+		certificate, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidTLSConfig, err)
+		}
+
+		return &certificate, nil
+
+	case hasPEM:
+		if config.CertPEM == "" || config.KeyPEM == "" {
+			return nil, fmt.Errorf("%w: cert PEM and key PEM must be set together", errInvalidTLSConfig)
+		}
+
+		certificate, err := tls.X509KeyPair([]byte(config.CertPEM), []byte(config.KeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidTLSConfig, err)
+		}
+
+		return &certificate, nil
+
+	default:
+		return nil, nil //nolint:nilnil // note: No certificate configured is a valid state.
+	}
+}
+
+// loadCAPool builds a certificate pool from the CA file or inline PEM.
+// It returns nil when no CA is configured.
+func (config TLSConfig) loadCAPool() (*x509.CertPool, error) {
+	if config.CAFile != "" && config.CAPEM != "" {
+		return nil, fmt.Errorf("%w: set either CA file or CA PEM, not both", errInvalidTLSConfig)
+	}
+
+	caPEM := []byte(config.CAPEM)
+
+	if config.CAFile != "" {
+		content, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidTLSConfig, err)
+		}
+
+		caPEM = content
+	}
+
+	if len(caPEM) == 0 {
+		return nil, nil
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("%w: no certificates found in CA", errInvalidTLSConfig)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,145 @@
+package envload
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// generateTestCertificate returns a self-signed certificate and key in PEM form.
+func generateTestCertificate(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "envload test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return string(certPEM), string(keyPEM)
+}
+
+func Test_TLSConfig_Build(t *testing.T) {
+	certPEM, keyPEM := generateTestCertificate(t)
+
+	t.Run("defaults", func(t *testing.T) {
+		var config TLSConfig
+		if err := Parse(map[string]string{}, &config); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		tlsConfig, err := config.Build()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if tlsConfig.MinVersion != tls.VersionTLS12 || tlsConfig.ClientAuth != tls.NoClientCert {
+			t.Errorf("Unexpected defaults: min=%x auth=%v", tlsConfig.MinVersion, tlsConfig.ClientAuth)
+		}
+	})
+
+	t.Run("inline PEM with mutual TLS", func(t *testing.T) {
+		envMap := map[string]string{
+			"TLS_CERT_PEM":    certPEM,
+			"TLS_KEY_PEM":     keyPEM,
+			"TLS_CA_PEM":      certPEM,
+			"TLS_MIN_VERSION": "1.3",
+			"TLS_CLIENT_AUTH": "require-and-verify",
+		}
+
+		var config TLSConfig
+		if err := Parse(envMap, &config); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		tlsConfig, err := config.Build()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(tlsConfig.Certificates) != 1 || tlsConfig.ClientCAs == nil || tlsConfig.RootCAs == nil {
+			t.Errorf("Expected certificate and CA pools to be set")
+		}
+
+		if tlsConfig.MinVersion != tls.VersionTLS13 || tlsConfig.ClientAuth != tls.RequireAndVerifyClientCert {
+			t.Errorf("Unexpected settings: min=%x auth=%v", tlsConfig.MinVersion, tlsConfig.ClientAuth)
+		}
+	})
+
+	t.Run("files", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile := filepath.Join(dir, "tls.crt")
+		keyFile := filepath.Join(dir, "tls.key")
+
+		if err := os.WriteFile(certFile, []byte(certPEM), 0o600); err != nil {
+			t.Fatalf("failed to write cert: %v", err)
+		}
+
+		if err := os.WriteFile(keyFile, []byte(keyPEM), 0o600); err != nil {
+			t.Fatalf("failed to write key: %v", err)
+		}
+
+		config := TLSConfig{CertFile: certFile, KeyFile: keyFile, CAFile: certFile, MinVersion: "1.2", ClientAuth: "none"}
+
+		tlsConfig, err := config.Build()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(tlsConfig.Certificates) != 1 || tlsConfig.RootCAs == nil {
+			t.Errorf("Expected certificate and CA pool to be set")
+		}
+	})
+
+	invalid := []struct {
+		name   string
+		config TLSConfig
+	}{
+		{"unknown min version", TLSConfig{MinVersion: "2.0", ClientAuth: "none"}},
+		{"unknown client auth", TLSConfig{MinVersion: "1.2", ClientAuth: "maybe"}},
+		{"cert without key", TLSConfig{MinVersion: "1.2", ClientAuth: "none", CertPEM: certPEM}},
+		{"files and PEM", TLSConfig{MinVersion: "1.2", ClientAuth: "none", CertPEM: certPEM, KeyPEM: keyPEM, CertFile: "x"}},
+		{"verification without CA", TLSConfig{MinVersion: "1.2", ClientAuth: "require-and-verify"}},
+		{"CA without certificates", TLSConfig{MinVersion: "1.2", ClientAuth: "none", CAPEM: "not a pem"}},
+		{"missing key file", TLSConfig{MinVersion: "1.2", ClientAuth: "none", CertFile: "missing.crt", KeyFile: "missing.key"}},
+	}
+
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.config.Build(); !errors.Is(err, errInvalidTLSConfig) {
+				t.Errorf("Expected errInvalidTLSConfig, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,369 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// validationRule is one comma-separated entry of a `validate` tag: "name" or "name=param".
+	validationRule struct {
+		name  string
+		param string
+	}
+
+	// validatorFunc checks a populated field value against the rule parameter.
+	// Problems with the rule itself are reported wrapping [errInvalidRule].
+	validatorFunc func(value reflect.Value, param string) error
+)
+
+const (
+	// [diveRule] separates rules for a slice or map from rules for each of its elements.
+	diveRule = "dive"
+)
+
+var (
+	errValidationFailed = errors.New("validation failed")
+	errInvalidRule      = errors.New("invalid validation rule")
+
+	customValidatorsMu sync.RWMutex
+	customValidators   = make(map[string]validatorFunc)
+
+	// [validators] maps rule names of the `validate` tag to their checks.
+	validators = map[string]validatorFunc{
+		"len":    validateLength("len", func(length, limit int) bool { return length == limit }, "must be %d"),
+		"minlen": validateLength("minlen", func(length, limit int) bool { return length >= limit }, "must be at least %d"),
+		"maxlen": validateLength("maxlen", func(length, limit int) bool { return length <= limit }, "must be at most %d"),
+		"min":    validateBound("min", func(value, limit float64) bool { return value >= limit }, "at least"),
+		"max":    validateBound("max", func(value, limit float64) bool { return value <= limit }, "at most"),
+
+		"file":      stringValidator("file", checkRegularFile),
+		"dir":       stringValidator("dir", checkDirectory),
+		"readable":  stringValidator("readable", checkReadable),
+		"writable":  stringValidator("writable", checkWritable),
+		privateRule: stringValidator(privateRule, checkPrivate),
+
+		"freeport": validateFreePort,
+
+		"hostname": stringValidator("hostname", checkHostname),
+		"fqdn":     stringValidator("fqdn", checkFQDN),
+		"email":    stringValidator("email", checkEmail),
+		"uuid":     stringValidator("uuid", checkUUID),
+		"base64":   stringValidator("base64", checkBase64),
+	}
+)
+
+// parseValidateTag splits a `validate` tag into its rules.
+// Example: "minlen=1,maxlen=5" -> [{minlen 1} {maxlen 5}].
+func parseValidateTag(tag string) []validationRule {
+	if tag == "" {
+		return nil
+	}
+
+	var rules []validationRule
+
+	for entry := range strings.SplitSeq(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		rules = append(rules, validationRule{name: name, param: param})
+	}
+
+	return rules
+}
+
+// validate applies the rules of the field's `validate` tag to its populated value.
+// Rules after "dive" apply to each slice element or map value instead of the container.
+// Example: `validate:"len=3"` on ETCD_ENDPOINTS=a,b -> "length must be 3, got 2".
+// Pointer fields are checked through the value they point to.
+func (resolver *fieldResolver) validate() error {
+	if resolver.value.Kind() == reflect.Pointer {
+		if resolver.value.IsNil() {
+			return nil
+		}
+
+		pointee := *resolver
+		pointee.field.Type = resolver.field.Type.Elem()
+		pointee.value = resolver.value.Elem()
+
+		return pointee.validate()
+	}
+
+	containerRules, elementRules, err := resolver.splitDive()
+	if err != nil {
+		return err
+	}
+
+	if err := resolver.applyRules(resolver.value, containerRules, ""); err != nil {
+		return err
+	}
+
+	if elementRules == nil {
+		return nil
+	}
+
+	if resolver.value.Kind() == reflect.Map {
+		keys := resolver.value.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(a.String(), b.String())
+		})
+
+		for _, key := range keys {
+			if err := resolver.applyRules(resolver.value.MapIndex(key), elementRules, fmt.Sprintf("key %q: ", key.String())); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for i := range resolver.value.Len() {
+		if err := resolver.applyRules(resolver.value.Index(i), elementRules, fmt.Sprintf("element %d: ", i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyRules checks value against rules; location prefixes failures of elements.
+func (resolver *fieldResolver) applyRules(value reflect.Value, rules []validationRule, location string) error {
+	for _, rule := range rules {
+		if isCrossFieldRule(rule.name) {
+			continue // Compared once all fields are populated, see validateCrossFields.
+		}
+
+		validator, ok := lookupValidator(rule.name)
+		if !ok {
+			return resolver.tagError("validate", fmt.Sprintf("unknown rule %q", rule.name))
+		}
+
+		err := validator(value, rule.param)
+		if errors.Is(err, errInvalidRule) {
+			return resolver.tagError("validate", err.Error())
+		}
+
+		if err != nil {
+			return &ruleError{rule: rule, err: fmt.Errorf("%w for field '%s': %s%w", errValidationFailed, resolver.field.Name, location, err)}
+		}
+	}
+
+	return nil
+}
+
+// splitDive separates the rules of the field's `validate` tag into those for the field
+// itself and, after "dive", those for its elements (nil without "dive").
+// On `secret:"true"` fields, "file" also requires the file not to be world-readable.
+func (resolver *fieldResolver) splitDive() ([]validationRule, []validationRule, error) {
+	rules := parseValidateTag(resolver.field.Tag.Get("validate"))
+
+	if isSecretField(resolver.field) && slices.ContainsFunc(rules, func(rule validationRule) bool { return rule.name == "file" }) {
+		rules = append(rules, validationRule{name: privateRule})
+	}
+
+	index := slices.IndexFunc(rules, func(rule validationRule) bool { return rule.name == diveRule })
+	if index < 0 {
+		return rules, nil, nil
+	}
+
+	// Pointer fields are validated through what they point to.
+	if kind := indirectType(resolver.field.Type).Kind(); kind != reflect.Slice && kind != reflect.Map {
+		return nil, nil, resolver.tagError("validate", "dive only applies to slices and maps, not "+resolver.field.Type.String())
+	}
+
+	elementRules := rules[index+1:]
+	if len(elementRules) == 0 || slices.ContainsFunc(elementRules, func(rule validationRule) bool { return rule.name == diveRule }) {
+		return nil, nil, resolver.tagError("validate", "dive must be followed by element rules and appear once")
+	}
+
+	return rules[:index], elementRules, nil
+}
+
+// validateRules checks that the field's `validate` tag only uses known rules with valid
+// parameters, without judging a value. Used by [WithStrictTags].
+func (resolver *fieldResolver) validateRules() error {
+	containerRules, elementRules, err := resolver.splitDive()
+	if err != nil {
+		return err
+	}
+
+	typ := indirectType(resolver.field.Type)
+
+	if err := resolver.checkRules(typ, containerRules); err != nil {
+		return err
+	}
+
+	if elementRules == nil {
+		return nil
+	}
+
+	return resolver.checkRules(typ.Elem(), elementRules)
+}
+
+// checkRules runs rules against a zero value of typ, reporting only problems with the rules.
+func (resolver *fieldResolver) checkRules(typ reflect.Type, rules []validationRule) error {
+	zero := reflect.New(typ).Elem()
+
+	for _, rule := range rules {
+		if isCrossFieldRule(rule.name) {
+			continue // References are checked by validateCrossFields.
+		}
+
+		validator, ok := validators[rule.name]
+		if !ok {
+			if _, custom := lookupCustomValidator(rule.name); custom {
+				continue // Custom validators are not called with zero values.
+			}
+
+			return resolver.tagError("validate", fmt.Sprintf("unknown rule %q", rule.name))
+		}
+
+		if err := validator(zero, rule.param); errors.Is(err, errInvalidRule) {
+			return resolver.tagError("validate", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// RegisterValidator registers fn as the `validate` tag rule name, so domain checks stay
+// declarative. fn receives the parsed field value (or each element after "dive") and the
+// rule parameter ("" without "="), and its error is reported wrapping the field name.
+// Fields left unset are not validated. Registering the same name twice replaces the
+// previous validator.
+//
+// RegisterValidator panics if name is empty, contains "," or "=", or is a built-in rule.
+//
+// Example:
+//
+//	envload.RegisterValidator("kafka-topic", func(value any, _ string) error {
+//		topic, _ := value.(string)
+//		if !topicPattern.MatchString(topic) {
+//			return fmt.Errorf("%q is not a valid Kafka topic name", topic)
+//		}
+//		return nil
+//	})
+//
+//	type Config struct {
+//		Topic string `env:"KAFKA_TOPIC" validate:"kafka-topic"`
+//	}
+func RegisterValidator(name string, fn func(value any, param string) error) {
+	if name == "" || strings.ContainsAny(name, ",=") {
+		panic(fmt.Sprintf("envload: RegisterValidator: invalid rule name %q", name))
+	}
+
+	if _, ok := validators[name]; ok || name == diveRule || isCrossFieldRule(name) {
+		panic(fmt.Sprintf("envload: RegisterValidator: %q is a built-in rule", name))
+	}
+
+	customValidatorsMu.Lock()
+	defer customValidatorsMu.Unlock()
+
+	customValidators[name] = func(value reflect.Value, param string) error {
+		return fn(value.Interface(), param)
+	}
+}
+
+// lookupValidator returns the built-in or registered validator for the rule name.
+func lookupValidator(name string) (validatorFunc, bool) {
+	if validator, ok := validators[name]; ok {
+		return validator, true
+	}
+
+	return lookupCustomValidator(name)
+}
+
+// lookupCustomValidator returns the validator registered with [RegisterValidator] under name.
+func lookupCustomValidator(name string) (validatorFunc, bool) {
+	customValidatorsMu.RLock()
+	defer customValidatorsMu.RUnlock()
+
+	validator, ok := customValidators[name]
+
+	return validator, ok
+}
+
+// stringValidator builds a validator running check on the content of a string value.
+func stringValidator(name string, check func(value string) error) validatorFunc {
+	return func(value reflect.Value, _ string) error {
+		if value.Kind() != reflect.String {
+			return fmt.Errorf("%w: %s only applies to strings, not %s", errInvalidRule, name, value.Type())
+		}
+
+		if value.String() == "" {
+			return nil // Zero values are only seen when checking rule definitions.
+		}
+
+		return check(value.String())
+	}
+}
+
+// validateLength builds a validator comparing the number of slice elements or map entries
+// with the rule parameter; message describes the expectation for errors.
+func validateLength(name string, accept func(length, limit int) bool, message string) validatorFunc {
+	return func(value reflect.Value, param string) error {
+		limit, err := strconv.Atoi(param)
+		if err != nil || limit < 0 {
+			return fmt.Errorf("%w: %s needs a non-negative integer, got %q", errInvalidRule, name, param)
+		}
+
+		if value.Kind() != reflect.Slice && value.Kind() != reflect.Map {
+			return fmt.Errorf("%w: %s only applies to slices and maps, not %s", errInvalidRule, name, value.Type())
+		}
+
+		if !accept(value.Len(), limit) {
+			return fmt.Errorf("length "+message+", got %d", limit, value.Len())
+		}
+
+		return nil
+	}
+}
+
+// validateBound builds a validator comparing a number (or time.Duration, with a duration
+// parameter such as "1s") with the rule parameter; relation describes it for errors.
+//
+//nolint:exhaustive // note: Other kinds are reported by the default case.
+func validateBound(name string, accept func(value, limit float64) bool, relation string) validatorFunc {
+	return func(value reflect.Value, param string) error {
+		if isDurationType(value.Type()) {
+			limit, err := time.ParseDuration(param)
+			if err != nil {
+				return fmt.Errorf("%w: %s needs a duration for %s, got %q", errInvalidRule, name, value.Type(), param)
+			}
+
+			if !accept(float64(value.Int()), float64(limit)) {
+				return fmt.Errorf("must be %s %s, got %s", relation, limit, time.Duration(value.Int()))
+			}
+
+			return nil
+		}
+
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %s needs a number, got %q", errInvalidRule, name, param)
+		}
+
+		var number float64
+
+		switch value.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			number = float64(value.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			number = float64(value.Uint())
+		case reflect.Float32, reflect.Float64:
+			number = value.Float()
+		default:
+			return fmt.Errorf("%w: %s only applies to numbers and durations, not %s", errInvalidRule, name, value.Type())
+		}
+
+		if !accept(number, limit) {
+			return fmt.Errorf("must be %s %s, got %v", relation, param, value.Interface())
+		}
+
+		return nil
+	}
+}
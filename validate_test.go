@@ -0,0 +1,224 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_ValidateLength(t *testing.T) {
+	type Config struct {
+		Endpoints []string       `env:"ETCD_ENDPOINTS" validate:"len=3"`
+		Brokers   []string       `env:"BROKERS" validate:"minlen=2,maxlen=3"`
+		Weights   map[string]int `env:"WEIGHTS" validate:"maxlen=2"`
+	}
+
+	tests := []struct {
+		name    string
+		envMap  map[string]string
+		message string
+	}{
+		{"valid", map[string]string{"ETCD_ENDPOINTS": "a,b,c", "BROKERS": "k1,k2", "WEIGHTS": "a:1,b:2"}, ""},
+		{"unset fields are not validated", map[string]string{}, ""},
+		{"len", map[string]string{"ETCD_ENDPOINTS": "a,b"}, "field 'Endpoints': length must be 3, got 2"},
+		{"minlen", map[string]string{"BROKERS": "k1"}, "field 'Brokers': length must be at least 2, got 1"},
+		{"maxlen", map[string]string{"BROKERS": "k1,k2,k3,k4"}, "field 'Brokers': length must be at most 3, got 4"},
+		{"map", map[string]string{"WEIGHTS": "a:1,b:2,c:3"}, "field 'Weights': length must be at most 2, got 3"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cfg Config
+
+			err := Parse(test.envMap, &cfg)
+			if test.message == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, errValidationFailed) || !strings.Contains(err.Error(), test.message) {
+				t.Errorf("Expected validation error containing %q, got %v", test.message, err)
+			}
+		})
+	}
+}
+
+func Test_ValidateInvalidRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		target any
+	}{
+		{"unknown rule", &struct {
+			Hosts []string `env:"HOSTS" validate:"size=2"`
+		}{}},
+		{"invalid param", &struct {
+			Hosts []string `env:"HOSTS" validate:"len=two"`
+		}{}},
+		{"not a slice", &struct {
+			Hosts string `env:"HOSTS" validate:"minlen=1"`
+		}{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := Parse(map[string]string{"HOSTS": "a,b"}, test.target); !errors.Is(err, errInvalidTagDefinition) {
+				t.Errorf("Expected errInvalidTagDefinition, got %v", err)
+			}
+
+			if err := Parse(nil, test.target, WithStrictTags()); !errors.Is(err, errInvalidTagDefinition) {
+				t.Errorf("Expected errInvalidTagDefinition under WithStrictTags, got %v", err)
+			}
+		})
+	}
+}
+
+func Test_ValidateBounds(t *testing.T) {
+	type Config struct {
+		Port    int           `env:"PORT" validate:"min=1,max=65535"`
+		Ratio   float64       `env:"RATIO" validate:"max=1"`
+		Timeout time.Duration `env:"TIMEOUT" validate:"min=1s,max=1m"`
+	}
+
+	tests := []struct {
+		name    string
+		envMap  map[string]string
+		message string
+	}{
+		{"valid", map[string]string{"PORT": "8080", "RATIO": "0.5", "TIMEOUT": "30s"}, ""},
+		{"min", map[string]string{"PORT": "0"}, "field 'Port': must be at least 1, got 0"},
+		{"max", map[string]string{"RATIO": "1.5"}, "field 'Ratio': must be at most 1, got 1.5"},
+		{"duration", map[string]string{"TIMEOUT": "2m"}, "field 'Timeout': must be at most 1m0s, got 2m0s"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cfg Config
+
+			err := Parse(test.envMap, &cfg)
+			if test.message == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, errValidationFailed) || !strings.Contains(err.Error(), test.message) {
+				t.Errorf("Expected validation error containing %q, got %v", test.message, err)
+			}
+		})
+	}
+}
+
+func Test_ValidateDive(t *testing.T) {
+	type Config struct {
+		Ports   []int          `env:"PORTS" validate:"minlen=1,dive,min=1,max=65535"`
+		Weights map[string]int `env:"WEIGHTS" validate:"dive,min=1"`
+	}
+
+	tests := []struct {
+		name    string
+		envMap  map[string]string
+		message string
+	}{
+		{"valid", map[string]string{"PORTS": "80,443", "WEIGHTS": "a:1,b:2"}, ""},
+		{"slice element", map[string]string{"PORTS": "80,70000,0"}, "field 'Ports': element 1: must be at most 65535, got 70000"},
+		{"map value", map[string]string{"WEIGHTS": "b:0,a:1,c:0"}, `field 'Weights': key "b": must be at least 1, got 0`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cfg Config
+
+			err := Parse(test.envMap, &cfg, WithStrictTags())
+			if test.message == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, errValidationFailed) || !strings.Contains(err.Error(), test.message) {
+				t.Errorf("Expected validation error containing %q, got %v", test.message, err)
+			}
+		})
+	}
+
+	t.Run("invalid dive", func(t *testing.T) {
+		targets := []any{
+			&struct {
+				Port int `env:"PORT" validate:"dive,min=1"`
+			}{},
+			&struct {
+				Ports []int `env:"PORT" validate:"dive"`
+			}{},
+			&struct {
+				Ports []string `env:"PORT" validate:"dive,min=1"`
+			}{},
+		}
+
+		for _, target := range targets {
+			if err := Parse(map[string]string{"PORT": "1"}, target); !errors.Is(err, errInvalidTagDefinition) {
+				t.Errorf("Expected errInvalidTagDefinition for %T, got %v", target, err)
+			}
+
+			if err := Parse(nil, target, WithStrictTags()); !errors.Is(err, errInvalidTagDefinition) {
+				t.Errorf("Expected errInvalidTagDefinition under WithStrictTags for %T, got %v", target, err)
+			}
+		}
+	})
+}
+
+func Test_RegisterValidator(t *testing.T) {
+	errTopic := errors.New("invalid topic")
+
+	RegisterValidator("test-topic", func(value any, param string) error {
+		topic, _ := value.(string)
+		if !strings.HasPrefix(topic, param+".") {
+			return fmt.Errorf("%w: %q lacks prefix %q", errTopic, topic, param)
+		}
+
+		return nil
+	})
+
+	type Config struct {
+		Topic  string   `env:"TOPIC" validate:"test-topic=orders"`
+		Topics []string `env:"TOPICS" validate:"minlen=1,dive,test-topic=events"`
+	}
+
+	var cfg Config
+
+	if err := Parse(map[string]string{"TOPIC": "orders.created", "TOPICS": "events.a,events.b"}, &cfg, WithStrictTags()); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	err := Parse(map[string]string{"TOPIC": "payments"}, &cfg)
+	if !errors.Is(err, errValidationFailed) || !errors.Is(err, errTopic) || !strings.Contains(err.Error(), "field 'Topic'") {
+		t.Errorf("Expected wrapped custom validator error, got %v", err)
+	}
+
+	err = Parse(map[string]string{"TOPICS": "events.a,orders.b"}, &cfg)
+	if !errors.Is(err, errTopic) || !strings.Contains(err.Error(), "element 1") {
+		t.Errorf("Expected element error from custom validator, got %v", err)
+	}
+}
+
+func Test_RegisterValidatorInvalidName(t *testing.T) {
+	for _, name := range []string{"", "a,b", "a=b", "len", "dive", "gtfield"} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected panic for rule name %q", name)
+				}
+			}()
+
+			RegisterValidator(name, func(any, string) error { return nil })
+		})
+	}
+}
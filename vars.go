@@ -0,0 +1,65 @@
+package envload
+
+import (
+	"reflect"
+	"time"
+)
+
+// Get reads key from envMap and converts it to T with the same rules as struct fields.
+// def is returned when the key is missing or empty, or when conversion fails.
+// Whitespace-only values count as empty unless T is a string type.
+//
+// Example:
+//
+//	port, err := envload.Get(envMap, "PORT", 8080)
+//	hosts, err := envload.Get(envMap, "HOSTS", []string{"localhost"})
+func Get[T any](envMap map[string]string, key string, def T) (T, error) {
+	value := def
+	resolver := fieldResolver{
+		field:  reflect.StructField{Name: key, Type: reflect.TypeFor[T]()},
+		value:  reflect.ValueOf(&value).Elem(),
+		envMap: envMap,
+		envKey: key,
+	}
+
+	rawValue, _ := resolver.lookupKey(envMap, key)
+	if rawValue == "" {
+		return def, nil
+	}
+
+	resolver.rawValue = rawValue
+
+	if err := resolver.setValue(); err != nil {
+		return def, err
+	}
+
+	return value, nil
+}
+
+// GetString returns the value of key, or def when it is missing or empty.
+func GetString(envMap map[string]string, key string, def string) string {
+	value, _ := Get(envMap, key, def) // Strings never fail to convert.
+	return value
+}
+
+// GetInt returns the value of key as an int, or def when it is missing or empty.
+func GetInt(envMap map[string]string, key string, def int) (int, error) {
+	return Get(envMap, key, def)
+}
+
+// GetFloat returns the value of key as a float64, or def when it is missing or empty.
+func GetFloat(envMap map[string]string, key string, def float64) (float64, error) {
+	return Get(envMap, key, def)
+}
+
+// GetBool returns the value of key as a bool, or def when it is missing or empty.
+// Accepts: "true", "false", "1", "0".
+func GetBool(envMap map[string]string, key string, def bool) (bool, error) {
+	return Get(envMap, key, def)
+}
+
+// GetDuration returns the value of key as a time.Duration, or def when it is missing or empty.
+// Example: TIMEOUT=5s -> 5 * time.Second.
+func GetDuration(envMap map[string]string, key string, def time.Duration) (time.Duration, error) {
+	return Get(envMap, key, def)
+}
@@ -0,0 +1,77 @@
+package envload
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Get_Variables(t *testing.T) {
+	envMap := map[string]string{
+		"PORT":    "9090",
+		"RATE":    "0.5",
+		"DEBUG":   "true",
+		"TIMEOUT": "5s",
+		"NAME":    "api",
+		"HOSTS":   "a,b",
+		"EMPTY":   "",
+		"BAD_INT": "abc",
+	}
+
+	t.Run("typed helpers", func(t *testing.T) {
+		port, err := GetInt(envMap, "PORT", 8080)
+		if err != nil || port != 9090 {
+			t.Errorf("Expected 9090, got %d (err: %v)", port, err)
+		}
+
+		rate, err := GetFloat(envMap, "RATE", 1)
+		if err != nil || rate != 0.5 {
+			t.Errorf("Expected 0.5, got %v (err: %v)", rate, err)
+		}
+
+		debug, err := GetBool(envMap, "DEBUG", false)
+		if err != nil || !debug {
+			t.Errorf("Expected true, got %t (err: %v)", debug, err)
+		}
+
+		timeout, err := GetDuration(envMap, "TIMEOUT", time.Second)
+		if err != nil || timeout != 5*time.Second {
+			t.Errorf("Expected 5s, got %v (err: %v)", timeout, err)
+		}
+
+		if name := GetString(envMap, "NAME", "default"); name != "api" {
+			t.Errorf("Expected 'api', got '%s'", name)
+		}
+	})
+
+	t.Run("defaults for missing and empty keys", func(t *testing.T) {
+		tests := Tests[string]{
+			{"missing key", GetString(envMap, "MISSING", "fallback"), "fallback"},
+			{"empty value", GetString(envMap, "EMPTY", "fallback"), "fallback"},
+		}
+
+		tests.runTests(t)
+
+		if port, err := GetInt(nil, "PORT", 8080); err != nil || port != 8080 {
+			t.Errorf("Expected 8080 from nil map, got %d (err: %v)", port, err)
+		}
+	})
+
+	t.Run("generic get", func(t *testing.T) {
+		hosts, err := Get(envMap, "HOSTS", []string{"localhost"})
+		if err != nil || len(hosts) != 2 || hosts[1] != "b" {
+			t.Errorf("Expected [a b], got %v (err: %v)", hosts, err)
+		}
+	})
+
+	t.Run("conversion error returns default", func(t *testing.T) {
+		value, err := GetInt(envMap, "BAD_INT", 7)
+		if err == nil || !strings.Contains(err.Error(), "invalid int for field 'BAD_INT'") {
+			t.Errorf("Expected invalid int error, got %v", err)
+		}
+
+		if value != 7 {
+			t.Errorf("Expected default 7, got %d", value)
+		}
+	})
+}
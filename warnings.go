@@ -0,0 +1,109 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"reflect"
+	"sync"
+)
+
+type (
+	// Warning describes a problem that did not stop loading, such as a missing env file.
+	// Collect warnings with [WithWarnings] to surface them yourself, for example to fail
+	// CI on any warning.
+	Warning struct {
+		Code    string // Kind of warning: "missing_file", "unexported_field" or "invalid_assignment".
+		File    string // Env file concerned, if any.
+		Field   string // Struct field concerned, if any.
+		Message string // Human-readable description, as logged.
+	}
+
+	// warningKey identifies a definition warning: the field at index in the layout of
+	// the target type.
+	warningKey struct {
+		typ   reflect.Type
+		index int
+	}
+)
+
+var (
+	// [loggedWarnings] holds the [warningKey] of every definition warning already logged,
+	// so a type parsed on every request logs each of them once.
+	loggedWarnings sync.Map
+)
+
+const (
+	warningMissingFile     = "missing_file"
+	warningUnexportedField = "unexported_field"
+	warningInvalidAssign   = "invalid_assignment"
+)
+
+// String returns the message of the warning.
+func (warning Warning) String() string {
+	return warning.Message
+}
+
+// warn logs warning unless [WithSilent] is set and, under [WithWarnings], records it
+// in load order.
+func (config options) warn(warning Warning) {
+	if config.warnings != nil {
+		*config.warnings = append(*config.warnings, warning)
+	}
+
+	if !config.silent {
+		logWarning(warning.Message, config.colorEnabled())
+	}
+}
+
+// warnOnce is like warn, but logs the definition warning identified by key only the
+// first time; [WithWarnings] still records it on every load.
+func (config options) warnOnce(key warningKey, warning Warning) {
+	if _, logged := loggedWarnings.LoadOrStore(key, struct{}{}); logged {
+		config.silent = true
+	}
+
+	config.warn(warning)
+}
+
+// missingFileWarning reports that the env file at filePath could not be read. Errors
+// that already name the file, such as a [ParseError], are not prefixed with it again.
+func missingFileWarning(filePath string, err error) Warning {
+	var (
+		parseErr *ParseError
+		pathErr  *fs.PathError
+	)
+
+	message := fmt.Sprintf("Could not read env file [%s: %v]. Using defaults only.", filePath, err)
+	if errors.As(err, &parseErr) || errors.As(err, &pathErr) && pathErr.Path == filePath {
+		message = fmt.Sprintf("Could not read env file [%v]. Using defaults only.", err)
+	}
+
+	return Warning{
+		Code:    warningMissingFile,
+		File:    filePath,
+		Message: message,
+	}
+}
+
+// unexportedFieldWarning reports an env tag on a field that can never be populated.
+func (resolver *fieldResolver) unexportedFieldWarning() Warning {
+	return Warning{
+		Code:  warningUnexportedField,
+		Field: resolver.field.Name,
+		Message: fmt.Sprintf("Field [%s] has env tag %q but is unexported, so it will not be populated.",
+			resolver.field.Name, resolver.field.Tag.Get("env")),
+	}
+}
+
+// colorEnabled reports whether warnings are highlighted: as set by [WithColor], otherwise
+// only when the standard logger writes to a terminal and NO_COLOR is not set.
+func (config options) colorEnabled() bool {
+	if config.color != nil {
+		return *config.color
+	}
+
+	return isTerminal(log.Writer()) && os.Getenv("NO_COLOR") == ""
+}
@@ -0,0 +1,135 @@
+package envload
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_WithWarnings(t *testing.T) {
+	var cfg struct {
+		Port    int `env:"PORT" default:"8080"`
+		private int `env:"PRIVATE"`
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.env")
+
+	var warnings []Warning
+
+	if err := LoadAndParse(missing, &cfg, WithWarnings(&warnings)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %v", warnings)
+	}
+
+	if warnings[0].Code != warningMissingFile || warnings[0].File != missing {
+		t.Errorf("Expected missing file warning first, got %+v", warnings[0])
+	}
+
+	if warnings[1].Code != warningUnexportedField || warnings[1].Field != "private" ||
+		warnings[1].String() != `Field [private] has env tag "PRIVATE" but is unexported, so it will not be populated.` {
+		t.Errorf("Expected unexported field warning, got %+v", warnings[1])
+	}
+
+	_ = cfg.private
+}
+
+func Test_WithSilent(t *testing.T) {
+	var buffer bytes.Buffer
+
+	output := log.Writer()
+	log.SetOutput(&buffer)
+
+	defer log.SetOutput(output)
+
+	var cfg struct {
+		Port int `env:"PORT"`
+	}
+
+	var warnings []Warning
+
+	if err := LoadAndParse(filepath.Join(t.TempDir(), "missing.env"), &cfg, WithSilent(), WithWarnings(&warnings)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if buffer.Len() != 0 {
+		t.Errorf("Expected no log output, got %q", buffer.String())
+	}
+
+	if len(warnings) != 1 {
+		t.Errorf("Expected the warning to still be collected, got %v", warnings)
+	}
+}
+
+func Test_WithColor(t *testing.T) {
+	var buffer bytes.Buffer
+
+	output, flags := log.Writer(), log.Flags()
+	log.SetOutput(&buffer)
+	log.SetFlags(0)
+
+	defer func() {
+		log.SetOutput(output)
+		log.SetFlags(flags)
+	}()
+
+	var cfg struct {
+		Port int `env:"PORT"`
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.env")
+
+	tests := []struct {
+		name   string
+		opts   []Option
+		prefix string
+	}{
+		{"non-terminal writer", nil, "[Warning]: "},
+		{"forced on", []Option{WithColor(true)}, ansiYellow + "[Warning]:" + ansiReset + " "},
+		{"forced off", []Option{WithColor(false)}, "[Warning]: "},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buffer.Reset()
+
+			if err := LoadAndParse(missing, &cfg, test.opts...); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !bytes.HasPrefix(buffer.Bytes(), []byte(test.prefix)) {
+				t.Errorf("Expected log line starting with %q, got %q", test.prefix, buffer.String())
+			}
+		})
+	}
+}
+
+func Test_missingFileWarning(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing.env")
+	malformed := filepath.Join(dir, "bad.env")
+
+	if err := os.WriteFile(malformed, []byte("BAD-KEY=1\n"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	for _, path := range []string{missing, malformed} {
+		var (
+			cfg      struct{}
+			warnings []Warning
+		)
+
+		if err := LoadAndParse(path, &cfg, WithWarnings(&warnings), WithSilent()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(warnings) != 1 || strings.Count(warnings[0].Message, path) != 1 {
+			t.Errorf("Expected one warning naming %s once, got %v", path, warnings)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Weighted is one named entry of a [WeightedList].
+	Weighted struct {
+		Name   string
+		Weight int
+	}
+
+	// WeightedList is an ordered list of weighted names parsed from "a=3,b=1,c",
+	// for load-balancing settings. A name without "=weight" has weight 1.
+	// Weights must be positive and names unique.
+	//
+	// Example:
+	//
+	//	type Config struct {
+	//		Upstreams envload.WeightedList `env:"UPSTREAMS"` // UPSTREAMS=a=3,b=1,c=1
+	//	}
+	WeightedList []Weighted
+)
+
+const (
+	// [defaultWeight] applies to entries written without "=weight".
+	defaultWeight = 1
+)
+
+var (
+	errInvalidWeightedList = errors.New("invalid weighted list")
+)
+
+// ParseWeightedList parses comma-separated name=weight entries.
+func ParseWeightedList(rawValue string) (WeightedList, error) {
+	var list WeightedList
+
+	seen := make(map[string]struct{})
+
+	for entry := range strings.SplitSeq(rawValue, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue // Empty values are filtered like in other slices.
+		}
+
+		name, rawWeight, hasWeight := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+
+		if name == "" {
+			return nil, fmt.Errorf("%w: entry %q has no name", errInvalidWeightedList, entry)
+		}
+
+		if _, ok := seen[name]; ok {
+			return nil, fmt.Errorf("%w: duplicate name %q", errInvalidWeightedList, name)
+		}
+
+		weight := defaultWeight
+
+		if hasWeight {
+			parsed, err := strconv.Atoi(strings.TrimSpace(rawWeight))
+			if err != nil || parsed <= 0 {
+				return nil, fmt.Errorf("%w: weight of %q must be a positive integer, got %q", errInvalidWeightedList, name, rawWeight)
+			}
+
+			weight = parsed
+		}
+
+		seen[name] = struct{}{}
+		list = append(list, Weighted{Name: name, Weight: weight})
+	}
+
+	return list, nil
+}
+
+// Total returns the sum of all weights.
+func (list WeightedList) Total() int {
+	total := 0
+	for _, entry := range list {
+		total += entry.Weight
+	}
+
+	return total
+}
+
+// Weights returns the entries as a name -> weight map.
+func (list WeightedList) Weights() map[string]int {
+	weights := make(map[string]int, len(list))
+	for _, entry := range list {
+		weights[entry.Name] = entry.Weight
+	}
+
+	return weights
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (list *WeightedList) UnmarshalText(text []byte) error {
+	parsed, err := ParseWeightedList(string(text))
+	if err != nil {
+		return err
+	}
+
+	*list = parsed
+
+	return nil
+}
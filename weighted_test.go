@@ -0,0 +1,45 @@
+package envload
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func Test_WeightedList_FieldDecoding(t *testing.T) {
+	envMap := map[string]string{"UPSTREAMS": "a=3, b = 1,,c"}
+
+	var config struct {
+		Upstreams WeightedList `env:"UPSTREAMS"`
+		Default   WeightedList `env:"FALLBACKS" default:"primary=9,secondary=1"`
+	}
+
+	if err := populateStruct(envMap, &config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := Tests[string]{
+		{"order and weights preserved", fmt.Sprint(config.Upstreams), "[{a 3} {b 1} {c 1}]"},
+		{"default", fmt.Sprint(config.Default), "[{primary 9} {secondary 1}]"},
+	}
+
+	tests.runTests(t)
+
+	if config.Upstreams.Total() != 5 {
+		t.Errorf("Expected total 5, got %d", config.Upstreams.Total())
+	}
+
+	if weights := config.Upstreams.Weights(); weights["a"] != 3 || len(weights) != 3 {
+		t.Errorf("Unexpected weights map: %v", weights)
+	}
+}
+
+func Test_ParseWeightedList_Invalid(t *testing.T) {
+	for _, rawValue := range []string{"a=0", "a=-1", "a=x", "=3", "a=1,a=2"} {
+		t.Run(rawValue, func(t *testing.T) {
+			if _, err := ParseWeightedList(rawValue); !errors.Is(err, errInvalidWeightedList) {
+				t.Errorf("Expected errInvalidWeightedList for %q, got %v", rawValue, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package envload
+
+import (
+	"reflect"
+)
+
+var (
+	// [lazyZeroerType] is used to clear secret [Lazy] fields.
+	lazyZeroerType = reflect.TypeFor[lazyZeroer]()
+)
+
+// ZeroSecrets clears every field of target tagged `secret:"true"`, including those of
+// nested `envPrefix` structs, on a best-effort basis, to shorten the lifetime of
+// secrets in memory once they have been handed to the code that needs them. Byte slices
+// (and byte slices within slices) are overwritten with zeros before being released;
+// strings are immutable in Go, so string fields can only be reset to "" and their
+// former contents are left to the garbage collector. [Lazy] fields drop their raw and
+// converted values. Other secret fields are reset to their zero value. ZeroSecrets must
+// not run concurrently with readers of target.
+//
+// Example:
+//
+//	db, err := sql.Open("postgres", cfg.DatabaseURL)
+//	envload.ZeroSecrets(&cfg)
+func ZeroSecrets(target any) {
+	if validateStruct(target) != nil {
+		return
+	}
+
+	value := reflect.ValueOf(target).Elem()
+
+	for _, field := range structFields(value) {
+		if !field.IsExported() || !isSecretField(field.StructField) {
+			continue
+		}
+
+		fieldValue := field.value
+
+		if reflect.PointerTo(field.Type).Implements(lazyZeroerType) {
+			fieldValue.Addr().Interface().(lazyZeroer).zeroLazy() //nolint:forcetypeassert // note: checked with Implements.
+			continue
+		}
+
+		wipeBytes(fieldValue)
+		fieldValue.SetZero()
+	}
+}
+
+// wipeBytes overwrites byte slices reachable through slices and arrays with zeros.
+func wipeBytes(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			if value.Kind() == reflect.Slice {
+				clear(value.Bytes())
+			}
+
+			return
+		}
+
+		for i := range value.Len() {
+			wipeBytes(value.Index(i))
+		}
+
+	default:
+	}
+}
@@ -0,0 +1,78 @@
+package envload
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+func Test_ZeroSecrets(t *testing.T) {
+	type Config struct {
+		Name     string       `env:"APP_NAME"`
+		Password string       `env:"DB_PASSWORD" secret:"true"`
+		Key      []byte       `env:"SIGNING_KEY" secret:"true"`
+		Keys     [][]byte     `secret:"true"`
+		Token    Lazy[string] `env:"TOKEN" secret:"true"`
+		Pin      int          `env:"PIN" secret:"true"`
+	}
+
+	var cfg Config
+
+	envMap := map[string]string{
+		"APP_NAME":    "service",
+		"DB_PASSWORD": "hunter2",
+		"SIGNING_KEY": "97,98,99",
+		"TOKEN":       "secret",
+		"PIN":         "1234",
+	}
+	if err := Parse(envMap, &cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := cfg.Token.Get(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	key := cfg.Key
+	extra := []byte("rotated")
+	cfg.Keys = [][]byte{extra}
+
+	ZeroSecrets(&cfg)
+
+	if cfg.Name != "service" {
+		t.Errorf("Expected non-secret Name to be kept, got %q", cfg.Name)
+	}
+
+	if cfg.Password != "" || cfg.Key != nil || cfg.Keys != nil || cfg.Pin != 0 {
+		t.Errorf("Expected secret fields to be cleared, got %+v", cfg)
+	}
+
+	if !bytes.Equal(key, make([]byte, 3)) || !bytes.Equal(extra, make([]byte, 7)) {
+		t.Errorf("Expected secret bytes to be overwritten, got %q and %q", key, extra)
+	}
+
+	if token, err := cfg.Token.Get(); token != "" || err != nil {
+		t.Errorf("Expected lazy secret to be dropped, got %q, %v", token, err)
+	}
+
+	t.Run("non-struct target is ignored", func(t *testing.T) {
+		ZeroSecrets("config")
+	})
+}
+
+func Test_LoadAndParse_WipesInternalCopies(t *testing.T) {
+	dir := writeEnvFiles(t, map[string]string{"app.env": "DB_PASSWORD=hunter2\n"})
+
+	reader := newEnvReader(options{})
+	if err := reader.readFile(filepath.Join(dir, "app.env"), nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	reader.wipe()
+
+	lines := reader.raw
+
+	if !bytes.Equal(lines[0].text, make([]byte, len(lines[0].text))) {
+		t.Errorf("Expected line text to be wiped, got %q", lines[0].text)
+	}
+}